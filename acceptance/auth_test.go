@@ -36,7 +36,7 @@ var _ = Describe("Auth", func() {
 		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
-		_, _, err = teamDB.SaveConfig(atc.DefaultPipelineName, atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: atc.DefaultPipelineName}, atc.Config{}, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
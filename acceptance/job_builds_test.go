@@ -78,14 +78,14 @@ var _ = Describe("Job Builds", func() {
 				teamID = team.ID
 
 				// job build data
-				_, _, err = teamDB.SaveConfig(pipelineName, atc.Config{
+				_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), db.PipelineUnpaused)
+				}, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
-				savedPipeline, err := teamDB.GetPipelineByName(pipelineName)
+				savedPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 
 				pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -42,7 +42,7 @@ var _ = Describe("Resource Pagination", func() {
 		teamID = team.ID
 
 		// job build data
-		_, _, err = teamDB.SaveConfig(atc.DefaultPipelineName, atc.Config{
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: atc.DefaultPipelineName}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{
 					Name: "job-name",
@@ -56,10 +56,10 @@ var _ = Describe("Resource Pagination", func() {
 			Resources: atc.ResourceConfigs{
 				{Name: "resource-name"},
 			},
-		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		}, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		savedPipeline, err := teamDB.GetPipelineByName(atc.DefaultPipelineName)
+		savedPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: atc.DefaultPipelineName})
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
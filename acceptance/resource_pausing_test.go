@@ -36,7 +36,7 @@ var _ = Describe("Resource Pausing", func() {
 		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
 		teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 		// job build data
-		_, _, err = teamDB.SaveConfig("some-pipeline", atc.Config{
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{
 					Name: "job-name",
@@ -50,10 +50,10 @@ var _ = Describe("Resource Pausing", func() {
 			Resources: atc.ResourceConfigs{
 				{Name: "resource-name"},
 			},
-		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		}, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		savedPipeline, err := teamDB.GetPipelineByName("some-pipeline")
+		savedPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "some-pipeline"})
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
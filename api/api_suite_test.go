@@ -24,6 +24,7 @@ import (
 	"github.com/concourse/atc/api/workerserver/workerserverfakes"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -55,6 +56,7 @@ var (
 	build                         *dbfakes.FakeBuild
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
+	fakeSecrets                   *credsfakes.FakeSecrets
 	configValidationErrorMessages []string
 	configValidationWarnings      []config.Warning
 	peerAddr                      string
@@ -115,6 +117,7 @@ var _ = BeforeEach(func() {
 
 	fakeSchedulerFactory = new(jobserverfakes.FakeSchedulerFactory)
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	fakeSecrets = new(credsfakes.FakeSecrets)
 
 	var err error
 
@@ -163,6 +166,7 @@ var _ = BeforeEach(func() {
 
 		fakeSchedulerFactory,
 		fakeScannerFactory,
+		fakeSecrets,
 
 		sink,
 
@@ -98,11 +98,24 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(userName).To(BeEmpty())
+					})
+
+					Context("when the request is authenticated with basic auth", func() {
+						BeforeEach(func() {
+							request.Header.Del("Authorization")
+							request.SetBasicAuth("some-user", "some-password")
+						})
+
+						It("generates a token for the basic auth user", func() {
+							_, _, _, _, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(userName).To(Equal("some-user"))
+						})
 					})
 				})
 
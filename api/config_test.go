@@ -8,10 +8,12 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
@@ -168,7 +170,7 @@ var _ = Describe("Config API", func() {
 				})
 
 				It("calls get config with the correct arguments", func() {
-					Expect(teamDB.GetConfigArgsForCall(0)).To(Equal("something-else"))
+					Expect(teamDB.GetConfigArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "something-else"}))
 				})
 			})
 
@@ -219,6 +221,77 @@ var _ = Describe("Config API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config?raw=true", func() {
+		var (
+			response *http.Response
+		)
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "something-else",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.URL.RawQuery = "raw=true"
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the config template can be loaded", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigTemplateReturns(
+						atc.RawConfig(`{"resources":[{"name":"((name))"}]}`),
+						template.Variables{"name": "some-resource"},
+						1,
+						nil,
+					)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the config version as X-Concourse-Config-Version", func() {
+					Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("1"))
+				})
+
+				It("returns the uninterpolated config and the vars", func() {
+					var actualConfigResponse atc.ConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(actualConfigResponse).To(Equal(atc.ConfigResponse{
+						Template: atc.RawConfig(`{"resources":[{"name":"((name))"}]}`),
+						Vars:     template.Variables{"name": "some-resource"},
+					}))
+				})
+
+				It("does not load the interpolated config", func() {
+					Expect(teamDB.GetConfigCallCount()).To(BeZero())
+					Expect(teamDB.GetConfigTemplateArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "something-else"}))
+				})
+			})
+
+			Context("when getting the config template fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigTemplateReturns(atc.RawConfig(""), nil, 0, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config", func() {
 		var (
 			request  *http.Request
@@ -319,11 +392,138 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							name, savedConfig, _, _, id, pipelineState, savedBy := teamDB.SaveConfigArgsForCall(0)
+							Expect(name).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
+							Expect(savedBy).To(Equal("a-team"))
+						})
+
+						Context("when the token identifies the user", func() {
+							BeforeEach(func() {
+								userContextReader.GetUserNameReturns("some-user", true)
+							})
+
+							It("records the user as having saved it", func() {
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+								_, _, _, _, _, _, savedBy := teamDB.SaveConfigArgsForCall(0)
+								Expect(savedBy).To(Equal("some-user"))
+							})
+						})
+
+						Context("when instance vars are given as query params", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "vars.branch=%22feature%22"
+							})
+
+							It("saves the pipeline instance with the vars", func() {
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+								pipelineRef, _, _, _, _, _, _ := teamDB.SaveConfigArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{
+									Name:         "a-pipeline",
+									InstanceVars: atc.InstanceVars{"branch": "feature"},
+								}))
+							})
+						})
+
+						Context("when the config refers to instance vars", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "vars.branch=%22feature%22"
+								request.Body = gbytes.BufferWithBytes([]byte(`{
+									"resources": [{
+										"name": "some-resource",
+										"type": "git",
+										"source": {"branch": "((branch))"}
+									}]
+								}`))
+							})
+
+							It("interpolates them, saving only the template with the instance vars", func() {
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+								_, savedConfig, savedTemplate, savedVars, _, _, _ := teamDB.SaveConfigArgsForCall(0)
+								Expect(savedConfig.Resources).To(Equal(atc.ResourceConfigs{
+									{
+										Name:   "some-resource",
+										Type:   "git",
+										Source: atc.Source{"branch": "feature"},
+									},
+								}))
+								Expect(string(savedTemplate)).To(ContainSubstring("((branch))"))
+								Expect(savedVars).To(BeNil())
+							})
+						})
+
+						Context("when a var conflicts with an instance var", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "vars.branch=%22feature%22"
+
+								body := &bytes.Buffer{}
+								writer := multipart.NewWriter(body)
+
+								configWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-type": {"application/json"},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								configPayload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = configWriter.Write(configPayload)
+								Expect(err).NotTo(HaveOccurred())
+
+								varsWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-type":        {"application/x-yaml"},
+										"Content-Disposition": {`form-data; name="vars"`},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = varsWriter.Write([]byte("branch: master\n"))
+								Expect(err).NotTo(HaveOccurred())
+
+								writer.Close()
+
+								request.Header.Set("Content-Type", writer.FormDataContentType())
+								request.Body = gbytes.BufferWithBytes(body.Bytes())
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("returns error JSON", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"var branch conflicts with the instance var of the same name"
+										]
+									}`))
+							})
+
+							It("does not save anything", func() {
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the instance vars are malformed", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "vars.branch=feature"
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not save anything", func() {
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							})
 						})
 
 						Context("and saving it fails", func() {
@@ -401,8 +601,8 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							name, savedConfig, _, _, id, pipelineState, _ := teamDB.SaveConfigArgsForCall(0)
+							Expect(name).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
@@ -411,7 +611,7 @@ var _ = Describe("Config API", func() {
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
 							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := teamDB.SaveConfigArgsForCall(0)
+							_, savedConfig, _, _, _, _, _ := teamDB.SaveConfigArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -448,8 +648,8 @@ jobs:
 							It("saves it", func() {
 								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								name, savedConfig, _, _, id, pipelineState, _ := teamDB.SaveConfigArgsForCall(0)
+								Expect(name).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
 										{
@@ -586,8 +786,8 @@ jobs:
 							It("saves it", func() {
 								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								name, savedConfig, _, _, id, pipelineState, _ := teamDB.SaveConfigArgsForCall(0)
+								Expect(name).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
 								Expect(pipelineState).To(Equal(expectedDBValue))
@@ -659,11 +859,17 @@ jobs:
 									}
 								})
 
-								It("returns warnings", func() {
+								It("returns warnings, followed by lint warnings", func() {
 									Expect(response.StatusCode).To(Equal(http.StatusOK))
 									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
 										"warnings": [
-										  {"type":"deprecation", "message":"deprecated"}
+										  {"type":"deprecation", "message":"deprecated"},
+										  {
+										    "type": "lint",
+										    "code": "ungrouped-job",
+										    "path": "jobs.some-job",
+										    "message": "job 'some-job' is not in any group"
+										  }
 										]
 									}`))
 								})
@@ -738,6 +944,112 @@ jobs:
 							})
 						})
 
+						Context("when vars are specified", func() {
+							BeforeEach(func() {
+								body := &bytes.Buffer{}
+								writer := multipart.NewWriter(body)
+
+								yamlWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-type": {"application/x-yaml"},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = yamlWriter.Write([]byte(`---
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    uri: ((uri))
+    nested: ((nested))
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    attempts: ((attempts))
+`))
+								Expect(err).NotTo(HaveOccurred())
+
+								varsWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-type":        {"application/x-yaml"},
+										"Content-Disposition": {`form-data; name="vars"`},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = varsWriter.Write([]byte(`---
+uri: https://example.com
+nested:
+  key: value
+attempts: 3
+`))
+								Expect(err).NotTo(HaveOccurred())
+
+								writer.Close()
+
+								request.Header.Set("Content-Type", writer.FormDataContentType())
+								request.Body = gbytes.BufferWithBytes(body.Bytes())
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+
+							It("saves the interpolated config along with the template and vars", func() {
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+								name, savedConfig, savedTemplate, savedVars, _, _, _ := teamDB.SaveConfigArgsForCall(0)
+								Expect(name).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+								Expect(savedConfig).To(Equal(atc.Config{
+									Resources: atc.ResourceConfigs{
+										{
+											Name: "some-resource",
+											Type: "some-type",
+											Source: atc.Source{
+												"uri": "https://example.com",
+												"nested": map[string]interface{}{
+													"key": "value",
+												},
+											},
+										},
+									},
+									Jobs: atc.JobConfigs{
+										{
+											Name: "some-job",
+											Plan: atc.PlanSequence{
+												{
+													Get:      "some-resource",
+													Attempts: 3,
+												},
+											},
+										},
+									},
+								}))
+
+								Expect(string(savedTemplate)).To(MatchJSON(`{
+									"resources": [{
+										"name": "some-resource",
+										"type": "some-type",
+										"source": {"uri": "((uri))", "nested": "((nested))"}
+									}],
+									"jobs": [{
+										"name": "some-job",
+										"plan": [{"get": "some-resource", "attempts": "((attempts))"}]
+									}]
+								}`))
+
+								Expect(savedVars).To(Equal(template.Variables{
+									"uri": "https://example.com",
+									"nested": map[string]interface{}{
+										"key": "value",
+									},
+									"attempts": 3,
+								}))
+							})
+						})
+
 						Context("when the config is malformed", func() {
 							Context("JSON", func() {
 								BeforeEach(func() {
@@ -933,4 +1245,517 @@ jobs:
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.ListConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the versions can be loaded", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns([]db.PipelineConfigVersion{
+						{Version: 2, Config: pipelineConfig, SavedBy: "a-team", SavedAt: time.Unix(200, 0)},
+						{Version: 1, Config: pipelineConfig, SavedAt: time.Unix(100, 0)},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the versions without their configs", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"version": 2, "saved_by": "a-team", "saved_at": 200},
+						{"version": 1, "saved_at": 100}
+					]`))
+				})
+
+				It("looks up the versions of the pipeline", func() {
+					Expect(teamDB.GetConfigVersionsArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+				})
+			})
+
+			Context("when loading the versions fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version", func() {
+		var (
+			response      *http.Response
+			configVersion string
+		)
+
+		BeforeEach(func() {
+			configVersion = "3"
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": configVersion,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+						Version:  3,
+						Config:   pipelineConfig,
+						Template: atc.RawConfig(`{"some":"template"}`),
+						Vars:     template.Variables{"some": "var"},
+						SavedBy:  "a-team",
+						SavedAt:  time.Unix(300, 0),
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the version with its config", func() {
+					var actual atc.PipelineConfigVersion
+					err := json.NewDecoder(response.Body).Decode(&actual)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(actual).To(Equal(atc.PipelineConfigVersion{
+						Version:  3,
+						SavedBy:  "a-team",
+						SavedAt:  300,
+						Config:   &pipelineConfig,
+						Template: atc.RawConfig(`{"some":"template"}`),
+						Vars:     template.Variables{"some": "var"},
+					}))
+				})
+
+				It("looks up the requested version", func() {
+					pipelineRef, version := teamDB.GetConfigVersionArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+					Expect(version).To(Equal(3))
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is not a number", func() {
+				BeforeEach(func() {
+					configVersion = "three"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not look it up", func() {
+					Expect(teamDB.GetConfigVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when loading the version fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/diff", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = "from=1&to=2"
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigDiff, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.URL.RawQuery = query
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when both versions exist", func() {
+				BeforeEach(func() {
+					changedConfig := pipelineConfig
+					changedConfig.Jobs = atc.JobConfigs{{Name: "some-other-job"}}
+
+					teamDB.GetConfigVersionStub = func(pipelineRef atc.PipelineRef, version int) (db.PipelineConfigVersion, bool, error) {
+						if version == 1 {
+							return db.PipelineConfigVersion{Version: 1, Config: pipelineConfig}, true, nil
+						}
+
+						return db.PipelineConfigVersion{Version: 2, Config: changedConfig}, true, nil
+					}
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the diff between the versions", func() {
+					var diff atc.ConfigDiff
+					err := json.NewDecoder(response.Body).Decode(&diff)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(diff.From).To(Equal(1))
+					Expect(diff.To).To(Equal(2))
+					Expect(diff.Jobs).To(Equal(atc.ConfigChanges{
+						Added:   []string{"some-other-job"},
+						Removed: []string{"some-job"},
+						Changed: []string{},
+					}))
+					Expect(diff.Resources).To(Equal(atc.ConfigChanges{
+						Added:   []string{},
+						Removed: []string{},
+						Changed: []string{},
+					}))
+				})
+			})
+
+			Context("when one of the versions does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when a version is missing from the query", func() {
+				BeforeEach(func() {
+					query = "from=1"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/lint", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.LintConfig, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/json")
+
+			payload, err := json.Marshal(pipelineConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Body = gbytes.BufferWithBytes(payload)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+
+				configValidationWarnings = []config.Warning{
+					{Type: "deprecation", Message: "deprecated"},
+				}
+			})
+
+			It("returns 200 with the warnings and lint warnings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"warnings": [
+						{"type":"deprecation", "message":"deprecated"},
+						{
+							"type": "lint",
+							"code": "ungrouped-job",
+							"path": "jobs.some-job",
+							"message": "job 'some-job' is not in any group"
+						}
+					]
+				}`))
+			})
+
+			It("does not save anything", func() {
+				Expect(teamDBFactory.GetTeamDBCallCount()).To(BeZero())
+				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					configValidationErrorMessages = []string{"totally invalid"}
+				})
+
+				It("returns 200 with the errors, without linting", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["totally invalid"],
+						"warnings": [
+							{"type":"deprecation", "message":"deprecated"}
+						]
+					}`))
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`{`))
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version/rollback", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.RollbackConfig, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "1",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when a config version is specified", func() {
+				BeforeEach(func() {
+					request.Header.Set(atc.ConfigVersionHeader, "3")
+				})
+
+				Context("when the version to roll back to exists", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+							Version:  1,
+							Config:   pipelineConfig,
+							Template: atc.RawConfig(`{"some":"template"}`),
+							Vars:     template.Variables{"some": "var"},
+						}, true, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("saves the old config as the latest version", func() {
+						Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+						name, savedConfig, savedTemplate, savedVars, id, pipelineState, savedBy := teamDB.SaveConfigArgsForCall(0)
+						Expect(name).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+						Expect(savedConfig).To(Equal(pipelineConfig))
+						Expect(savedTemplate).To(Equal(atc.RawConfig(`{"some":"template"}`)))
+						Expect(savedVars).To(Equal(template.Variables{"some": "var"}))
+						Expect(id).To(Equal(db.ConfigVersion(3)))
+						Expect(pipelineState).To(Equal(db.PipelineNoChange))
+						Expect(savedBy).To(Equal("a-team"))
+					})
+
+					Context("when the token identifies the user", func() {
+						BeforeEach(func() {
+							userContextReader.GetUserNameReturns("some-user", true)
+						})
+
+						It("records the user as having rolled it back", func() {
+							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, savedBy := teamDB.SaveConfigArgsForCall(0)
+							Expect(savedBy).To(Equal("some-user"))
+						})
+					})
+
+					Context("when the old config is no longer valid", func() {
+						BeforeEach(func() {
+							configValidationErrorMessages = []string{"totally invalid"}
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not save it", func() {
+							Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the pipeline is archived", func() {
+					BeforeEach(func() {
+						teamDB.GetPipelineByRefReturns(db.SavedPipeline{Archived: true}, nil)
+						teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+							Version: 1,
+							Config:  pipelineConfig,
+						}, true, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not save anything", func() {
+						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the version to roll back to does not exist", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+
+					It("does not save anything", func() {
+						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when a config version is not specified", func() {
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not save anything", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
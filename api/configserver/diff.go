@@ -0,0 +1,62 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/config"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) GetConfigDiff(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-diff")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	pipelineRef, ok := s.pipelineRef(w, r, logger)
+	if !ok {
+		return
+	}
+
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	fromVersion, found, err := teamDB.GetConfigVersion(pipelineRef, from)
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	toVersion, found, err := teamDB.GetConfigVersion(pipelineRef, to)
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	diff := config.Diff(fromVersion.Config, toVersion.Config)
+	diff.From = from
+	diff.To = to
+
+	json.NewEncoder(w).Encode(diff)
+}
@@ -5,15 +5,27 @@ import (
 	"fmt"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
 
 func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("get-config")
-	pipelineName := rata.Param(r, "pipeline_name")
 	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
-	config, rawConfig, id, err := teamDB.GetConfig(pipelineName)
+
+	pipelineRef, ok := s.pipelineRef(w, r, logger)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Get("raw") == "true" {
+		s.getConfigTemplate(w, teamDB, pipelineRef, logger)
+		return
+	}
+
+	config, rawConfig, id, err := teamDB.GetConfig(pipelineRef)
 	if err != nil {
 		if malformedErr, ok := err.(atc.MalformedConfigError); ok {
 			getConfigResponse := atc.ConfigResponse{
@@ -45,3 +57,19 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		RawConfig: rawConfig,
 	})
 }
+
+func (s *Server) getConfigTemplate(w http.ResponseWriter, teamDB db.TeamDB, pipelineRef atc.PipelineRef, logger lager.Logger) {
+	configTemplate, vars, id, err := teamDB.GetConfigTemplate(pipelineRef)
+	if err != nil {
+		logger.Error("failed-to-get-config-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
+
+	json.NewEncoder(w).Encode(atc.ConfigResponse{
+		Template: configTemplate,
+		Vars:     vars,
+	})
+}
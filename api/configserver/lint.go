@@ -0,0 +1,59 @@
+package configserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/config"
+)
+
+// LintConfig validates and lints the config in the request without saving it.
+// The config is accepted in the same forms as by SaveConfig; problems with it
+// are reported in the response body rather than as a failed request.
+func (s *Server) LintConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("lint-config")
+
+	pipelineConfig, _, _, _, err := saveConfigRequestUnmarshaler(r, nil)
+
+	switch err {
+	case nil:
+	case ErrStatusUnsupportedMediaType:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	case ErrMalformedRequestPayload:
+		session.Info("malformed-request-payload", lager.Data{
+			"content-type": r.Header.Get("Content-Type"),
+		})
+
+		s.handleBadRequest(w, []string{"malformed config"}, session)
+		return
+	case config.ErrCouldNotDecode:
+		s.handleBadRequest(w, []string{"failed to decode config"}, session)
+		return
+	case ErrInvalidPausedValue:
+		s.handleBadRequest(w, []string{"invalid paused value"}, session)
+		return
+	default:
+		if eke, ok := err.(config.ExtraKeysError); ok {
+			s.handleBadRequest(w, []string{eke.Error()}, session)
+			return
+		}
+
+		session.Error("unexpected-error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	warnings, errorMessages := s.validate(pipelineConfig)
+	if len(errorMessages) == 0 {
+		warnings = append(warnings, config.Lint(pipelineConfig)...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{
+		Errors:   errorMessages,
+		Warnings: warnings,
+	}, session)
+}
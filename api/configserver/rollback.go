@@ -0,0 +1,71 @@
+package configserver
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// RollbackConfig saves the config of an earlier version as the pipeline's
+// current config. It is validated and saved just as in SaveConfig, so the
+// current config version must be given in the request. Archived pipelines
+// can't be rolled back, as their earlier configs no longer have credentials.
+func (s *Server) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("rollback-config")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	pipelineRef, ok := s.pipelineRef(w, r, session)
+	if !ok {
+		return
+	}
+
+	version, ok := s.parseConfigVersion(w, r, session)
+	if !ok {
+		return
+	}
+
+	rollbackTo, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, err := teamDB.GetPipelineByRef(pipelineRef)
+	if err != nil && err != sql.ErrNoRows {
+		session.Error("failed-to-get-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pipeline.Archived {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	configVersion, found, err := teamDB.GetConfigVersion(pipelineRef, rollbackTo)
+	if err != nil {
+		session.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.saveConfig(
+		w,
+		r,
+		session,
+		pipelineRef,
+		configVersion.Config,
+		configVersion.Template,
+		configVersion.Vars,
+		version,
+		db.PipelineNoChange,
+	)
+}
@@ -1,7 +1,6 @@
 package configserver
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,9 +12,11 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/configsaver"
 	"github.com/concourse/atc/db"
-	"github.com/mitchellh/mapstructure"
+	"github.com/concourse/atc/template"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
 )
@@ -24,26 +25,9 @@ var (
 	ErrStatusUnsupportedMediaType = errors.New("content-type is not supported")
 	ErrCannotParseContentType     = errors.New("content-type header could not be parsed")
 	ErrMalformedRequestPayload    = errors.New("data in body could not be decoded")
-	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
-	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
 )
 
-type ExtraKeysError struct {
-	extraKeys []string
-}
-
-func (eke ExtraKeysError) Error() string {
-	msg := &bytes.Buffer{}
-
-	fmt.Fprintln(msg, "unknown/extra keys:")
-	for _, unusedKey := range eke.extraKeys {
-		fmt.Fprintf(msg, "  - %s\n", unusedKey)
-	}
-
-	return msg.String()
-}
-
 type SaveConfigResponse struct {
 	Errors   []string         `json:"errors,omitempty"`
 	Warnings []config.Warning `json:"warnings,omitempty"`
@@ -51,21 +35,18 @@ type SaveConfigResponse struct {
 
 func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	session := s.logger.Session("set-config")
-	configVersionStr := r.Header.Get(atc.ConfigVersionHeader)
-	if len(configVersionStr) == 0 {
-		s.handleBadRequest(w, []string{"no config version specified"}, session)
+
+	pipelineRef, ok := s.pipelineRef(w, r, session)
+	if !ok {
 		return
 	}
 
-	var version db.ConfigVersion
-	_, err := fmt.Sscanf(configVersionStr, "%d", &version)
-	if err != nil {
-		session.Error("malformed-config-version", err)
-		s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+	version, ok := s.parseConfigVersion(w, r, session)
+	if !ok {
 		return
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
+	pipelineConfig, configTemplate, vars, pausedState, err := saveConfigRequestUnmarshaler(r, pipelineRef.InstanceVars)
 
 	switch err {
 	case ErrStatusUnsupportedMediaType:
@@ -78,11 +59,11 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
 		return
-	case ErrFailedToConstructDecoder:
+	case config.ErrFailedToConstructDecoder:
 		session.Error("failed-to-construct-decoder", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
-	case ErrCouldNotDecode:
+	case config.ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
 		return
@@ -92,9 +73,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	default:
 		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
-				s.handleBadRequest(w, []string{eke.Error()}, session)
-			} else {
+			switch err.(type) {
+			case config.ExtraKeysError, config.InstanceVarConflictError:
+				s.handleBadRequest(w, []string{err.Error()}, session)
+			default:
 				session.Error("unexpected-error", err)
 				w.WriteHeader(http.StatusInternalServerError)
 			}
@@ -103,29 +85,61 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	warnings, errorMessages := s.validate(config)
-	if len(errorMessages) > 0 {
-		session.Error("ignoring-invalid-config", err)
-		s.handleBadRequest(w, errorMessages, session)
-		return
+	s.saveConfig(w, r, session, pipelineRef, pipelineConfig, configTemplate, vars, version, pausedState)
+}
+
+func (s *Server) parseConfigVersion(w http.ResponseWriter, r *http.Request, session lager.Logger) (db.ConfigVersion, bool) {
+	configVersionStr := r.Header.Get(atc.ConfigVersionHeader)
+	if len(configVersionStr) == 0 {
+		s.handleBadRequest(w, []string{"no config version specified"}, session)
+		return 0, false
+	}
+
+	var version db.ConfigVersion
+	_, err := fmt.Sscanf(configVersionStr, "%d", &version)
+	if err != nil {
+		session.Error("malformed-config-version", err)
+		s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+		return 0, false
 	}
 
-	session.Info("saving")
+	return version, true
+}
 
-	pipelineName := rata.Param(r, "pipeline_name")
+func (s *Server) saveConfig(
+	w http.ResponseWriter,
+	r *http.Request,
+	session lager.Logger,
+	pipelineRef atc.PipelineRef,
+	pipelineConfig atc.Config,
+	configTemplate atc.RawConfig,
+	vars template.Variables,
+	version db.ConfigVersion,
+	pausedState db.PipelinePausedState,
+) {
 	teamName := rata.Param(r, "team_name")
 
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	_, created, err := teamDB.SaveConfig(pipelineName, config, version, pausedState)
+
+	warnings, errorMessages, _, created, err := configsaver.SavePipeline(
+		session,
+		s.validate,
+		pipelineConfig,
+		func() (db.SavedPipeline, bool, error) {
+			return teamDB.SaveConfig(pipelineRef, pipelineConfig, configTemplate, vars, version, pausedState, savedBy(r))
+		},
+	)
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
 	if err != nil {
-		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, "failed to save config: %s", err)
 		return
 	}
 
-	session.Info("saved")
-
 	if created {
 		w.WriteHeader(http.StatusCreated)
 	} else {
@@ -142,6 +156,17 @@ func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string,
 	}, session)
 }
 
+// savedBy names who saved a config in its history: the user, or the team
+// when the request's token does not identify a user.
+func savedBy(r *http.Request) string {
+	userName := auth.GetAuthUserName(r)
+	if userName != "" {
+		return userName
+	}
+
+	return auth.GetAuthTeamName(r)
+}
+
 func (s *Server) writeSaveConfigResponse(w http.ResponseWriter, saveConfigResponse SaveConfigResponse, session lager.Logger) {
 	responseJSON, err := json.Marshal(saveConfigResponse)
 	if err != nil {
@@ -154,7 +179,7 @@ func (s *Server) writeSaveConfigResponse(w http.ResponseWriter, saveConfigRespon
 	w.Write(responseJSON)
 }
 
-func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}) (db.PipelinePausedState, error) {
+func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}, varsStructure interface{}) (db.PipelinePausedState, error) {
 	pausedState := db.PipelineNoChange
 
 	mediaType, params, err := mime.ParseMediaType(contentType)
@@ -206,9 +231,15 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 				} else {
 					return db.PipelineNoChange, ErrInvalidPausedValue
 				}
+			} else if part.FormName() == "vars" {
+				partContentType := part.Header.Get("Content-type")
+				_, err := requestToConfig(partContentType, part, varsStructure, nil)
+				if err != nil {
+					return db.PipelineNoChange, ErrMalformedRequestPayload
+				}
 			} else {
 				partContentType := part.Header.Get("Content-type")
-				_, err := requestToConfig(partContentType, part, configStructure)
+				_, err := requestToConfig(partContentType, part, configStructure, varsStructure)
 				if err != nil {
 					return db.PipelineNoChange, ErrMalformedRequestPayload
 				}
@@ -221,37 +252,18 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 	return pausedState, nil
 }
 
-func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
+func saveConfigRequestUnmarshaler(r *http.Request, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, template.Variables, db.PipelinePausedState, error) {
 	var configStructure interface{}
-	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
+	var varsStructure interface{}
+	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure, &varsStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, err
+		return atc.Config{}, "", nil, db.PipelineNoChange, err
 	}
 
-	var config atc.Config
-	var md mapstructure.Metadata
-	msConfig := &mapstructure.DecoderConfig{
-		Metadata:         &md,
-		Result:           &config,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			atc.SanitizeDecodeHook,
-			atc.VersionConfigDecodeHook,
-		),
-	}
-
-	decoder, err := mapstructure.NewDecoder(msConfig)
+	pipelineConfig, configTemplate, vars, err := config.DecodeConfig(configStructure, varsStructure, instanceVars)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
-	}
-
-	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
-	}
-
-	if len(md.Unused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
+		return atc.Config{}, "", nil, db.PipelineNoChange, err
 	}
 
-	return config, pausedState, nil
+	return pipelineConfig, configTemplate, vars, pausedState, nil
 }
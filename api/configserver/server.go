@@ -1,24 +1,25 @@
 package configserver
 
 import (
+	"net/http"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
 )
 
 type Server struct {
 	logger        lager.Logger
 	teamDBFactory db.TeamDBFactory
-	validate      ConfigValidator
+	validate      config.Validator
 }
 
-type ConfigValidator func(atc.Config) ([]config.Warning, []string)
-
 func NewServer(
 	logger lager.Logger,
 	teamDBFactory db.TeamDBFactory,
-	validator ConfigValidator,
+	validator config.Validator,
 ) *Server {
 	return &Server{
 		logger:        logger,
@@ -26,3 +27,19 @@ func NewServer(
 		validate:      validator,
 	}
 }
+
+// pipelineRef identifies the pipeline of the request by its name and the
+// instance vars given as query params.
+func (s *Server) pipelineRef(w http.ResponseWriter, r *http.Request, logger lager.Logger) (atc.PipelineRef, bool) {
+	instanceVars, err := atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		logger.Info("malformed-instance-vars", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return atc.PipelineRef{}, false
+	}
+
+	return atc.PipelineRef{
+		Name:         rata.Param(r, "pipeline_name"),
+		InstanceVars: instanceVars,
+	}, true
+}
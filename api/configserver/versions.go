@@ -0,0 +1,70 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-versions")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	pipelineRef, ok := s.pipelineRef(w, r, logger)
+	if !ok {
+		return
+	}
+
+	configVersions, err := teamDB.GetConfigVersions(pipelineRef)
+	if err != nil {
+		logger.Error("failed-to-get-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.PipelineConfigVersion, len(configVersions))
+	for i, configVersion := range configVersions {
+		presented[i] = present.PipelineConfigVersion(configVersion)
+	}
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	pipelineRef, ok := s.pipelineRef(w, r, logger)
+	if !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	configVersion, found, err := teamDB.GetConfigVersion(pipelineRef, version)
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	presented := present.PipelineConfigVersion(configVersion)
+	presented.Config = &configVersion.Config
+	presented.Template = configVersion.Template
+	presented.Vars = configVersion.Vars
+
+	json.NewEncoder(w).Encode(presented)
+}
@@ -113,6 +113,8 @@ var _ = Describe("Containers API", func() {
 									ResourceName: "some-resource",
 									WorkerName:   "some-other-worker-guid",
 									Handle:       "some-other-handle",
+									CPULimit:     512,
+									MemoryLimit:  1024,
 								},
 							},
 
@@ -172,7 +174,11 @@ var _ = Describe("Containers API", func() {
 									"validity_in_seconds": 0,
 									"worker_name": "some-other-worker-guid",
 									"pipeline_name": "some-other-pipeline",
-									"resource_name": "some-resource"
+									"resource_name": "some-resource",
+									"container_limits": {
+										"cpu": 512,
+										"memory": 1024
+									}
 								}
 							]
 						`))
@@ -24,6 +24,8 @@ import (
 	"github.com/concourse/atc/api/volumeserver"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/pipelines"
@@ -52,7 +54,7 @@ func NewHandler(
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
 
-	configValidator configserver.ConfigValidator,
+	configValidator config.Validator,
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
 	drain <-chan struct{},
@@ -62,6 +64,7 @@ func NewHandler(
 
 	schedulerFactory jobserver.SchedulerFactory,
 	scannerFactory resourceserver.ScannerFactory,
+	secrets creds.Secrets,
 
 	sink *lager.ReconfigurableSink,
 
@@ -95,8 +98,8 @@ func NewHandler(
 		drain,
 	)
 
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, workerClient)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, secrets)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
 
@@ -114,7 +117,7 @@ func NewHandler(
 
 	volumesServer := volumeserver.NewServer(logger, volumesDB, teamDBFactory)
 
-	teamServer := teamserver.NewServer(logger, teamDBFactory, teamsDB)
+	teamServer := teamserver.NewServer(logger, teamDBFactory, teamsDB, pipelineDBFactory, configValidator)
 
 	infoServer := infoserver.NewServer(logger, version)
 
@@ -122,8 +125,13 @@ func NewHandler(
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
 
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:          http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:         http.HandlerFunc(configServer.SaveConfig),
+		atc.ListConfigVersions: http.HandlerFunc(configServer.ListConfigVersions),
+		atc.GetConfigVersion:   http.HandlerFunc(configServer.GetConfigVersion),
+		atc.GetConfigDiff:      http.HandlerFunc(configServer.GetConfigDiff),
+		atc.RollbackConfig:     http.HandlerFunc(configServer.RollbackConfig),
+		atc.LintConfig:         http.HandlerFunc(configServer.LintConfig),
 
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild, true),
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
@@ -134,20 +142,23 @@ func NewHandler(
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation, false),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents, false),
 
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs, true),        // authorized or public
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob, true),          // authorized or public
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds, true),   // authorized or public
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs, false),  // authorized
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild, true),     // authorized or public
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild, false), // authorized
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob, false),       // authorized
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob, false),     // authorized
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge, true),        // authorized or public
+		atc.ListJobs:             pipelineHandlerFactory.HandlerFor(jobServer.ListJobs, true),              // authorized or public
+		atc.GetJob:               pipelineHandlerFactory.HandlerFor(jobServer.GetJob, true),                // authorized or public
+		atc.ListJobBuilds:        pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds, true),         // authorized or public
+		atc.ListJobInputs:        pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs, false),        // authorized
+		atc.ExplainJobScheduling: pipelineHandlerFactory.HandlerFor(jobServer.ExplainJobScheduling, false), // authorized
+		atc.GetJobBuild:          pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild, true),           // authorized or public
+		atc.CreateJobBuild:       pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild, false),       // authorized
+		atc.PauseJob:             pipelineHandlerFactory.HandlerFor(jobServer.PauseJob, false),             // authorized
+		atc.UnpauseJob:           pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob, false),           // authorized
+		atc.ClearJobCaches:       pipelineHandlerFactory.HandlerFor(jobServer.ClearJobCaches, false),       // authorized
+		atc.JobBadge:             pipelineHandlerFactory.HandlerFor(jobServer.JobBadge, true),              // authorized or public
 
 		atc.ListAllPipelines: http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:    http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.GetPipeline:      http.HandlerFunc(pipelineServer.GetPipeline),
-		atc.DeletePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline, false), // authorized
+		atc.DeletePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline, false),  // authorized
+		atc.ArchivePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline, false), // authorized
 		atc.OrderPipelines:   http.HandlerFunc(pipelineServer.OrderPipelines),
 		atc.PausePipeline:    pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline, false),   // authorized
 		atc.UnpausePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline, false), // authorized
@@ -156,15 +167,18 @@ func NewHandler(
 		atc.GetVersionsDB:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB, false),   // authorized
 		atc.RenamePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline, false),  // authorized
 
-		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources, true),    // authorized or public
-		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource, true),      // authorized or public
-		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource, false),   // authorized
-		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource, false), // authorized
-		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource, false),   // authorized
+		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources, true),                 // authorized or public
+		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource, true),                   // authorized or public
+		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource, false),                // authorized
+		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource, false),              // authorized
+		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource, false),                // authorized
+		atc.CheckResourceWebHook: pipelineHandlerFactory.UnauthenticatedHandlerFor(resourceServer.CheckResourceWebHook), // webhook token
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions, true),          // authorized or public
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion, false),        // authorized
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion, false),       // authorized
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion, false),           // authorized
+		atc.UnpinResourceVersion:          pipelineHandlerFactory.HandlerFor(versionServer.UnpinResourceVersion, false),         // authorized
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput, true),  // authorized or public
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput, true), // authorized or public
 
@@ -187,8 +201,10 @@ func NewHandler(
 
 		atc.ListVolumes: http.HandlerFunc(volumesServer.ListVolumes),
 
-		atc.ListTeams: http.HandlerFunc(teamServer.ListTeams),
-		atc.SetTeam:   http.HandlerFunc(teamServer.SetTeam),
+		atc.ListTeams:  http.HandlerFunc(teamServer.ListTeams),
+		atc.SetTeam:    http.HandlerFunc(teamServer.SetTeam),
+		atc.ExportTeam: http.HandlerFunc(teamServer.ExportTeam),
+		atc.ImportTeam: http.HandlerFunc(teamServer.ImportTeam),
 	}
 
 	results := []http.Handler{}
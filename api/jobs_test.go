@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,13 +9,18 @@ import (
 	"net/http"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 )
 
 var _ = Describe("Jobs API", func() {
@@ -25,7 +31,7 @@ var _ = Describe("Jobs API", func() {
 		pipelineDB = new(dbfakes.FakePipelineDB)
 		pipelineDBFactory.BuildReturns(pipelineDB)
 		expectedSavedPipeline = db.SavedPipeline{}
-		teamDB.GetPipelineByNameReturns(expectedSavedPipeline, nil)
+		teamDB.GetPipelineByRefReturns(expectedSavedPipeline, nil)
 	})
 
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", func() {
@@ -1225,6 +1231,10 @@ var _ = Describe("Jobs API", func() {
 							},
 						},
 
+						SerialGroups: atc.SerialGroupConfigs{
+							{Name: "some-serial-group", MaxInFlight: 2},
+						},
+
 						Resources: atc.ResourceConfigs{
 							{Name: "resource-1", Type: "some-type"},
 							{Name: "resource-2", Type: "some-other-type"},
@@ -1252,7 +1262,7 @@ var _ = Describe("Jobs API", func() {
 					It("triggers using the current config", func() {
 						Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
 
-						_, job, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+						_, job, serialGroups, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
 						Expect(job).To(Equal(atc.JobConfig{
 							Name: "some-job",
 							Plan: atc.PlanSequence{
@@ -1261,6 +1271,9 @@ var _ = Describe("Jobs API", func() {
 								},
 							},
 						}))
+						Expect(serialGroups).To(Equal(atc.SerialGroupConfigs{
+							{Name: "some-serial-group", MaxInFlight: 2},
+						}))
 						Expect(resources).To(Equal(atc.ResourceConfigs{
 							{Name: "resource-1", Type: "some-type"},
 							{Name: "resource-2", Type: "some-other-type"},
@@ -1303,6 +1316,185 @@ var _ = Describe("Jobs API", func() {
 					})
 				})
 
+				Context("when the request chooses input versions", func() {
+					var build *dbfakes.FakeBuild
+
+					requestWithBody := func(body string) *http.Request {
+						request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(body))
+						Expect(err).NotTo(HaveOccurred())
+						return request
+					}
+
+					BeforeEach(func() {
+						request = requestWithBody(`{"inputs": {"some-input": 7}, "force": true}`)
+
+						build = new(dbfakes.FakeBuild)
+						build.IDReturns(42)
+						build.NameReturns("1")
+						build.JobNameReturns("some-job")
+						build.PipelineNameReturns("a-pipeline")
+						build.TeamNameReturns("some-team")
+						build.StatusReturns(db.StatusPending)
+						build.IsManuallyPinnedReturns(true)
+						fakeScheduler.TriggerWithInputsReturns(build, nil, nil)
+					})
+
+					It("triggers with the chosen versions", func() {
+						Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(BeZero())
+						Expect(fakeScheduler.TriggerWithInputsCallCount()).To(Equal(1))
+
+						_, job, _, resources, resourceTypes, versionIDs, force := fakeScheduler.TriggerWithInputsArgsForCall(0)
+						Expect(job.Name).To(Equal("some-job"))
+						Expect(resources).To(Equal(atc.ResourceConfigs{
+							{Name: "resource-1", Type: "some-type"},
+							{Name: "resource-2", Type: "some-other-type"},
+						}))
+						Expect(resourceTypes).To(Equal(atc.ResourceTypes{
+							{Name: "custom-resource", Type: "custom-type"},
+						}))
+						Expect(versionIDs).To(Equal(map[string]int{"some-input": 7}))
+						Expect(force).To(BeTrue())
+					})
+
+					It("returns the build, marked as manually pinned", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 42,
+							"name": "1",
+							"job_name": "some-job",
+							"status": "pending",
+							"url": "/teams/some-team/pipelines/a-pipeline/jobs/some-job/builds/1",
+							"api_url": "/api/v1/builds/42",
+							"pipeline_name": "a-pipeline",
+							"team_name": "some-team",
+							"manually_pinned": true
+						}`))
+					})
+
+					Context("when a version is chosen by its version", func() {
+						BeforeEach(func() {
+							request = requestWithBody(`{"inputs": {"some-input": {"ref": "abc"}}}`)
+						})
+
+						Context("when the version exists", func() {
+							BeforeEach(func() {
+								pipelineDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 9}, true, nil)
+							})
+
+							It("looks up the version of the input's resource", func() {
+								Expect(pipelineDB.GetVersionedResourceByVersionCallCount()).To(Equal(1))
+								version, resourceName := pipelineDB.GetVersionedResourceByVersionArgsForCall(0)
+								Expect(version).To(Equal(atc.Version{"ref": "abc"}))
+								Expect(resourceName).To(Equal("some-input"))
+							})
+
+							It("triggers with the version's ID", func() {
+								Expect(fakeScheduler.TriggerWithInputsCallCount()).To(Equal(1))
+								_, _, _, _, _, versionIDs, force := fakeScheduler.TriggerWithInputsArgsForCall(0)
+								Expect(versionIDs).To(Equal(map[string]int{"some-input": 9}))
+								Expect(force).To(BeFalse())
+							})
+						})
+
+						Context("when the version does not exist", func() {
+							BeforeEach(func() {
+								pipelineDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, nil)
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeScheduler.TriggerWithInputsCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when looking up the version fails", func() {
+							BeforeEach(func() {
+								pipelineDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, errors.New("oh no!"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the input is not one of the job's inputs", func() {
+						BeforeEach(func() {
+							request = requestWithBody(`{"inputs": {"bogus-input": 7}}`)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not trigger the build", func() {
+							Expect(fakeScheduler.TriggerWithInputsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the request is malformed", func() {
+						BeforeEach(func() {
+							request = requestWithBody(`{"inputs": {"some-input": true}}`)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not trigger the build", func() {
+							Expect(fakeScheduler.TriggerWithInputsCallCount()).To(BeZero())
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the chosen version is not a version of the input's resource", func() {
+						BeforeEach(func() {
+							fakeScheduler.TriggerWithInputsReturns(nil, nil, inputmapper.UnknownInputVersionError{Input: "some-input", VersionID: 7})
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the chosen input cannot be resolved", func() {
+						BeforeEach(func() {
+							fakeScheduler.TriggerWithInputsReturns(nil, nil, inputmapper.UnresolvableInputError{Input: "some-input"})
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the chosen versions do not satisfy the job's constraints", func() {
+						BeforeEach(func() {
+							fakeScheduler.TriggerWithInputsReturns(nil, nil, inputmapper.ErrManualInputsNotSatisfiable)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when triggering the build fails", func() {
+						BeforeEach(func() {
+							fakeScheduler.TriggerWithInputsReturns(nil, nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
 				Context("when the job is not present in the config", func() {
 					BeforeEach(func() {
 						pipelineDB.GetConfigReturns(atc.Config{
@@ -1359,9 +1551,9 @@ var _ = Describe("Jobs API", func() {
 			})
 
 			It("looked up the proper pipeline", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-				Expect(pipelineName).To(Equal("some-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -1558,6 +1750,208 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling-explanation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/scheduling-explanation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the config contains the requested job", func() {
+				someJob := atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:      "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"job-a", "job-b"},
+						},
+						{
+							Get:      "some-other-input",
+							Resource: "some-other-resource",
+							Passed:   []string{"job-a"},
+						},
+					},
+				}
+
+				var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+				BeforeEach(func() {
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{someJob},
+					}, 42, true, nil)
+				})
+
+				Context("when explaining the input mapping succeeds", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainInputMappingReturns(algorithm.Explanation{
+							Inputs: []algorithm.InputExplanation{
+								{
+									Name:            "some-input",
+									Versions:        3,
+									Candidates:      1,
+									PinnedVersionID: 2,
+									Passed: []algorithm.PassedExplanation{
+										{JobID: 1, JobName: "job-a", Versions: 2, EliminatedVersionIDs: []int{}},
+										{JobID: 2, JobName: "job-b", Versions: 1, EliminatedVersionIDs: []int{3}},
+									},
+									DisabledVersionIDs: []int{4},
+								},
+								{
+									Name:       "some-other-input",
+									Versions:   1,
+									Candidates: 1,
+									Passed: []algorithm.PassedExplanation{
+										{JobID: 1, JobName: "job-a", Versions: 1, EliminatedVersionIDs: []int{}},
+									},
+									DisabledVersionIDs: []int{},
+								},
+							},
+							Conflicts: []algorithm.ConflictExplanation{
+								{JobID: 1, JobName: "job-a", Inputs: []string{"some-input", "some-other-input"}},
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("explained the input mapping of the job", func() {
+						actualPipelineDB, actualExternalURL := fakeSchedulerFactory.BuildSchedulerArgsForCall(0)
+						Expect(actualPipelineDB).To(Equal(pipelineDB))
+						Expect(actualExternalURL).To(Equal(externalURL))
+
+						Expect(fakeScheduler.ExplainInputMappingCallCount()).To(Equal(1))
+						_, receivedJobConfig := fakeScheduler.ExplainInputMappingArgsForCall(0)
+						Expect(receivedJobConfig).To(Equal(someJob))
+					})
+
+					It("does not save the input mapping", func() {
+						Expect(fakeScheduler.SaveNextInputMappingCallCount()).To(BeZero())
+					})
+
+					It("does not look up the disabled versions of each resource", func() {
+						Expect(pipelineDB.GetDisabledVersionsCallCount()).To(BeZero())
+					})
+
+					It("returns the explanation with the disabled versions and the reason", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"resolved": false,
+							"reason": "inputs some-input, some-other-input have no build of job-a in common",
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"versions": 3,
+									"candidates": 1,
+									"passed": [
+										{"job": "job-a", "versions": 2},
+										{"job": "job-b", "versions": 1, "eliminated_version_ids": [3]}
+									],
+									"pinned_version_id": 2,
+									"disabled_version_ids": [4]
+								},
+								{
+									"name": "some-other-input",
+									"resource": "some-other-resource",
+									"versions": 1,
+									"candidates": 1,
+									"passed": [
+										{"job": "job-a", "versions": 1}
+									]
+								}
+							],
+							"conflicts": [
+								{"job": "job-a", "inputs": ["some-input", "some-other-input"]}
+							]
+						}`))
+					})
+
+					Context("when an input was left out because its pinned version does not exist", func() {
+						BeforeEach(func() {
+							fakeScheduler.ExplainInputMappingReturns(algorithm.Explanation{
+								Inputs: []algorithm.InputExplanation{
+									{Name: "some-input", Versions: 1, Candidates: 1},
+								},
+								Resolved: true,
+							}, nil)
+						})
+
+						It("is not resolved", func() {
+							var explanation atc.SchedulingExplanation
+							err := json.NewDecoder(response.Body).Decode(&explanation)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(explanation.Resolved).To(BeFalse())
+							Expect(explanation.Reason).To(Equal("pinned version of input 'some-other-input' not found"))
+							Expect(explanation.Inputs[1].PinnedVersionNotFound).To(BeTrue())
+						})
+					})
+				})
+
+				Context("when explaining the input mapping fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainInputMappingReturns(algorithm.Explanation{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the config does not contain the requested job", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-bogus-job"},
+						},
+					}, 42, true, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the pipeline is no longer configured", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 0, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -1688,8 +2082,8 @@ var _ = Describe("Jobs API", func() {
 			})
 
 			It("injects the PipelineDB", func() {
-				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-				Expect(pipelineName).To(Equal("some-pipeline"))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -1751,8 +2145,8 @@ var _ = Describe("Jobs API", func() {
 			})
 
 			It("injects the PipelineDB", func() {
-				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-				Expect(pipelineName).To(Equal("some-pipeline"))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -1793,4 +2187,146 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/caches", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the job has caches", func() {
+				var (
+					fakeWorker  *workerfakes.FakeWorker
+					fakeVolume1 *workerfakes.FakeVolume
+					fakeVolume2 *workerfakes.FakeVolume
+				)
+
+				BeforeEach(func() {
+					pipelineDB.GetJobTaskCacheVolumesReturns([]db.SavedVolume{
+						{Volume: db.Volume{Handle: "some-handle-1", WorkerName: "some-worker"}},
+						{Volume: db.Volume{Handle: "some-handle-2", WorkerName: "some-worker"}},
+					}, nil)
+
+					fakeWorker = new(workerfakes.FakeWorker)
+					fakeWorkerClient.GetWorkerReturns(fakeWorker, nil)
+
+					fakeVolume1 = new(workerfakes.FakeVolume)
+					fakeVolume2 = new(workerfakes.FakeVolume)
+					fakeWorker.LookupVolumeStub = func(_ lager.Logger, handle string) (worker.Volume, bool, error) {
+						if handle == "some-handle-1" {
+							return fakeVolume1, true, nil
+						}
+
+						return fakeVolume2, true, nil
+					}
+				})
+
+				It("looks up the caches of the right job", func() {
+					Expect(pipelineDB.GetJobTaskCacheVolumesCallCount()).To(Equal(1))
+					Expect(pipelineDB.GetJobTaskCacheVolumesArgsForCall(0)).To(Equal("job-name"))
+				})
+
+				It("expires the volumes on their worker", func() {
+					Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+
+					Expect(fakeVolume1.ReleaseCallCount()).To(Equal(1))
+					Expect(fakeVolume1.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(time.Minute)))
+
+					Expect(fakeVolume2.ReleaseCallCount()).To(Equal(1))
+					Expect(fakeVolume2.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(time.Minute)))
+				})
+
+				It("forgets about the volumes so that the next build starts a new cache", func() {
+					Expect(pipelineDB.ReapTaskCacheVolumeCallCount()).To(Equal(2))
+					Expect(pipelineDB.ReapTaskCacheVolumeArgsForCall(0)).To(Equal("some-handle-1"))
+					Expect(pipelineDB.ReapTaskCacheVolumeArgsForCall(1)).To(Equal("some-handle-2"))
+				})
+
+				It("returns 200 with the number of caches removed", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{"caches_removed": 2}`))
+				})
+
+				Context("when a volume is no longer on its worker", func() {
+					BeforeEach(func() {
+						fakeWorker.LookupVolumeStub = nil
+						fakeWorker.LookupVolumeReturns(nil, false, nil)
+					})
+
+					It("still forgets about it", func() {
+						Expect(pipelineDB.ReapTaskCacheVolumeCallCount()).To(Equal(2))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{"caches_removed": 2}`))
+					})
+				})
+
+				Context("when the worker cannot be found", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.GetWorkerReturns(nil, errors.New("nope"))
+					})
+
+					It("leaves the volumes to be cleared later", func() {
+						Expect(pipelineDB.ReapTaskCacheVolumeCallCount()).To(BeZero())
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{"caches_removed": 0}`))
+					})
+				})
+
+				Context("when forgetting a volume fails", func() {
+					BeforeEach(func() {
+						pipelineDB.ReapTaskCacheVolumeReturns(errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when getting the caches fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobTaskCacheVolumesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not clear any caches", func() {
+				Expect(pipelineDB.GetJobTaskCacheVolumesCallCount()).To(BeZero())
+			})
+		})
+	})
 })
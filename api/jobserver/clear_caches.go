@@ -0,0 +1,72 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/tedsuo/rata"
+)
+
+// cleared caches linger briefly so that containers which are being created
+// from them at the time are not affected
+const clearedCacheTTL = time.Minute
+
+func (s *Server) ClearJobCaches(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("clear-job-caches")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := rata.Param(r, "job_name")
+
+		volumes, err := pipelineDB.GetJobTaskCacheVolumes(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-task-cache-volumes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		removed := 0
+		for _, savedVolume := range volumes {
+			vLogger := logger.WithData(lager.Data{
+				"worker-name": savedVolume.WorkerName,
+				"handle":      savedVolume.Handle,
+			})
+
+			volumeWorker, err := s.workerClient.GetWorker(savedVolume.WorkerName)
+			if err != nil {
+				vLogger.Info("could-not-locate-worker", lager.Data{"error": err.Error()})
+				continue
+			}
+
+			volume, found, err := volumeWorker.LookupVolume(vLogger, savedVolume.Handle)
+			if err != nil {
+				vLogger.Error("failed-to-lookup-volume", err)
+				continue
+			}
+
+			if found {
+				volume.Release(worker.FinalTTL(clearedCacheTTL))
+			}
+
+			// forget the volume so that the next build starts a fresh cache
+			err = pipelineDB.ReapTaskCacheVolume(savedVolume.Handle)
+			if err != nil {
+				vLogger.Error("failed-to-delete-volume-from-database", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			removed++
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(atc.ClearJobCachesResponse{
+			CachesRemoved: removed,
+		})
+	})
+}
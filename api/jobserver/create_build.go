@@ -3,10 +3,14 @@ package jobserver
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/inputmapper"
 )
 
 func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
@@ -15,7 +19,15 @@ func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
 
 		jobName := r.FormValue(":job_name")
 
-		config, _, found, err := pipelineDB.GetConfig()
+		var request atc.CreateJobBuildRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "malformed request: %s", err)
+			return
+		}
+
+		pipelineConfig, _, found, err := pipelineDB.GetConfig()
 		if err != nil {
 			logger.Error("could-not-get-pipeline-config", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -27,7 +39,7 @@ func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		job, found := config.Jobs.Lookup(jobName)
+		job, found := pipelineConfig.Jobs.Lookup(jobName)
 		if !found {
 			w.WriteHeader(http.StatusNotFound)
 			return
@@ -38,10 +50,47 @@ func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
+		buildScheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
+
+		var build db.Build
+		if len(request.Inputs) == 0 {
+			build, _, err = buildScheduler.TriggerImmediately(logger, job, pipelineConfig.SerialGroups, pipelineConfig.Resources, pipelineConfig.ResourceTypes)
+		} else {
+			var versionIDs map[string]int
+			versionIDs, err = chosenVersionIDs(pipelineDB, job, request.Inputs)
+			if err != nil {
+				if _, ok := err.(invalidInputVersionError); ok {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "%s", err)
+					return
+				}
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, config.Resources, config.ResourceTypes)
+				logger.Error("failed-to-find-chosen-versions", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			build, _, err = buildScheduler.TriggerWithInputs(logger, job, pipelineConfig.SerialGroups, pipelineConfig.Resources, pipelineConfig.ResourceTypes, versionIDs, request.Force)
+		}
 		if err != nil {
+			if _, ok := err.(inputmapper.UnknownInputVersionError); ok {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
+			if _, ok := err.(inputmapper.UnresolvableInputError); ok {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
+			if err == inputmapper.ErrManualInputsNotSatisfiable {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			fmt.Fprintf(w, "failed to trigger: %s", err)
@@ -51,3 +100,46 @@ func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
 		json.NewEncoder(w).Encode(present.Build(build))
 	})
 }
+
+type invalidInputVersionError struct {
+	message string
+}
+
+func (err invalidInputVersionError) Error() string {
+	return err.message
+}
+
+// chosenVersionIDs resolves the versions chosen for the job's inputs to their
+// IDs, looking up those which were given as versions.
+func chosenVersionIDs(pipelineDB db.PipelineDB, job atc.JobConfig, choices map[string]atc.InputVersionChoice) (map[string]int, error) {
+	resources := map[string]string{}
+	for _, input := range config.JobInputs(job) {
+		resources[input.Name] = input.Resource
+	}
+
+	versionIDs := map[string]int{}
+	for inputName, choice := range choices {
+		resourceName, found := resources[inputName]
+		if !found {
+			return nil, invalidInputVersionError{fmt.Sprintf("unknown input '%s'", inputName)}
+		}
+
+		if choice.Version == nil {
+			versionIDs[inputName] = choice.ID
+			continue
+		}
+
+		svr, found, err := pipelineDB.GetVersionedResourceByVersion(choice.Version, resourceName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, invalidInputVersionError{fmt.Sprintf("version of input '%s' not found", inputName)}
+		}
+
+		versionIDs[inputName] = svr.ID
+	}
+
+	return versionIDs, nil
+}
@@ -0,0 +1,48 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ExplainJobScheduling(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("explain-job-scheduling")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		pipelineConfig, _, found, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		jobConfig, found := pipelineConfig.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
+
+		explanation, err := scheduler.ExplainInputMapping(logger, jobConfig)
+		if err != nil {
+			logger.Error("failed-to-explain-input-mapping", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		json.NewEncoder(w).Encode(present.SchedulingExplanation(config.JobInputs(jobConfig), explanation))
+	})
+}
@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -26,7 +27,7 @@ var _ = Describe("Pipelines API", func() {
 		pipelineDB = new(dbfakes.FakePipelineDB)
 		pipelineDBFactory.BuildReturns(pipelineDB)
 		expectedSavedPipeline = db.SavedPipeline{}
-		teamDB.GetPipelineByNameReturns(expectedSavedPipeline, nil)
+		teamDB.GetPipelineByRefReturns(expectedSavedPipeline, nil)
 	})
 
 	Describe("GET /api/v1/pipelines", func() {
@@ -334,6 +335,99 @@ var _ = Describe("Pipelines API", func() {
 				})
 			})
 
+			Context("and archived pipelines are requested", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", 1, true, true)
+
+					teamDB.GetArchivedPipelinesReturns([]db.SavedPipeline{
+						{
+							ID:       3,
+							Paused:   true,
+							Archived: true,
+							TeamName: "a-team",
+							Pipeline: db.Pipeline{
+								Name: "archived-pipeline",
+							},
+						},
+					}, nil)
+				})
+
+				JustBeforeEach(func() {
+					req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines?archived=true", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					response, err = client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns only the archived pipelines", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "archived-pipeline",
+							"url": "/teams/a-team/pipelines/archived-pipeline",
+							"paused": true,
+							"public": false,
+							"archived": true,
+							"team_name": "a-team"
+						}
+					]`))
+				})
+			})
+
+			Context("and the pipelines are instanced", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", 1, true, true)
+
+					teamDB.GetPipelinesReturns([]db.SavedPipeline{
+						{
+							ID:       4,
+							TeamName: "a-team",
+							Pipeline: db.Pipeline{
+								Name:         "a-pipeline",
+								InstanceVars: atc.InstanceVars{"branch": "feature"},
+							},
+						},
+						{
+							ID:       5,
+							TeamName: "a-team",
+							Pipeline: db.Pipeline{
+								Name:         "a-pipeline",
+								InstanceVars: atc.InstanceVars{"branch": "master"},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns each instance with its vars and url", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "a-pipeline",
+							"instance_vars": {"branch": "feature"},
+							"url": "/teams/a-team/pipelines/a-pipeline?vars.branch=%22feature%22",
+							"paused": false,
+							"public": false,
+							"team_name": "a-team"
+						},
+						{
+							"name": "a-pipeline",
+							"instance_vars": {"branch": "master"},
+							"url": "/teams/a-team/pipelines/a-pipeline?vars.branch=%22master%22",
+							"paused": false,
+							"public": false,
+							"team_name": "a-team"
+						}
+					]`))
+				})
+			})
+
 			Context("but not authorized", func() {
 				BeforeEach(func() {
 					authValidator.IsAuthenticatedReturns(true)
@@ -367,8 +461,11 @@ var _ = Describe("Pipelines API", func() {
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name", func() {
 		var response *http.Response
 		var savedPipeline db.SavedPipeline
+		var query string
 
 		BeforeEach(func() {
+			query = ""
+
 			savedPipeline = db.SavedPipeline{
 				ID:       1,
 				Paused:   false,
@@ -392,11 +489,11 @@ var _ = Describe("Pipelines API", func() {
 					},
 				},
 			}
-			teamDB.GetPipelineByNameReturns(savedPipeline, nil)
+			teamDB.GetPipelineByRefReturns(savedPipeline, nil)
 		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/some-specific-pipeline", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/some-specific-pipeline"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -406,8 +503,39 @@ var _ = Describe("Pipelines API", func() {
 		})
 
 		It("looks up the pipeline in the db via the url param", func() {
-			Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-			Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("some-specific-pipeline"))
+			Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+			Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-specific-pipeline"}))
+		})
+
+		Context("when instance vars are given as query params", func() {
+			BeforeEach(func() {
+				query = "?vars.branch=%22feature%22&vars.replicas=2"
+			})
+
+			It("looks up the pipeline instance with the vars", func() {
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{
+					Name: "some-specific-pipeline",
+					InstanceVars: atc.InstanceVars{
+						"branch":   "feature",
+						"replicas": float64(2),
+					},
+				}))
+			})
+		})
+
+		Context("when the instance vars are malformed", func() {
+			BeforeEach(func() {
+				query = "?vars.branch=feature"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not look up the pipeline", func() {
+				Expect(teamDB.GetPipelineByRefCallCount()).To(BeZero())
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -419,7 +547,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("and the pipeline is private", func() {
 				BeforeEach(func() {
 					savedPipeline.Public = false
-					teamDB.GetPipelineByNameReturns(savedPipeline, nil)
+					teamDB.GetPipelineByRefReturns(savedPipeline, nil)
 				})
 
 				It("returns 401", func() {
@@ -430,7 +558,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("and the pipeline is public", func() {
 				BeforeEach(func() {
 					savedPipeline.Public = true
-					teamDB.GetPipelineByNameReturns(savedPipeline, nil)
+					teamDB.GetPipelineByRefReturns(savedPipeline, nil)
 				})
 
 				It("returns 200 OK", func() {
@@ -484,6 +612,21 @@ var _ = Describe("Pipelines API", func() {
 					]
 				}`))
 				})
+
+				Context("when the pipeline was configured by a build", func() {
+					BeforeEach(func() {
+						savedPipeline.ParentBuildID = 42
+						teamDB.GetPipelineByRefReturns(savedPipeline, nil)
+					})
+
+					It("includes the build's ID", func() {
+						var pipeline atc.Pipeline
+						err := json.NewDecoder(response.Body).Decode(&pipeline)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pipeline.ParentBuildID).To(Equal(42))
+					})
+				})
 			})
 
 			Context("when not authorized", func() {
@@ -495,7 +638,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("and the pipeline is private", func() {
 					BeforeEach(func() {
 						savedPipeline.Public = false
-						teamDB.GetPipelineByNameReturns(savedPipeline, nil)
+						teamDB.GetPipelineByRefReturns(savedPipeline, nil)
 					})
 
 					It("returns 403 forbidden", func() {
@@ -506,7 +649,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("and the pipeline is public", func() {
 					BeforeEach(func() {
 						savedPipeline.Public = true
-						teamDB.GetPipelineByNameReturns(savedPipeline, nil)
+						teamDB.GetPipelineByRefReturns(savedPipeline, nil)
 					})
 
 					It("returns 200 OK", func() {
@@ -518,7 +661,7 @@ var _ = Describe("Pipelines API", func() {
 
 		Context("when the call to get pipeline fails", func() {
 			BeforeEach(func() {
-				teamDB.GetPipelineByNameReturns(db.SavedPipeline{}, errors.New("disaster"))
+				teamDB.GetPipelineByRefReturns(db.SavedPipeline{}, errors.New("disaster"))
 			})
 
 			It("returns 500 error", func() {
@@ -558,8 +701,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline-name"))
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline-name"}))
 					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -604,6 +747,90 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/archive", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/archive", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", 42, true, true)
+				})
+
+				It("injects the proper pipelineDB", func() {
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+				})
+
+				It("archives the pipeline", func() {
+					Expect(pipelineDB.ArchiveCallCount()).To(Equal(1))
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				Context("when archiving the pipeline fails", func() {
+					BeforeEach(func() {
+						pipelineDB.ArchiveReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the pipeline is already archived", func() {
+					BeforeEach(func() {
+						teamDB.GetPipelineByRefReturns(db.SavedPipeline{Archived: true}, nil)
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not archive it again", func() {
+						Expect(pipelineDB.ArchiveCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("another-team", 42, true, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/pause", func() {
 		var response *http.Response
 
@@ -630,8 +857,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -707,8 +934,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -784,8 +1011,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -861,8 +1088,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -1175,8 +1402,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
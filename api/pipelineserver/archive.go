@@ -0,0 +1,30 @@
+package pipelineserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ArchivePipeline(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("archiving-pipeline", lager.Data{
+			"name": pipelineDB.GetPipelineName(),
+		})
+
+		logger.Info("start")
+
+		err := pipelineDB.Archive()
+		if err != nil {
+			logger.Error("failed", err)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("done")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
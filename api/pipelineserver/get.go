@@ -4,16 +4,27 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/auth"
 )
 
 func (s *Server) GetPipeline(w http.ResponseWriter, r *http.Request) {
-	pipelineName := r.FormValue(":pipeline_name")
 	teamName := r.FormValue(":team_name")
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         r.FormValue(":pipeline_name"),
+		InstanceVars: instanceVars,
+	}
+
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	pipeline, err := teamDB.GetPipelineByName(pipelineName)
+	pipeline, err := teamDB.GetPipelineByRef(pipelineRef)
 	if err != nil {
 		s.logger.Error("call-to-get-pipeline-failed", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -6,12 +6,14 @@ import (
 	"github.com/concourse/atc/db"
 )
 
-func (s *Server) getPipelines(teamName string, all bool) ([]atc.Pipeline, error) {
+func (s *Server) getPipelines(teamName string, all bool, archived bool) ([]atc.Pipeline, error) {
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
 	var pipelines []db.SavedPipeline
 	var err error
 
-	if all {
+	if archived {
+		pipelines, err = teamDB.GetArchivedPipelines()
+	} else if all {
 		if !teamDB.HasTeamName() {
 			pipelines, err = s.pipelinesDB.GetAllPublicPipelines()
 		} else {
@@ -11,7 +11,7 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-all-pipelines")
 	teamName := auth.GetAuthTeamName(r)
 
-	pipelines, err := s.getPipelines(teamName, true)
+	pipelines, err := s.getPipelines(teamName, true, false)
 	if err != nil {
 		logger.Error("failed-to-get-all-active-pipelines", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -52,6 +52,8 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		URL:          reqURL,
 		APIURL:       apiURL,
+
+		ManuallyPinned: build.IsManuallyPinned(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -10,6 +10,15 @@ func Container(container db.SavedContainer) atc.Container {
 	if container.Type != db.ContainerTypeCheck {
 		stepType = container.Type.String()
 	}
+
+	var limits *atc.ContainerLimits
+	if container.CPULimit != 0 || container.MemoryLimit != 0 {
+		limits = &atc.ContainerLimits{
+			CPU:    container.CPULimit,
+			Memory: container.MemoryLimit,
+		}
+	}
+
 	return atc.Container{
 		ID:                   container.Handle,
 		TTLInSeconds:         int64(container.ExpiresIn.Seconds()),
@@ -26,5 +35,6 @@ func Container(container db.SavedContainer) atc.Container {
 		EnvironmentVariables: container.EnvironmentVariables,
 		Attempts:             container.Attempts,
 		User:                 container.User,
+		Limits:               limits,
 	}
 }
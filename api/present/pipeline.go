@@ -17,12 +17,20 @@ func Pipeline(savedPipeline db.SavedPipeline, config atc.Config) atc.Pipeline {
 		panic("failed to generate url: " + err.Error())
 	}
 
+	if len(savedPipeline.InstanceVars) > 0 {
+		pathForRoute += "?" + savedPipeline.Ref().QueryParams().Encode()
+	}
+
 	return atc.Pipeline{
-		Name:     savedPipeline.Name,
-		TeamName: savedPipeline.TeamName,
-		URL:      pathForRoute,
-		Paused:   savedPipeline.Paused,
-		Public:   savedPipeline.Public,
-		Groups:   config.Groups,
+		Name:         savedPipeline.Name,
+		InstanceVars: savedPipeline.InstanceVars,
+		TeamName:     savedPipeline.TeamName,
+		URL:          pathForRoute,
+		Paused:       savedPipeline.Paused,
+		Public:       savedPipeline.Public,
+		Groups:       config.Groups,
+
+		ParentBuildID: savedPipeline.ParentBuildID,
+		Archived:      savedPipeline.Archived,
 	}
 }
@@ -0,0 +1,14 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func PipelineConfigVersion(configVersion db.PipelineConfigVersion) atc.PipelineConfigVersion {
+	return atc.PipelineConfigVersion{
+		Version: configVersion.Version,
+		SavedBy: configVersion.SavedBy,
+		SavedAt: configVersion.SavedAt.Unix(),
+	}
+}
@@ -37,6 +37,11 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 		checkErrString = dbResource.CheckError.Error()
 	}
 
+	var pinnedVersion atc.Version
+	if dbResource.PinnedVersionID != 0 {
+		pinnedVersion = atc.Version(dbResource.PinnedVersion)
+	}
+
 	return atc.Resource{
 		Name:   resource.Name,
 		Type:   resource.Type,
@@ -47,5 +52,9 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 
 		FailingToCheck: dbResource.FailingToCheck(),
 		CheckError:     checkErrString,
+
+		PinnedVersion: pinnedVersion,
+		PinComment:    dbResource.PinComment,
+		PinnedBy:      dbResource.PinnedBy,
 	}
 }
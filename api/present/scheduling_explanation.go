@@ -0,0 +1,102 @@
+package present
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+func SchedulingExplanation(jobInputs []config.JobInput, explanation algorithm.Explanation) atc.SchedulingExplanation {
+	explainedInputs := map[string]algorithm.InputExplanation{}
+	for _, input := range explanation.Inputs {
+		explainedInputs[input.Name] = input
+	}
+
+	presented := atc.SchedulingExplanation{
+		Resolved: explanation.Resolved,
+		Inputs:   []atc.InputExplanation{},
+	}
+
+	for _, jobInput := range jobInputs {
+		input := atc.InputExplanation{
+			Name:     jobInput.Name,
+			Resource: jobInput.Resource,
+		}
+
+		explained, found := explainedInputs[jobInput.Name]
+		if !found {
+			// inputs are only left out when their pinned version does not exist
+			input.PinnedVersionNotFound = true
+			presented.Resolved = false
+		} else {
+			input.Versions = explained.Versions
+			input.Candidates = explained.Candidates
+			input.PinnedVersionID = explained.PinnedVersionID
+			input.PinnedVersionExcluded = explained.PinnedVersionExcluded
+			input.DisabledVersionIDs = explained.DisabledVersionIDs
+
+			for _, passed := range explained.Passed {
+				input.Passed = append(input.Passed, atc.PassedExplanation{
+					Job:                  passed.JobName,
+					Versions:             passed.Versions,
+					EliminatedVersionIDs: passed.EliminatedVersionIDs,
+				})
+			}
+		}
+
+		presented.Inputs = append(presented.Inputs, input)
+	}
+
+	for _, conflict := range explanation.Conflicts {
+		presented.Conflicts = append(presented.Conflicts, atc.ConstraintConflict{
+			Job:    conflict.JobName,
+			Inputs: conflict.Inputs,
+		})
+	}
+
+	if !presented.Resolved {
+		presented.Reason = schedulingReason(presented)
+	}
+
+	return presented
+}
+
+func schedulingReason(explanation atc.SchedulingExplanation) string {
+	for _, input := range explanation.Inputs {
+		if input.PinnedVersionNotFound {
+			return fmt.Sprintf("pinned version of input '%s' not found", input.Name)
+		}
+	}
+
+	for _, input := range explanation.Inputs {
+		if input.Candidates > 0 {
+			continue
+		}
+
+		if len(input.Passed) == 0 {
+			return fmt.Sprintf("no versions of input '%s' available", input.Name)
+		}
+
+		jobs := []string{}
+		for _, passed := range input.Passed {
+			jobs = append(jobs, passed.Job)
+		}
+
+		return fmt.Sprintf("no versions of input '%s' have passed %s", input.Name, strings.Join(jobs, ", "))
+	}
+
+	for _, input := range explanation.Inputs {
+		if input.PinnedVersionExcluded {
+			return fmt.Sprintf("pinned version of input '%s' is not available", input.Name)
+		}
+	}
+
+	for _, conflict := range explanation.Conflicts {
+		return fmt.Sprintf("inputs %s have no build of %s in common", strings.Join(conflict.Inputs, ", "), conflict.Job)
+	}
+
+	return "no combination of versions satisfies the passed constraints"
+}
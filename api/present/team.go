@@ -9,5 +9,7 @@ func Team(savedTeam db.SavedTeam) atc.Team {
 	return atc.Team{
 		ID:   savedTeam.ID,
 		Name: savedTeam.Name,
+
+		ContainerLimits: savedTeam.ContainerLimits,
 	}
 }
@@ -26,7 +26,7 @@ var _ = Describe("Resources API", func() {
 		fakePipelineDB = new(dbfakes.FakePipelineDB)
 		pipelineDBFactory.BuildReturns(fakePipelineDB)
 		expectedSavedPipeline = db.SavedPipeline{}
-		teamDB.GetPipelineByNameReturns(expectedSavedPipeline, nil)
+		teamDB.GetPipelineByRefReturns(expectedSavedPipeline, nil)
 	})
 
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources", func() {
@@ -408,6 +408,38 @@ var _ = Describe("Resources API", func() {
 								"check_error": "sup"
 							}`))
 						})
+
+						Context("when the resource is pinned", func() {
+							BeforeEach(func() {
+								fakePipelineDB.GetResourceReturns(db.SavedResource{
+									ID:              1,
+									PipelineName:    "a-pipeline",
+									PinnedVersionID: 42,
+									PinnedVersion:   db.Version{"ref": "abcdef"},
+									PinComment:      "holding back during an incident",
+									PinnedBy:        "a-team",
+									Resource: db.Resource{
+										Name: "resource-1",
+									},
+								}, true, nil)
+							})
+
+							It("returns the resource json with the pinned version", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`
+								{
+									"name": "resource-1",
+									"type": "type-1",
+									"groups": ["group-1", "group-2"],
+									"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
+									"pinned_version": {"ref": "abcdef"},
+									"pin_comment": "holding back during an incident",
+									"pinned_by": "a-team"
+								}`))
+							})
+						})
 					})
 				})
 			})
@@ -442,9 +474,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -524,9 +556,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -609,9 +641,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
-				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -750,4 +782,215 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			webhookToken = "some-token"
+
+			fakePipelineDB.GetConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "resource-name", WebhookToken: "some-token"},
+					{Name: "resource-without-token"},
+				},
+			}, 1, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				pipelineRef := teamDB.GetPipelineByRefArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			It("tries to scan with no version specified", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+				Expect(actualResourceName).To(Equal("resource-name"))
+				Expect(actualFromVersion).To(BeNil())
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			Context("when the resource already has versions", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+						VersionedResource: db.VersionedResource{
+							Version: db.Version{"some": "version"},
+						},
+					}, true, nil)
+				})
+
+				It("tries to scan with the latest version", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualFromVersion).To(Equal(atc.Version{"some": "version"}))
+				})
+			})
+
+			Context("when the webhook token is wrong", func() {
+				BeforeEach(func() {
+					webhookToken = "bogus-token"
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the webhook token is a secret", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetTeamNameReturns("a-team")
+					fakePipelineDB.GetPipelineNameReturns("a-pipeline")
+					fakePipelineDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "resource-name", WebhookToken: "((webhook-token))"},
+						},
+					}, 1, true, nil)
+				})
+
+				Context("when the secret is defined", func() {
+					BeforeEach(func() {
+						fakeSecrets.GetReturns("some-token", true, nil)
+					})
+
+					It("compares against the resolved token", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					})
+
+					It("looks up the secret for the resource's pipeline", func() {
+						teamName, pipelineName, secretName := fakeSecrets.GetArgsForCall(0)
+						Expect(teamName).To(Equal("a-team"))
+						Expect(pipelineName).To(Equal("a-pipeline"))
+						Expect(secretName).To(Equal("webhook-token"))
+					})
+				})
+
+				Context("when the secret is not defined", func() {
+					BeforeEach(func() {
+						fakeSecrets.GetReturns(nil, false, nil)
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not scan", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the given token is the placeholder itself", func() {
+					BeforeEach(func() {
+						webhookToken = "((webhook-token))"
+						fakeSecrets.GetReturns("some-token", true, nil)
+					})
+
+					It("returns Unauthorized", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+			})
+
+			Context("when the webhook token is missing", func() {
+				BeforeEach(func() {
+					webhookToken = ""
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the resource does not configure a webhook token", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "resource-name"},
+						},
+					}, 1, true, nil)
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the resource is not in the config", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{}, 1, true, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the config cannot be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{}, 0, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the config fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{}, 0, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when checking the resource fails internally", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
@@ -25,39 +25,48 @@ func (s *Server) CheckResource(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		fromVersion := reqBody.From
-		if fromVersion == nil {
-			latestVersion, found, err := pipelineDB.GetLatestVersionedResource(resourceName)
-			if err != nil {
-				logger.Info("failed-to-get-latest-versioned-resource", lager.Data{"error": err.Error()})
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-
-			if found {
-				fromVersion = atc.Version(latestVersion.Version)
-			}
+		s.checkResource(logger, pipelineDB, resourceName, reqBody.From, w)
+	})
+}
+
+func (s *Server) checkResource(
+	logger lager.Logger,
+	pipelineDB db.PipelineDB,
+	resourceName string,
+	fromVersion atc.Version,
+	w http.ResponseWriter,
+) {
+	if fromVersion == nil {
+		latestVersion, found, err := pipelineDB.GetLatestVersionedResource(resourceName)
+		if err != nil {
+			logger.Info("failed-to-get-latest-versioned-resource", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 
-		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
+		if found {
+			fromVersion = atc.Version(latestVersion.Version)
+		}
+	}
 
-		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
-		switch scanErr := err.(type) {
-		case resource.ErrResourceScriptFailed:
-			checkResponseBody := atc.CheckResponseBody{
-				ExitStatus: scanErr.ExitStatus,
-				Stderr:     scanErr.Stderr,
-			}
+	scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
 
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(checkResponseBody)
-		case db.ResourceNotFoundError:
-			w.WriteHeader(http.StatusNotFound)
-		case error:
-			w.WriteHeader(http.StatusInternalServerError)
-		default:
-			w.WriteHeader(http.StatusOK)
+	err := scanner.ScanFromVersion(logger, resourceName, fromVersion)
+	switch scanErr := err.(type) {
+	case resource.ErrResourceScriptFailed:
+		checkResponseBody := atc.CheckResponseBody{
+			ExitStatus: scanErr.ExitStatus,
+			Stderr:     scanErr.Stderr,
 		}
-	})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(checkResponseBody)
+	case db.ResourceNotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+	case error:
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
 }
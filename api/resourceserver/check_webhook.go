@@ -0,0 +1,61 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResourceWebHook(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		if webhookToken == "" {
+			logger.Info("missing-webhook-token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		config, _, found, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resourceConfig, found := config.Resources.Lookup(resourceName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		variables := creds.NewVariables(s.secrets, pipelineDB.GetTeamName(), pipelineDB.GetPipelineName())
+
+		expectedToken, err := variables.EvaluateString(resourceConfig.WebhookToken)
+		if err != nil {
+			logger.Error("failed-to-evaluate-webhook-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(webhookToken), []byte(expectedToken)) != 1 {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		s.checkResource(logger, pipelineDB, resourceName, nil, w)
+	})
+}
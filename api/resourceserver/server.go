@@ -2,6 +2,7 @@ package resourceserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/radar"
 )
 
@@ -14,11 +15,13 @@ type ScannerFactory interface {
 type Server struct {
 	logger         lager.Logger
 	scannerFactory ScannerFactory
+	secrets        creds.Secrets
 }
 
-func NewServer(logger lager.Logger, scannerFactory ScannerFactory) *Server {
+func NewServer(logger lager.Logger, scannerFactory ScannerFactory, secrets creds.Secrets) *Server {
 	return &Server{
 		logger:         logger,
 		scannerFactory: scannerFactory,
+		secrets:        secrets,
 	}
 }
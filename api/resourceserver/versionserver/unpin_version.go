@@ -0,0 +1,35 @@
+package versionserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) UnpinResourceVersion(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("unpin-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		versionID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = pipelineDB.UnpinVersionedResource(resourceName, versionID)
+		if err == db.ErrVersionedResourceNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-unpin-versioned-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
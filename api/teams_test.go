@@ -10,6 +10,8 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/template"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -45,6 +47,7 @@ func atcDBTeamEquality(atcTeam atc.Team, dbTeam db.Team) {
 			Expect(dbGitHubTeam.TeamName).To(Equal(atcGitHubTeam.TeamName))
 		}
 	}
+	Expect(dbTeam.ContainerLimits).To(Equal(atcTeam.ContainerLimits))
 }
 
 var _ = Describe("Teams API", func() {
@@ -524,6 +527,94 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 				})
+
+				Context("updating container limits", func() {
+					BeforeEach(func() {
+						team.ContainerLimits = &atc.ContainerLimits{
+							CPU:    512,
+							Memory: 1024,
+						}
+					})
+
+					It("updates the container limits for that team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(teamDB.UpdateContainerLimitsCallCount()).To(Equal(1))
+						Expect(teamDB.UpdateContainerLimitsArgsForCall(0)).To(Equal(&atc.ContainerLimits{
+							CPU:    512,
+							Memory: 1024,
+						}))
+					})
+
+					It("returns the team with its container limits", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 2,
+							"name": "team venture",
+							"container_limits": {
+								"cpu": 512,
+								"memory": 1024
+							}
+						}`))
+					})
+
+					Context("when updating the container limits fails", func() {
+						BeforeEach(func() {
+							teamDB.UpdateContainerLimitsReturns(db.SavedTeam{}, errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the container limits are given empty", func() {
+					BeforeEach(func() {
+						team.ContainerLimits = &atc.ContainerLimits{}
+						savedTeam.ContainerLimits = &atc.ContainerLimits{CPU: 256}
+						teamDB.GetTeamReturns(savedTeam, true, nil)
+					})
+
+					It("clears the team's container limits", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(teamDB.UpdateContainerLimitsCallCount()).To(Equal(1))
+						Expect(teamDB.UpdateContainerLimitsArgsForCall(0)).To(BeNil())
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 2,
+							"name": "team venture"
+						}`))
+					})
+				})
+
+				Context("when the container limits are not given", func() {
+					BeforeEach(func() {
+						team.ContainerLimits = nil
+						savedTeam.ContainerLimits = &atc.ContainerLimits{CPU: 256}
+						teamDB.GetTeamReturns(savedTeam, true, nil)
+					})
+
+					It("keeps the team's container limits", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(teamDB.UpdateContainerLimitsCallCount()).To(BeZero())
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 2,
+							"name": "team venture",
+							"container_limits": {
+								"cpu": 256
+							}
+						}`))
+					})
+				})
 			})
 
 			Context("when team does not exist", func() {
@@ -707,4 +798,414 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/export", func() {
+		var response *http.Response
+		var pipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			pipelineDB = new(dbfakes.FakePipelineDB)
+			pipelineDBFactory.BuildReturns(pipelineDB)
+
+			teamDB.GetPipelinesReturns([]db.SavedPipeline{
+				{
+					ID:     1,
+					Paused: true,
+					Public: true,
+					Pipeline: db.Pipeline{
+						Name: "some-pipeline",
+						Config: atc.Config{
+							Groups: atc.GroupConfigs{
+								{Name: "some-group", Jobs: []string{"some-job"}},
+							},
+							Resources: atc.ResourceConfigs{
+								{Name: "some-resource", Type: "git"},
+								{Name: "some-other-resource", Type: "git"},
+							},
+						},
+					},
+				},
+				{
+					ID: 2,
+					Pipeline: db.Pipeline{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature"},
+					},
+				},
+			}, nil)
+
+			pipelineDB.GetResourceStub = func(resourceName string) (db.SavedResource, bool, error) {
+				if resourceName == "some-resource" {
+					return db.SavedResource{
+						PinnedVersionID: 42,
+						PinnedVersion:   db.Version{"ref": "pinned"},
+						PinComment:      "some-comment",
+					}, true, nil
+				}
+
+				return db.SavedResource{}, true, nil
+			}
+
+			pipelineDB.GetDisabledVersionsStub = func(resourceName string) ([]db.Version, error) {
+				if resourceName == "some-resource" {
+					return []db.Version{{"ref": "disabled"}}, nil
+				}
+
+				return []db.Version{}, nil
+			}
+
+			teamDB.GetConfigTemplateStub = func(ref atc.PipelineRef) (atc.RawConfig, template.Variables, db.ConfigVersion, error) {
+				if ref.InstanceVars == nil {
+					return "resources: ((resources))", template.Variables{"resources": "some-resources"}, 1, nil
+				}
+
+				return "{}", nil, 2, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/export", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 1, true, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns application/json", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("exports the team's pipelines in order", func() {
+				Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+
+				var export atc.TeamExport
+				err := json.NewDecoder(response.Body).Decode(&export)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(export).To(Equal(atc.TeamExport{
+					Pipelines: []atc.ExportedPipeline{
+						{
+							Name:   "some-pipeline",
+							Paused: true,
+							Public: true,
+							Config: atc.Config{
+								Groups: atc.GroupConfigs{
+									{Name: "some-group", Jobs: []string{"some-job"}},
+								},
+								Resources: atc.ResourceConfigs{
+									{Name: "some-resource", Type: "git"},
+									{Name: "some-other-resource", Type: "git"},
+								},
+							},
+							Template: "resources: ((resources))",
+							Vars:     template.Variables{"resources": "some-resources"},
+							Resources: []atc.ExportedResource{
+								{
+									Name:             "some-resource",
+									PinnedVersion:    atc.Version{"ref": "pinned"},
+									PinComment:       "some-comment",
+									DisabledVersions: []atc.Version{{"ref": "disabled"}},
+								},
+							},
+						},
+						{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "feature"},
+							Template:     "{}",
+						},
+					},
+				}))
+			})
+
+			Context("when getting the pipelines fails", func() {
+				BeforeEach(func() {
+					teamDB.GetPipelinesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			It("exports the config templates of the pipelines", func() {
+				Expect(teamDB.GetConfigTemplateCallCount()).To(Equal(2))
+				Expect(teamDB.GetConfigTemplateArgsForCall(1)).To(Equal(atc.PipelineRef{
+					Name:         "some-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}))
+			})
+
+			Context("when getting a config template fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigTemplateStub = nil
+					teamDB.GetConfigTemplateReturns("", nil, 0, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the disabled versions fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetDisabledVersionsStub = nil
+					pipelineDB.GetDisabledVersionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 1, true, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/import", func() {
+		var response *http.Response
+		var export atc.TeamExport
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+
+			export = atc.TeamExport{
+				Pipelines: []atc.ExportedPipeline{
+					{
+						Name:   "some-pipeline",
+						Paused: true,
+						Public: true,
+						Config: atc.Config{
+							Resources: atc.ResourceConfigs{
+								{Name: "some-resource", Type: "git"},
+							},
+						},
+						Template: "resources: [{name: some-resource, type: ((type))}]",
+						Vars:     template.Variables{"type": "git"},
+						Resources: []atc.ExportedResource{
+							{
+								Name:             "some-resource",
+								PinnedVersion:    atc.Version{"ref": "pinned"},
+								PinComment:       "some-comment",
+								DisabledVersions: []atc.Version{{"ref": "disabled"}},
+							},
+						},
+					},
+					{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature"},
+					},
+					{
+						Name: "some-other-pipeline",
+					},
+				},
+			}
+
+			teamDB.ImportPipelinesReturns(nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/import"+query, jsonEncode(export))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 1, true, true)
+				userContextReader.GetUserNameReturns("some-user", true)
+			})
+
+			It("returns 201", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			})
+
+			It("imports all of the pipelines at once", func() {
+				Expect(teamDB.ImportPipelinesCallCount()).To(Equal(1))
+
+				pipelines, savedBy, pinnedBy := teamDB.ImportPipelinesArgsForCall(0)
+				Expect(pipelines).To(Equal(export.Pipelines))
+				Expect(savedBy).To(Equal("a-team"))
+				Expect(pinnedBy).To(Equal("some-user"))
+			})
+
+			It("does not save the pipelines one by one", func() {
+				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				Expect(teamDB.OrderPipelinesCallCount()).To(BeZero())
+			})
+
+			Context("when a pipeline already exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigStub = func(ref atc.PipelineRef) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
+						if ref.Name == "some-other-pipeline" {
+							return atc.Config{}, "", 42, nil
+						}
+
+						return atc.Config{}, "", 0, nil
+					}
+				})
+
+				It("returns 409 with the conflicts", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+
+					var importResponse atc.TeamImportResponse
+					err := json.NewDecoder(response.Body).Decode(&importResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(importResponse.Conflicts).To(Equal([]atc.PipelineRef{{Name: "some-other-pipeline"}}))
+				})
+
+				It("does not import anything", func() {
+					Expect(teamDB.ImportPipelinesCallCount()).To(BeZero())
+				})
+
+				Context("when importing as a dry run", func() {
+					BeforeEach(func() {
+						query = "?dry_run=true"
+					})
+
+					It("returns 200 with the conflicts", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						var importResponse atc.TeamImportResponse
+						err := json.NewDecoder(response.Body).Decode(&importResponse)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(importResponse).To(Equal(atc.TeamImportResponse{
+							DryRun:    true,
+							Conflicts: []atc.PipelineRef{{Name: "some-other-pipeline"}},
+						}))
+					})
+
+					It("does not import anything", func() {
+						Expect(teamDB.ImportPipelinesCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when importing as a dry run", func() {
+				BeforeEach(func() {
+					query = "?dry_run=true"
+				})
+
+				It("does not import anything", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(teamDB.ImportPipelinesCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a config is invalid", func() {
+				BeforeEach(func() {
+					configValidationErrorMessages = []string{"some-error"}
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					var importResponse atc.TeamImportResponse
+					err := json.NewDecoder(response.Body).Decode(&importResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(importResponse.Errors).To(ContainElement("pipeline some-other-pipeline: some-error"))
+				})
+
+				It("does not import anything", func() {
+					Expect(teamDB.ImportPipelinesCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a pinned resource is not in the config", func() {
+				BeforeEach(func() {
+					export.Pipelines[0].Resources[0].Name = "bogus-resource"
+				})
+
+				It("returns 400 with the error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					var importResponse atc.TeamImportResponse
+					err := json.NewDecoder(response.Body).Decode(&importResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(importResponse.Errors).To(Equal([]string{"pipeline some-pipeline: resource 'bogus-resource' not found"}))
+				})
+			})
+
+			Context("when a pipeline is exported more than once", func() {
+				BeforeEach(func() {
+					export.Pipelines = append(export.Pipelines, atc.ExportedPipeline{Name: "some-other-pipeline"})
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when a pipeline is created while importing", func() {
+				BeforeEach(func() {
+					teamDB.ImportPipelinesReturns(db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when importing the pipelines fails", func() {
+				BeforeEach(func() {
+					teamDB.ImportPipelinesReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 1, true, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not import anything", func() {
+				Expect(teamDB.ImportPipelinesCallCount()).To(BeZero())
+			})
+		})
+	})
 })
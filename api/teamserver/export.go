@@ -0,0 +1,107 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ExportTeam(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("export-team")
+
+	teamName := r.FormValue(":team_name")
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	savedPipelines, err := teamDB.GetPipelines()
+	if err != nil {
+		hLog.Error("failed-to-get-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	export := atc.TeamExport{
+		Pipelines: []atc.ExportedPipeline{},
+	}
+
+	for _, savedPipeline := range savedPipelines {
+		pipelineDB := s.pipelineDBFactory.Build(savedPipeline)
+
+		resources, err := s.exportResources(pipelineDB, savedPipeline.Config)
+		if err != nil {
+			hLog.Error("failed-to-export-resources", err, lager.Data{
+				"pipeline": savedPipeline.Ref().String(),
+			})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		configTemplate, vars, _, err := teamDB.GetConfigTemplate(savedPipeline.Ref())
+		if err != nil {
+			hLog.Error("failed-to-get-config-template", err, lager.Data{
+				"pipeline": savedPipeline.Ref().String(),
+			})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		export.Pipelines = append(export.Pipelines, atc.ExportedPipeline{
+			Name:         savedPipeline.Name,
+			InstanceVars: savedPipeline.InstanceVars,
+			Paused:       savedPipeline.Paused,
+			Public:       savedPipeline.Public,
+			Config:       savedPipeline.Config,
+			Template:     configTemplate,
+			Vars:         vars,
+			Resources:    resources,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(export)
+}
+
+func (s *Server) exportResources(pipelineDB db.PipelineDB, config atc.Config) ([]atc.ExportedResource, error) {
+	var resources []atc.ExportedResource
+
+	for _, resourceConfig := range config.Resources {
+		savedResource, found, err := pipelineDB.GetResource(resourceConfig.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		disabledVersions, err := pipelineDB.GetDisabledVersions(resourceConfig.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if savedResource.PinnedVersionID == 0 && len(disabledVersions) == 0 {
+			continue
+		}
+
+		resource := atc.ExportedResource{
+			Name: resourceConfig.Name,
+		}
+
+		if savedResource.PinnedVersionID != 0 {
+			resource.PinnedVersion = atc.Version(savedResource.PinnedVersion)
+			resource.PinComment = savedResource.PinComment
+		}
+
+		for _, version := range disabledVersions {
+			resource.DisabledVersions = append(resource.DisabledVersions, atc.Version(version))
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
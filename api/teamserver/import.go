@@ -0,0 +1,110 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+// ImportTeam recreates the pipelines of a TeamExport in the team. All of the
+// pipelines are validated before any are saved, and they are saved in a single
+// transaction, so nothing is imported if any of them is invalid or already
+// exists; with ?dry_run=true the conflicts and errors are only reported.
+func (s *Server) ImportTeam(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("import-team")
+
+	var export atc.TeamExport
+	err := json.NewDecoder(r.Body).Decode(&export)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	teamName := r.FormValue(":team_name")
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	response := atc.TeamImportResponse{
+		DryRun: r.URL.Query().Get("dry_run") == "true",
+	}
+
+	seen := map[string]bool{}
+	for _, pipeline := range export.Pipelines {
+		ref := pipeline.Ref()
+
+		if seen[ref.String()] {
+			response.Errors = append(response.Errors, fmt.Sprintf("pipeline %s: exported more than once", ref))
+			continue
+		}
+
+		seen[ref.String()] = true
+
+		warnings, errorMessages := s.validateConfig(pipeline.Config)
+		for _, warning := range warnings {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("pipeline %s: %s", ref, warning.Message))
+		}
+
+		for _, errorMessage := range errorMessages {
+			response.Errors = append(response.Errors, fmt.Sprintf("pipeline %s: %s", ref, errorMessage))
+		}
+
+		for _, resource := range pipeline.Resources {
+			if _, found := pipeline.Config.Resources.Lookup(resource.Name); !found {
+				response.Errors = append(response.Errors, fmt.Sprintf("pipeline %s: resource '%s' not found", ref, resource.Name))
+			}
+		}
+
+		_, _, existingVersion, err := teamDB.GetConfig(ref)
+		if err != nil {
+			hLog.Error("failed-to-get-existing-config", err, lager.Data{"pipeline": ref.String()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if existingVersion != 0 {
+			response.Conflicts = append(response.Conflicts, ref)
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		s.writeImportResponse(w, http.StatusBadRequest, response)
+		return
+	}
+
+	if response.DryRun {
+		s.writeImportResponse(w, http.StatusOK, response)
+		return
+	}
+
+	if len(response.Conflicts) > 0 {
+		s.writeImportResponse(w, http.StatusConflict, response)
+		return
+	}
+
+	err = teamDB.ImportPipelines(export.Pipelines, auth.GetAuthTeamName(r), auth.GetAuthUserName(r))
+	if err == db.ErrConfigComparisonFailed {
+		hLog.Info("pipeline-created-concurrently")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-import-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.writeImportResponse(w, http.StatusCreated, response)
+}
+
+func (s *Server) writeImportResponse(w http.ResponseWriter, status int, response atc.TeamImportResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(response)
+}
@@ -2,6 +2,7 @@ package teamserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 )
 
@@ -13,19 +14,25 @@ type TeamsDB interface {
 }
 
 type Server struct {
-	logger        lager.Logger
-	teamDBFactory db.TeamDBFactory
-	teamsDB       TeamsDB
+	logger            lager.Logger
+	teamDBFactory     db.TeamDBFactory
+	teamsDB           TeamsDB
+	pipelineDBFactory db.PipelineDBFactory
+	validateConfig    config.Validator
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamDBFactory db.TeamDBFactory,
 	teamsDB TeamsDB,
+	pipelineDBFactory db.PipelineDBFactory,
+	validator config.Validator,
 ) *Server {
 	return &Server{
-		logger:        logger,
-		teamDBFactory: teamDBFactory,
-		teamsDB:       teamsDB,
+		logger:            logger,
+		teamDBFactory:     teamDBFactory,
+		teamsDB:           teamsDB,
+		pipelineDBFactory: pipelineDBFactory,
+		validateConfig:    validator,
 	}
 }
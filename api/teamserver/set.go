@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
@@ -64,6 +65,24 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// teams set without container limits keep the ones they have, and
+		// setting empty container limits clears them
+		if team.ContainerLimits != nil {
+			containerLimits := team.ContainerLimits
+			if *containerLimits == (atc.ContainerLimits{}) {
+				containerLimits = nil
+			}
+
+			_, err = teamDB.UpdateContainerLimits(containerLimits)
+			if err != nil {
+				hLog.Error("failed-to-update-container-limits", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			savedTeam.ContainerLimits = containerLimits
+		}
+
 		w.WriteHeader(http.StatusOK)
 	} else if isAdmin {
 		hLog.Debug("creating team")
@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 )
@@ -22,7 +24,7 @@ var _ = Describe("Versions API", func() {
 		pipelineDB = new(dbfakes.FakePipelineDB)
 		pipelineDBFactory.BuildReturns(pipelineDB)
 		expectedSavedPipeline = db.SavedPipeline{}
-		teamDB.GetPipelineByNameReturns(expectedSavedPipeline, nil)
+		teamDB.GetPipelineByRefReturns(expectedSavedPipeline, nil)
 	})
 
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
@@ -261,7 +263,7 @@ var _ = Describe("Versions API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("a-pipeline"))
+				Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -323,8 +325,8 @@ var _ = Describe("Versions API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("a-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
 				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
 				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
@@ -366,6 +368,187 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var requestBody string
+		var response *http.Response
+
+		BeforeEach(func() {
+			requestBody = `{"pin_comment":"holding back during an incident"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+				userContextReader.GetUserNameReturns("some-user", true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			Context("when pinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.PinVersionedResourceReturns(nil)
+				})
+
+				It("pins the right versioned resource with the comment and who pinned it", func() {
+					Expect(pipelineDB.PinVersionedResourceCallCount()).To(Equal(1))
+					resourceName, versionID, comment, pinnedBy := pipelineDB.PinVersionedResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+					Expect(versionID).To(Equal(42))
+					Expect(comment).To(Equal("holding back during an incident"))
+					Expect(pinnedBy).To(Equal("some-user"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when no comment is given", func() {
+				BeforeEach(func() {
+					requestBody = ""
+				})
+
+				It("pins the versioned resource without a comment", func() {
+					Expect(pipelineDB.PinVersionedResourceCallCount()).To(Equal(1))
+					_, _, comment, _ := pipelineDB.PinVersionedResourceArgsForCall(0)
+					Expect(comment).To(BeEmpty())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = "{"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not pin the versioned resource", func() {
+					Expect(pipelineDB.PinVersionedResourceCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the version does not belong to the resource", func() {
+				BeforeEach(func() {
+					pipelineDB.PinVersionedResourceReturns(db.ErrVersionedResourceNotFound)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when pinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.PinVersionedResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/unpin", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinVersionedResourceReturns(nil)
+				})
+
+				It("unpins the right versioned resource", func() {
+					resourceName, versionID := pipelineDB.UnpinVersionedResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+					Expect(versionID).To(Equal(42))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the version does not belong to the resource", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinVersionedResourceReturns(db.ErrVersionedResourceNotFound)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinVersionedResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
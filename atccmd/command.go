@@ -25,6 +25,7 @@ import (
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/containerkeepaliver"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
@@ -79,6 +80,12 @@ type ATCCommand struct {
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	BuildLogRetention struct {
+		Builds                 int `long:"default-build-logs-to-retain"               description:"Default number of builds to retain logs for, for jobs that do not configure build log retention. 0 means unlimited."`
+		Days                   int `long:"default-days-to-retain-build-logs"          description:"Default number of days to retain build logs for, for jobs that do not configure build log retention. 0 means unlimited."`
+		MinimumSucceededBuilds int `long:"default-minimum-succeeded-builds-to-retain" description:"Default number of latest succeeded builds to always retain logs for, for jobs that do not configure build log retention."`
+	} `group:"Build Log Retention"`
+
 	Developer struct {
 		DevelopmentMode bool `short:"d" long:"development-mode"  description:"Lax security rules to make local development easier."`
 		Noop            bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
@@ -108,6 +115,14 @@ type ATCCommand struct {
 
 	UAAAuth UAAAuth `group:"UAA Authentication" namespace:"uaa-auth"`
 
+	CredentialManagement struct {
+		SecretsFile FileFlag `long:"secrets-file" description:"YAML file mapping TEAM/NAME or TEAM/PIPELINE/NAME to values of ((secrets)) referenced by pipelines."`
+
+		VaultURL        URLFlag `long:"vault-url"         description:"Vault-compatible key/value store to look up ((secrets)) referenced by pipelines."`
+		VaultToken      string  `long:"vault-token"       description:"Token used to authenticate with the Vault server."`
+		VaultPathPrefix string  `long:"vault-path-prefix" default:"concourse" description:"Path under which secrets are stored as PREFIX/TEAM/NAME or PREFIX/TEAM/PIPELINE/NAME."`
+	} `group:"Credential Management"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -177,17 +192,20 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	tracker := trackerFactory.TrackerFor(workerClient)
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
-	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
+	secrets := cmd.constructSecrets()
+	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory, secrets)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		engine,
+		secrets,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
+		secrets,
 		cmd.ExternalURL.String(),
 	)
 
@@ -237,6 +255,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		drain,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		secrets,
 		cmd.Developer.DevelopmentMode,
 	)
 
@@ -354,6 +373,12 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				sqlDB,
 				pipelineDBFactory,
 				500,
+				atc.BuildLogRetention{
+					Builds:                 cmd.BuildLogRetention.Builds,
+					Days:                   cmd.BuildLogRetention.Days,
+					MinimumSucceededBuilds: cmd.BuildLogRetention.MinimumSucceededBuilds,
+				},
+				clock.NewClock(),
 			),
 			"build-reaper",
 			sqlDB,
@@ -518,6 +543,20 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.CredentialManagement.SecretsFile != "" && cmd.CredentialManagement.VaultURL.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --secrets-file or --vault-url"),
+		)
+	}
+
+	if cmd.CredentialManagement.VaultURL.URL() != nil && cmd.CredentialManagement.VaultToken == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --vault-token to use Vault"),
+		)
+	}
+
 	tlsFlagCount := 0
 	if cmd.TLSBindPort != 0 {
 		tlsFlagCount++
@@ -763,6 +802,7 @@ func (cmd *ATCCommand) constructEngine(
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
 	teamDBFactory db.TeamDBFactory,
+	secrets creds.Secrets,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
@@ -774,6 +814,8 @@ func (cmd *ATCCommand) constructEngine(
 		gardenFactory,
 		engine.NewBuildDelegateFactory(),
 		teamDBFactory,
+		secrets,
+		cmd.constructConfigValidator(),
 		cmd.ExternalURL.String(),
 	)
 
@@ -782,6 +824,36 @@ func (cmd *ATCCommand) constructEngine(
 	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine})
 }
 
+func (cmd *ATCCommand) secretsConfigured() bool {
+	return cmd.CredentialManagement.SecretsFile != "" ||
+		cmd.CredentialManagement.VaultURL.URL() != nil
+}
+
+func (cmd *ATCCommand) constructSecrets() creds.Secrets {
+	if cmd.CredentialManagement.SecretsFile != "" {
+		return creds.NewFileSecrets(string(cmd.CredentialManagement.SecretsFile))
+	}
+
+	if cmd.CredentialManagement.VaultURL.URL() != nil {
+		return creds.NewVaultSecrets(
+			cmd.CredentialManagement.VaultURL.String(),
+			cmd.CredentialManagement.VaultToken,
+			cmd.CredentialManagement.VaultPathPrefix,
+		)
+	}
+
+	return nil
+}
+
+func (cmd *ATCCommand) constructConfigValidator() config.Validator {
+	if cmd.secretsConfigured() {
+		// leave ((placeholders)) to be resolved from the credential manager
+		return config.ValidateConfigWithSecrets
+	}
+
+	return config.ValidateConfig
+}
+
 func (cmd *ATCCommand) constructHTTPHandler(
 	webHandler http.Handler,
 	apiHandler http.Handler,
@@ -821,6 +893,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	drain <-chan struct{},
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
+	secrets creds.Secrets,
 	devMode bool,
 ) (http.Handler, http.Handler, error) {
 	tokenValidator := cmd.constructTokenValidator(&signingKey.PublicKey)
@@ -860,7 +933,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
 
-		config.ValidateConfig,
+		cmd.constructConfigValidator(),
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
 		drain,
@@ -869,6 +942,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		workerClient,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		secrets,
 
 		reconfigurableSink,
 
@@ -29,6 +29,15 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetUserNameStub        func(r *http.Request) (string, bool)
+	getUserNameMutex       sync.RWMutex
+	getUserNameArgsForCall []struct {
+		r *http.Request
+	}
+	getUserNameReturns struct {
+		result1 string
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -103,6 +112,40 @@ func (fake *FakeUserContextReader) GetSystemReturns(result1 bool, result2 bool)
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetUserName(r *http.Request) (string, bool) {
+	fake.getUserNameMutex.Lock()
+	fake.getUserNameArgsForCall = append(fake.getUserNameArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUserName", []interface{}{r})
+	fake.getUserNameMutex.Unlock()
+	if fake.GetUserNameStub != nil {
+		return fake.GetUserNameStub(r)
+	} else {
+		return fake.getUserNameReturns.result1, fake.getUserNameReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUserNameCallCount() int {
+	fake.getUserNameMutex.RLock()
+	defer fake.getUserNameMutex.RUnlock()
+	return len(fake.getUserNameArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUserNameArgsForCall(i int) *http.Request {
+	fake.getUserNameMutex.RLock()
+	defer fake.getUserNameMutex.RUnlock()
+	return fake.getUserNameArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUserNameReturns(result1 string, result2 bool) {
+	fake.GetUserNameStub = nil
+	fake.getUserNameReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -110,6 +153,8 @@ func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	defer fake.getTeamMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getUserNameMutex.RLock()
+	defer fake.getUserNameMutex.RUnlock()
 	return fake.invocations
 }
 
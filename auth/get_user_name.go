@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gorilla/context"
+)
+
+// GetAuthUserName returns the name of the user the request was authenticated
+// as, if their token identifies them.
+func GetAuthUserName(r *http.Request) string {
+	userName, found := context.GetOk(r, userNameKey)
+	if !found {
+		return ""
+	}
+
+	return userName.(string)
+}
@@ -1,6 +1,10 @@
 package github
 
 import (
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/oauth2"
@@ -16,6 +20,10 @@ func NewProvider(
 	redirectURL string,
 ) Provider {
 	client := NewClient(gitHubAuth.APIURL)
+	users := &userRecordingClient{
+		Client: client,
+		logins: map[*http.Client]string{},
+	}
 
 	endpoint := github.Endpoint
 	if gitHubAuth.AuthURL != "" && gitHubAuth.TokenURL != "" {
@@ -24,10 +32,11 @@ func NewProvider(
 	}
 
 	return Provider{
+		users: users,
 		Verifier: verifier.NewVerifierBasket(
 			NewTeamVerifier(dbTeamsToGitHubTeams(gitHubAuth.Teams), client),
 			NewOrganizationVerifier(gitHubAuth.Organizations, client),
-			NewUserVerifier(gitHubAuth.Users, client),
+			NewUserVerifier(gitHubAuth.Users, users),
 		),
 		Config: &oauth2.Config{
 			ClientID:     gitHubAuth.ClientID,
@@ -47,6 +56,8 @@ type Provider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+
+	users *userRecordingClient
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -63,3 +74,39 @@ func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
 func (Provider) DisplayName() string {
 	return "GitHub"
 }
+
+// UserName returns the login that was looked up while verifying the client,
+// so that naming the user does not cost another API call. Users of teams that
+// are not authorized by user are not named.
+func (provider Provider) UserName(logger lager.Logger, httpClient *http.Client) (string, error) {
+	return provider.users.login(httpClient), nil
+}
+
+// userRecordingClient remembers the login of each client whose current user
+// it looked up.
+type userRecordingClient struct {
+	Client
+
+	loginsL sync.Mutex
+	logins  map[*http.Client]string
+}
+
+func (c *userRecordingClient) CurrentUser(httpClient *http.Client) (string, error) {
+	currentUser, err := c.Client.CurrentUser(httpClient)
+	if err != nil {
+		return "", err
+	}
+
+	c.loginsL.Lock()
+	c.logins[httpClient] = currentUser
+	c.loginsL.Unlock()
+
+	return currentUser, nil
+}
+
+func (c *userRecordingClient) login(httpClient *http.Client) string {
+	c.loginsL.Lock()
+	defer c.loginsL.Unlock()
+
+	return c.logins[httpClient]
+}
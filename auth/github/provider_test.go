@@ -0,0 +1,103 @@
+package github_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	gogithub "github.com/google/go-github/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("Provider", func() {
+	var (
+		githubServer *ghttp.Server
+
+		gitHubAuth *db.GitHubAuth
+		provider   github.Provider
+
+		proxiedClient *http.Client
+	)
+
+	BeforeEach(func() {
+		githubServer = ghttp.NewServer()
+
+		gitHubAuth = &db.GitHubAuth{}
+
+		proxiedClient = &http.Client{
+			Transport: proxiedTransport{githubServer},
+		}
+
+		githubServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/user/teams", "page=1"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []gogithub.Team{}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/user/orgs", "page=1"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []gogithub.Organization{
+					{Login: gogithub.String("some-org")},
+				}),
+			),
+		)
+	})
+
+	JustBeforeEach(func() {
+		provider = github.NewProvider(gitHubAuth, "http://example.com/redirect")
+	})
+
+	AfterEach(func() {
+		githubServer.Close()
+	})
+
+	Describe("UserName", func() {
+		Context("when the user was verified by their login", func() {
+			BeforeEach(func() {
+				gitHubAuth.Users = []string{"some-user"}
+
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, gogithub.User{
+							Login: gogithub.String("some-user"),
+						}),
+					),
+				)
+			})
+
+			It("returns the login without looking it up again", func() {
+				verified, err := provider.Verify(lagertest.NewTestLogger("test"), proxiedClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+
+				userName, err := provider.UserName(lagertest.NewTestLogger("test"), proxiedClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userName).To(Equal("some-user"))
+
+				Expect(githubServer.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
+		Context("when the user was verified without looking up their login", func() {
+			BeforeEach(func() {
+				gitHubAuth.Organizations = []string{"some-org"}
+			})
+
+			It("returns no user name without making any more requests", func() {
+				verified, err := provider.Verify(lagertest.NewTestLogger("test"), proxiedClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+
+				userName, err := provider.UserName(lagertest.NewTestLogger("test"), proxiedClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userName).To(BeEmpty())
+
+				Expect(githubServer.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+})
@@ -31,6 +31,20 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, int, bool, bool) {
 	return teamName, teamID, isAdmin, true
 }
 
+func (jr JWTReader) GetUserName(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	userNameInterface, userNameOK := token.Claims[userNameClaimKey]
+	if !userNameOK {
+		return "", false
+	}
+
+	return userNameInterface.(string), true
+}
+
 func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 	if jr.DevelopmentMode {
 		return true, true
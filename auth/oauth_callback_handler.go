@@ -160,9 +160,17 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// the user name is only used to attribute actions, so the token is still
+	// issued if it cannot be looked up
+	userName, err := provider.UserName(hLog.Session("user-name"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-get-user-name", err)
+		userName = ""
+	}
+
 	exp := time.Now().Add(CookieAge)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin, userName)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
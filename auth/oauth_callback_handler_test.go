@@ -227,6 +227,7 @@ K4iijxtW0XYe5R1Od6lWOEKZ6un9Ag==
 					Context("when the token is verified", func() {
 						BeforeEach(func() {
 							fakeProviderB.VerifyReturns(true, nil)
+							fakeProviderB.UserNameReturns("some-user", nil)
 						})
 
 						It("responds OK", func() {
@@ -272,6 +273,17 @@ K4iijxtW0XYe5R1Od6lWOEKZ6un9Ag==
 								Expect(token.Claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("contains the name of the user who authorized it", func() {
+								Expect(fakeProviderB.UserNameCallCount()).To(Equal(1))
+								_, client := fakeProviderB.UserNameArgsForCall(0)
+								Expect(client).To(Equal(httpClient))
+
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								Expect(token.Claims["userName"]).To(Equal("some-user"))
+							})
 						})
 
 						It("does not redirect", func() {
@@ -292,6 +304,28 @@ K4iijxtW0XYe5R1Od6lWOEKZ6un9Ag==
 						})
 					})
 
+					Context("when the user name cannot be looked up", func() {
+						BeforeEach(func() {
+							fakeProviderB.VerifyReturns(true, nil)
+							fakeProviderB.UserNameReturns("", errors.New("nope"))
+						})
+
+						It("responds OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("sets a token cookie without a user name", func() {
+							cookie := response.Cookies()[0]
+							Expect(cookie.Name).To(Equal(auth.CookieName))
+
+							token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+							Expect(err).ToNot(HaveOccurred())
+
+							Expect(token.Claims).NotTo(HaveKey("userName"))
+							Expect(token.Claims["teamName"]).To(Equal(team.Name))
+						})
+					})
+
 					Context("when the token is not verified", func() {
 						BeforeEach(func() {
 							fakeProviderB.VerifyReturns(false, nil)
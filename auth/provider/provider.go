@@ -18,6 +18,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	UserNamer
 }
 
 type OAuthClient interface {
@@ -31,3 +32,9 @@ type OAuthClient interface {
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+// UserNamer looks up the name of the user who authorized the client, so that
+// the actions they take can be attributed to them.
+type UserNamer interface {
+	UserName(lager.Logger, *http.Client) (string, error)
+}
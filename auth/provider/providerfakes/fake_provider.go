@@ -56,6 +56,16 @@ type FakeProvider struct {
 		result1 bool
 		result2 error
 	}
+	UserNameStub        func(arg1 lager.Logger, arg2 *http.Client) (string, error)
+	userNameMutex       sync.RWMutex
+	userNameArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	userNameReturns struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -223,6 +233,41 @@ func (fake *FakeProvider) VerifyReturns(result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeProvider) UserName(arg1 lager.Logger, arg2 *http.Client) (string, error) {
+	fake.userNameMutex.Lock()
+	fake.userNameArgsForCall = append(fake.userNameArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("UserName", []interface{}{arg1, arg2})
+	fake.userNameMutex.Unlock()
+	if fake.UserNameStub != nil {
+		return fake.UserNameStub(arg1, arg2)
+	} else {
+		return fake.userNameReturns.result1, fake.userNameReturns.result2
+	}
+}
+
+func (fake *FakeProvider) UserNameCallCount() int {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return len(fake.userNameArgsForCall)
+}
+
+func (fake *FakeProvider) UserNameArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return fake.userNameArgsForCall[i].arg1, fake.userNameArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) UserNameReturns(result1 string, result2 error) {
+	fake.UserNameStub = nil
+	fake.userNameReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -236,6 +281,8 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.clientMutex.RUnlock()
 	fake.verifyMutex.RLock()
 	defer fake.verifyMutex.RUnlock()
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -17,9 +17,10 @@ const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const teamIDClaimKey = "teamID"
 const isAdminClaimKey = "isAdmin"
+const userNameClaimKey = "userName"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, userName string) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -32,13 +33,17 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error) {
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, userName string) (TokenType, TokenValue, error) {
 	jwtToken := jwt.New(SigningMethod)
 	jwtToken.Claims["exp"] = expiration.Unix()
 	jwtToken.Claims["teamName"] = teamName
 	jwtToken.Claims["teamID"] = teamID
 	jwtToken.Claims["isAdmin"] = isAdmin
 
+	if userName != "" {
+		jwtToken.Claims["userName"] = userName
+	}
+
 	signed, err := jwtToken.SignedString(generator.privateKey)
 	if err != nil {
 		return "", "", err
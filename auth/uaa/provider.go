@@ -57,3 +57,12 @@ type Provider struct {
 func (Provider) DisplayName() string {
 	return "UAA"
 }
+
+func (Provider) UserName(logger lager.Logger, httpClient *http.Client) (string, error) {
+	uaaToken, err := clientToken(httpClient)
+	if err != nil {
+		return "", err
+	}
+
+	return uaaToken.UserName, nil
+}
@@ -7,4 +7,5 @@ import "net/http"
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, int, bool, bool)
 	GetSystem(r *http.Request) (bool, bool)
+	GetUserName(r *http.Request) (string, bool)
 }
@@ -11,6 +11,7 @@ var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
 var isSystemKey = "system"
+var userNameKey = "userName"
 
 func WrapHandler(
 	handler http.Handler,
@@ -43,5 +44,10 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if found {
 		context.Set(r, isSystemKey, isSystem)
 	}
+
+	userName, found := h.userContextReader.GetUserName(r)
+	if found {
+		context.Set(r, userNameKey, userName)
+	}
 	h.handler.ServeHTTP(w, r)
 }
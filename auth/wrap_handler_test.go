@@ -28,6 +28,7 @@ var _ = Describe("WrapHandler", func() {
 		isSystemChan    <-chan bool
 		foundChan       <-chan bool
 		systemFoundChan <-chan bool
+		userNameChan    <-chan string
 	)
 
 	BeforeEach(func() {
@@ -41,6 +42,7 @@ var _ = Describe("WrapHandler", func() {
 		is := make(chan bool, 1)
 		f := make(chan bool, 1)
 		sf := make(chan bool, 1)
+		un := make(chan string, 1)
 
 		authenticated = a
 		teamNameChan = tn
@@ -49,6 +51,7 @@ var _ = Describe("WrapHandler", func() {
 		isSystemChan = is
 		foundChan = f
 		systemFoundChan = sf
+		userNameChan = un
 		simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			a <- auth.IsAuthenticated(r)
 			teamName, teamID, isAdmin, found := auth.GetTeam(r)
@@ -62,6 +65,7 @@ var _ = Describe("WrapHandler", func() {
 			if systemFound {
 				is <- system.(bool)
 			}
+			un <- auth.GetAuthUserName(r)
 		})
 
 		server = httptest.NewServer(auth.WrapHandler(
@@ -156,5 +160,25 @@ var _ = Describe("WrapHandler", func() {
 				Expect(<-systemFoundChan).To(BeFalse())
 			})
 		})
+
+		Context("when the userContextReader finds a user name", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetUserNameReturns("some-user", true)
+			})
+
+			It("passes the user name along in the request object", func() {
+				Expect(<-userNameChan).To(Equal("some-user"))
+			})
+		})
+
+		Context("when the userContextReader does not find a user name", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetUserNameReturns("", false)
+			})
+
+			It("does not pass a user name along in the request object", func() {
+				Expect(<-userNameChan).To(BeEmpty())
+			})
+		})
 	})
 })
@@ -1,7 +1,11 @@
 package buildreaper
 
 import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -10,6 +14,7 @@ import (
 type BuildReaperDB interface {
 	GetAllPipelines() ([]db.SavedPipeline, error)
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
+	GetUnreapedSucceededBuildIDs(jobID int) ([]int, error)
 }
 
 type BuildReaper interface {
@@ -21,6 +26,8 @@ type buildReaper struct {
 	db                BuildReaperDB
 	pipelineDBFactory db.PipelineDBFactory
 	batchSize         int
+	defaultRetention  atc.BuildLogRetention
+	clock             clock.Clock
 }
 
 func NewBuildReaper(
@@ -28,12 +35,16 @@ func NewBuildReaper(
 	db BuildReaperDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	batchSize int,
+	defaultRetention atc.BuildLogRetention,
+	clock clock.Clock,
 ) BuildReaper {
 	return &buildReaper{
 		logger:            logger,
 		db:                db,
 		pipelineDBFactory: pipelineDBFactory,
 		batchSize:         batchSize,
+		defaultRetention:  defaultRetention,
+		clock:             clock,
 	}
 }
 
@@ -58,7 +69,8 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.JobConfig.BuildLogsToRetain == 0 {
+			retention := job.JobConfig.LogRetention(br.defaultRetention)
+			if retention.Builds == 0 && retention.Days == 0 {
 				continue
 			}
 
@@ -97,53 +109,82 @@ func (br *buildReaper) Run() error {
 				)
 			}
 
-			buildIDsToConsiderDeleting := []int{}
-			for _, build := range buildsToConsiderDeleting {
-				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
-			}
+			firstBuildToRetain := 0
+			if retention.Builds > 0 {
+				buildsToRetain, _, err := pipelineDB.GetJobBuilds(
+					job.Job.Name,
+					db.Page{Limit: retention.Builds},
+				)
+				if err != nil {
+					br.logger.Error("could-not-get-job-builds-to-retain", err)
+					return err
+				}
 
-			buildsToRetain, _, err := pipelineDB.GetJobBuilds(
-				job.Job.Name,
-				db.Page{Limit: job.JobConfig.BuildLogsToRetain},
-			)
-			if err != nil {
-				br.logger.Error("could-not-get-job-builds-to-retain", err)
-				return err
-			}
+				if len(buildsToRetain) == 0 {
+					continue
+				}
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
+				firstBuildToRetain = buildsToRetain[len(buildsToRetain)-1].ID()
 			}
 
-			if len(buildsToRetain) == 0 {
-				continue
-			}
+			buildIDsToDelete := []int{}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+			// the latest succeeded builds are kept regardless of age or count;
+			// ones that were kept by earlier runs but have since been superseded
+			// are behind FirstLoggedBuildID, so they're reaped here instead
+			succeededBuildIDsToRetain := map[int]bool{}
+			if retention.MinimumSucceededBuilds > 0 {
+				succeededBuildIDs, err := br.db.GetUnreapedSucceededBuildIDs(job.Job.ID)
+				if err != nil {
+					br.logger.Error("could-not-get-succeeded-job-builds", err)
+					return err
+				}
 
-			buildIDsToDelete := []int{}
+				for i, buildID := range succeededBuildIDs {
+					if i < retention.MinimumSucceededBuilds {
+						succeededBuildIDsToRetain[buildID] = true
+					} else if buildID < job.Job.FirstLoggedBuildID {
+						buildIDsToDelete = append(buildIDsToDelete, buildID)
+					}
+				}
+			}
+
+			lastConsideredBuildID := 0
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
 
-				if build.ID() >= firstBuildToRetain || build.IsRunning() {
+				if build.IsRunning() {
 					break
 				}
 
+				beyondBuilds := firstBuildToRetain != 0 && build.ID() < firstBuildToRetain
+				beyondDays := retention.Days != 0 && br.expired(build, retention.Days)
+				if !beyondBuilds && !beyondDays {
+					break
+				}
+
+				lastConsideredBuildID = build.ID()
+
+				if succeededBuildIDsToRetain[build.ID()] {
+					continue
+				}
+
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
-			if len(buildIDsToDelete) == 0 {
-				continue
+			if len(buildIDsToDelete) > 0 {
+				err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+				if err != nil {
+					br.logger.Error("could-not-delete-build-events", err)
+					return err
+				}
 			}
 
-			err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
-			if err != nil {
-				br.logger.Error("could-not-delete-build-events", err)
-				return err
+			if lastConsideredBuildID == 0 {
+				continue
 			}
 
-			err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, buildIDsToDelete[len(buildIDsToDelete)-1]+1)
+			err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, lastConsideredBuildID+1)
 			if err != nil {
 				br.logger.Error("could-not-update-first-logged-build-id", err)
 				return err
@@ -153,3 +194,7 @@ func (br *buildReaper) Run() error {
 
 	return nil
 }
+
+func (br *buildReaper) expired(build db.Build, days int) bool {
+	return build.EndTime().Add(time.Duration(days) * 24 * time.Hour).Before(br.clock.Now())
+}
@@ -3,7 +3,9 @@ package buildreaper_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	. "github.com/concourse/atc/buildreaper"
@@ -21,12 +23,16 @@ var _ = Describe("BuildReaper", func() {
 		fakeBuildReaperDB     *buildreaperfakes.FakeBuildReaperDB
 		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
 		batchSize             int
+		defaultRetention      atc.BuildLogRetention
+		fakeClock             *fakeclock.FakeClock
 	)
 
 	BeforeEach(func() {
 		fakeBuildReaperDB = new(buildreaperfakes.FakeBuildReaperDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		batchSize = 5
+		defaultRetention = atc.BuildLogRetention{}
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
 	})
 
 	JustBeforeEach(func() {
@@ -36,6 +42,8 @@ var _ = Describe("BuildReaper", func() {
 			fakeBuildReaperDB,
 			fakePipelineDBFactory,
 			batchSize,
+			defaultRetention,
+			fakeClock,
 		)
 	})
 
@@ -491,6 +499,223 @@ var _ = Describe("BuildReaper", func() {
 			})
 		})
 
+		Context("when the job configures build log retention by days", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetDashboardReturns(db.Dashboard{
+					{
+						JobConfig: atc.JobConfig{
+							BuildLogRetention: &atc.BuildLogRetention{Days: 7},
+						},
+						Job: db.SavedJob{
+							Job:                db.Job{Name: "job-1"},
+							FirstLoggedBuildID: 6,
+						},
+					},
+				}, atc.GroupConfigs{}, nil)
+
+				expiredTime := fakeClock.Now().Add(-8 * 24 * time.Hour)
+				recentTime := fakeClock.Now().Add(-6 * 24 * time.Hour)
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{
+							finishedBuild(10, recentTime),
+							finishedBuild(9, recentTime),
+							finishedBuild(8, expiredTime),
+							finishedBuild(7, expiredTime),
+							finishedBuild(6, expiredTime),
+						}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			It("reaps the builds that finished more than that many days ago", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+			})
+
+			It("updates FirstLoggedBuildID to n+1, n = latest reaped build ID", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				actualJobName, actualNewFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+				Expect(actualJobName).To(Equal("job-1"))
+				Expect(actualNewFirstLoggedBuildID).To(Equal(9))
+			})
+		})
+
+		Context("when the job configures a minimum number of succeeded builds to retain", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetDashboardReturns(db.Dashboard{
+					{
+						JobConfig: atc.JobConfig{
+							BuildLogRetention: &atc.BuildLogRetention{
+								Builds:                 10,
+								MinimumSucceededBuilds: 2,
+							},
+						},
+						Job: db.SavedJob{
+							ID:                 7,
+							Job:                db.Job{Name: "job-1"},
+							FirstLoggedBuildID: 6,
+						},
+					},
+				}, atc.GroupConfigs{}, nil)
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Limit: 10}) {
+						return []db.Build{sb(25), sb(24), sb(23), sb(22), sb(21), sb(20), sb(19), sb(18), sb(17), sb(16)}, db.Pagination{}, nil
+					} else if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+
+				fakeBuildReaperDB.GetUnreapedSucceededBuildIDsReturns([]int{9, 7, 3, 2}, nil)
+			})
+
+			It("looks up the job's succeeded builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.GetUnreapedSucceededBuildIDsCallCount()).To(Equal(1))
+				Expect(fakeBuildReaperDB.GetUnreapedSucceededBuildIDsArgsForCall(0)).To(Equal(7))
+			})
+
+			It("reaps all but the latest succeeded builds, including ones retained by earlier runs", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(2, 3, 6, 8, 10))
+			})
+
+			It("updates FirstLoggedBuildID past the retained succeeded builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				actualJobName, actualNewFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+				Expect(actualJobName).To(Equal("job-1"))
+				Expect(actualNewFirstLoggedBuildID).To(Equal(11))
+			})
+
+			Context("when only retained succeeded builds are left to consider", func() {
+				BeforeEach(func() {
+					fakeBuildReaperDB.GetUnreapedSucceededBuildIDsReturns([]int{10, 9}, nil)
+
+					fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+						if job == "job-1" && page == (db.Page{Limit: 10}) {
+							return []db.Build{sb(20), sb(19), sb(18), sb(17), sb(16), sb(15), sb(14), sb(13), sb(12), sb(11)}, db.Pagination{}, nil
+						} else if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+							return []db.Build{sb(10), sb(9)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+				})
+
+				It("doesn't reap any builds", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+
+				It("still updates FirstLoggedBuildID past them", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+					_, actualNewFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+					Expect(actualNewFirstLoggedBuildID).To(Equal(11))
+				})
+			})
+
+			Context("when getting the succeeded builds fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("major malfunction")
+
+					fakeBuildReaperDB.GetUnreapedSucceededBuildIDsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					err := buildReaper.Run()
+					Expect(err).To(Equal(disaster))
+				})
+
+				It("doesn't reap any builds", func() {
+					buildReaper.Run()
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the job does not configure build log retention", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetDashboardReturns(db.Dashboard{
+					{
+						JobConfig: atc.JobConfig{},
+						Job: db.SavedJob{
+							Job:                db.Job{Name: "job-1"},
+							FirstLoggedBuildID: 6,
+						},
+					},
+				}, atc.GroupConfigs{}, nil)
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Limit: 10}) {
+						return []db.Build{sb(18), sb(17), sb(16), sb(15), sb(14), sb(13), sb(12), sb(11), sb(10), sb(9)}, db.Pagination{}, nil
+					} else if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			Context("when there is a default build log retention", func() {
+				BeforeEach(func() {
+					defaultRetention = atc.BuildLogRetention{Builds: 10}
+				})
+
+				It("reaps builds according to the default", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+				})
+			})
+
+			Context("when there is no default build log retention", func() {
+				It("skips the reaping step for that job", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.GetJobBuildsCallCount()).To(BeZero())
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when the dashboard job says retain 0 builds", func() {
 			BeforeEach(func() {
 				fakePipelineDB.GetDashboardReturns(db.Dashboard{
@@ -562,3 +787,11 @@ func runningBuild(id int) db.Build {
 	build.IsRunningReturns(true)
 	return build
 }
+
+func finishedBuild(id int, endTime time.Time) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.IsRunningReturns(false)
+	build.EndTimeReturns(endTime)
+	return build
+}
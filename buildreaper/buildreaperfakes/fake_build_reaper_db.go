@@ -24,6 +24,15 @@ type FakeBuildReaperDB struct {
 	deleteBuildEventsByBuildIDsReturns struct {
 		result1 error
 	}
+	GetUnreapedSucceededBuildIDsStub        func(jobID int) ([]int, error)
+	getUnreapedSucceededBuildIDsMutex       sync.RWMutex
+	getUnreapedSucceededBuildIDsArgsForCall []struct {
+		jobID int
+	}
+	getUnreapedSucceededBuildIDsReturns struct {
+		result1 []int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -92,6 +101,40 @@ func (fake *FakeBuildReaperDB) DeleteBuildEventsByBuildIDsReturns(result1 error)
 	}{result1}
 }
 
+func (fake *FakeBuildReaperDB) GetUnreapedSucceededBuildIDs(jobID int) ([]int, error) {
+	fake.getUnreapedSucceededBuildIDsMutex.Lock()
+	fake.getUnreapedSucceededBuildIDsArgsForCall = append(fake.getUnreapedSucceededBuildIDsArgsForCall, struct {
+		jobID int
+	}{jobID})
+	fake.recordInvocation("GetUnreapedSucceededBuildIDs", []interface{}{jobID})
+	fake.getUnreapedSucceededBuildIDsMutex.Unlock()
+	if fake.GetUnreapedSucceededBuildIDsStub != nil {
+		return fake.GetUnreapedSucceededBuildIDsStub(jobID)
+	} else {
+		return fake.getUnreapedSucceededBuildIDsReturns.result1, fake.getUnreapedSucceededBuildIDsReturns.result2
+	}
+}
+
+func (fake *FakeBuildReaperDB) GetUnreapedSucceededBuildIDsCallCount() int {
+	fake.getUnreapedSucceededBuildIDsMutex.RLock()
+	defer fake.getUnreapedSucceededBuildIDsMutex.RUnlock()
+	return len(fake.getUnreapedSucceededBuildIDsArgsForCall)
+}
+
+func (fake *FakeBuildReaperDB) GetUnreapedSucceededBuildIDsArgsForCall(i int) int {
+	fake.getUnreapedSucceededBuildIDsMutex.RLock()
+	defer fake.getUnreapedSucceededBuildIDsMutex.RUnlock()
+	return fake.getUnreapedSucceededBuildIDsArgsForCall[i].jobID
+}
+
+func (fake *FakeBuildReaperDB) GetUnreapedSucceededBuildIDsReturns(result1 []int, result2 error) {
+	fake.GetUnreapedSucceededBuildIDsStub = nil
+	fake.getUnreapedSucceededBuildIDsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -99,6 +142,8 @@ func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPipelinesMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.getUnreapedSucceededBuildIDsMutex.RLock()
+	defer fake.getUnreapedSucceededBuildIDsMutex.RUnlock()
 	return fake.invocations
 }
 
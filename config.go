@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/concourse/atc/template"
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
@@ -17,13 +19,18 @@ type ConfigResponse struct {
 	Config    *Config   `json:"config"`
 	Errors    []string  `json:"errors"`
 	RawConfig RawConfig `json:"raw_config"`
+
+	// only present when requesting the uninterpolated config
+	Template RawConfig          `json:"template,omitempty"`
+	Vars     template.Variables `json:"vars,omitempty"`
 }
 
 type Config struct {
-	Groups        GroupConfigs    `yaml:"groups" json:"groups" mapstructure:"groups"`
-	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
-	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
-	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+	Groups        GroupConfigs       `yaml:"groups" json:"groups" mapstructure:"groups"`
+	Resources     ResourceConfigs    `yaml:"resources" json:"resources" mapstructure:"resources"`
+	ResourceTypes ResourceTypes      `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
+	Jobs          JobConfigs         `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+	SerialGroups  SerialGroupConfigs `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 }
 
 type RawConfig string
@@ -50,18 +57,54 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, bool) {
 	return GroupConfig{}, false
 }
 
+// SerialGroupConfig configures how many builds may run at once across all
+// jobs in a serial group. Serial groups which are not configured allow one.
+type SerialGroupConfig struct {
+	Name        string `yaml:"name" json:"name" mapstructure:"name"`
+	MaxInFlight int    `yaml:"max_in_flight" json:"max_in_flight" mapstructure:"max_in_flight"`
+}
+
+type SerialGroupConfigs []SerialGroupConfig
+
+func (groups SerialGroupConfigs) Lookup(name string) (SerialGroupConfig, bool) {
+	for _, group := range groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+
+	return SerialGroupConfig{}, false
+}
+
+// MaxInFlight returns the number of builds allowed to run at once in the
+// given serial group.
+func (groups SerialGroupConfigs) MaxInFlight(name string) int {
+	group, found := groups.Lookup(name)
+	if !found {
+		return 1
+	}
+
+	return group.MaxInFlight
+}
+
 type ResourceConfig struct {
 	Name string `yaml:"name" json:"name" mapstructure:"name"`
 
 	Type       string `yaml:"type" json:"type" mapstructure:"type"`
 	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
 }
 
 type ResourceType struct {
-	Name   string `yaml:"name" json:"name" mapstructure:"name"`
-	Type   string `yaml:"type" json:"type" mapstructure:"type"`
-	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+	Name       string `yaml:"name" json:"name" mapstructure:"name"`
+	Type       string `yaml:"type" json:"type" mapstructure:"type"`
+	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
+	Privileged *bool  `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
+	Params     Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
+	CheckEvery string `yaml:"check_every,omitempty" json:"check_every,omitempty" mapstructure:"check_every"`
+	Tags       Tags   `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
 }
 
 type ResourceTypes []ResourceType
@@ -97,7 +140,43 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
+
+	// hooks run around the job's entire plan, like the equivalent step hooks
+	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
+	Ensure  *PlanConfig `yaml:"ensure,omitempty" json:"ensure,omitempty" mapstructure:"ensure"`
+	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
+
+	// run if the build is aborted while running the job's plan
+	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+}
+
+// BuildLogRetention configures which builds of a job have their logs reaped.
+// Logs are reaped from builds beyond the latest Builds builds, or which
+// finished more than Days days ago, except for the latest
+// MinimumSucceededBuilds successful builds. Zero values are unlimited.
+type BuildLogRetention struct {
+	Builds                 int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days                   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumSucceededBuilds int `yaml:"minimum_succeeded_builds,omitempty" json:"minimum_succeeded_builds,omitempty" mapstructure:"minimum_succeeded_builds"`
+}
+
+// LogRetention returns the job's build log retention. If only
+// build_logs_to_retain is configured, it overrides the number of builds of the
+// default; if neither is configured, the default is returned.
+func (config JobConfig) LogRetention(defaultRetention BuildLogRetention) BuildLogRetention {
+	if config.BuildLogRetention != nil {
+		return *config.BuildLogRetention
+	}
+
+	retention := defaultRetention
+	if config.BuildLogsToRetain != 0 {
+		retention.Builds = config.BuildLogsToRetain
+	}
+
+	return retention
 }
 
 func (config JobConfig) MaxInFlight() int {
@@ -242,6 +321,9 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan, which may limit how many steps run at once
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -261,11 +343,28 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
-	// task config path, e.g. foo/build.yml
+	// task config path, e.g. foo/build.yml; also the file read by LoadVar and
+	// SetPipeline
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a LoadVar plan
+	// name of the local var to load, available to later steps as ((.:name))
+	LoadVar string `yaml:"load_var,omitempty" json:"load_var,omitempty" mapstructure:"load_var"`
+	// how to parse the file: raw, trim, json, or yaml
+	Format string `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format"`
+	// redact the var's value from build output
+	Sensitive bool `yaml:"sensitive,omitempty" json:"sensitive,omitempty" mapstructure:"sensitive"`
+
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure in the build's team
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+	// vars to interpolate into the pipeline config
+	Vars map[string]interface{} `yaml:"vars,omitempty" json:"vars,omitempty" mapstructure:"vars"`
+	// files to load vars from, e.g. foo/vars.yml; overridden by Vars
+	VarFiles []string `yaml:"var_files,omitempty" json:"var_files,omitempty" mapstructure:"var_files"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -273,6 +372,9 @@ type PlanConfig struct {
 	InputMapping  map[string]string `yaml:"input_mapping,omitempty" json:"input_mapping,omitempty" mapstructure:"input_mapping"`
 	OutputMapping map[string]string `yaml:"output_mapping,omitempty" json:"output_mapping,omitempty" mapstructure:"output_mapping"`
 
+	// used by Task to override the container limits from the task config
+	ContainerLimits *ContainerLimits `yaml:"container_limits,omitempty" json:"container_limits,omitempty" mapstructure:"container_limits"`
+
 	// used to specify an image artifact from a previous build to be used as the image for a subsequent task container
 	ImageArtifactName string `yaml:"image,omitempty" json:"image,omitempty" mapstructure:"image"`
 
@@ -291,6 +393,12 @@ type PlanConfig struct {
 	// used on any step to execute on successful completion of the step
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 
+	// used on any step to execute when the build is aborted while the step is running
+	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+
+	// used on any step to execute when the step errors, as opposed to failing
+	Error *PlanConfig `yaml:"on_error,omitempty" json:"on_error,omitempty" mapstructure:"on_error"`
+
 	// used on any step to swallow failures and errors
 	Try *PlanConfig `yaml:"try,omitempty" json:"try,omitempty" mapstructure:"try"`
 
@@ -304,6 +412,30 @@ type PlanConfig struct {
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
+
+	// run the step once for every combination of the given vars' values
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+	// used with Across to limit how many combinations run at once
+	MaxInFlight int `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+	// used with Across to interrupt the remaining combinations once one has failed
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
+// InParallelConfig configures steps to run in parallel, like Aggregate, but
+// with at most Limit running at once. With FailFast, the remaining steps are
+// interrupted as soon as one fails.
+type InParallelConfig struct {
+	Steps    PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+	Limit    int          `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	FailFast bool         `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
+// AcrossVarConfig names a var and the values it takes on across the
+// combinations of a step. The values may be given inline or interpolated from
+// a pipeline var, e.g. values: ((go_versions)).
+type AcrossVarConfig struct {
+	Var    string        `yaml:"var" json:"var" mapstructure:"var"`
+	Values []interface{} `yaml:"values,omitempty" json:"values,omitempty" mapstructure:"values"`
 }
 
 func (config PlanConfig) Name() string {
@@ -323,6 +455,14 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.LoadVar != "" {
+		return config.LoadVar
+	}
+
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
 	return ""
 }
 
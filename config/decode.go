@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
+	"github.com/mitchellh/mapstructure"
+)
+
+var (
+	ErrFailedToConstructDecoder = errors.New("decoder could not be constructed")
+	ErrCouldNotDecode           = errors.New("data could not be decoded into config structure")
+)
+
+type ExtraKeysError struct {
+	extraKeys []string
+}
+
+// InstanceVarConflictError is returned when a var is given a different value
+// than the pipeline's instance var of the same name.
+type InstanceVarConflictError struct {
+	Name string
+}
+
+func (err InstanceVarConflictError) Error() string {
+	return fmt.Sprintf("var %s conflicts with the instance var of the same name", err.Name)
+}
+
+func (eke ExtraKeysError) Error() string {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintln(msg, "unknown/extra keys:")
+	for _, unusedKey := range eke.extraKeys {
+		fmt.Fprintf(msg, "  - %s\n", unusedKey)
+	}
+
+	return msg.String()
+}
+
+// DecodeConfig interpolates the vars and the pipeline's instance vars into the
+// config, as decoded from JSON or YAML, and decodes the result. The config is
+// also returned in its original form, so that it can be saved as the
+// pipeline's template, along with the vars excluding the instance vars.
+func DecodeConfig(configStructure interface{}, varsStructure interface{}, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, template.Variables, error) {
+	var vars template.Variables
+	if varsStructure != nil {
+		err := sanitizingDecode(varsStructure, &vars)
+		if err != nil {
+			return atc.Config{}, "", nil, ErrCouldNotDecode
+		}
+	}
+
+	evaluationVars := template.Variables{}
+	for name, val := range instanceVars {
+		evaluationVars[name] = val
+	}
+
+	for name, val := range vars {
+		instanceVal, found := instanceVars[name]
+		if found && !sameValue(val, instanceVal) {
+			return atc.Config{}, "", nil, InstanceVarConflictError{Name: name}
+		}
+
+		evaluationVars[name] = val
+	}
+
+	var templateStructure map[string]interface{}
+	err := sanitizingDecode(configStructure, &templateStructure)
+	if err != nil {
+		return atc.Config{}, "", nil, ErrCouldNotDecode
+	}
+
+	configTemplate, err := json.Marshal(templateStructure)
+	if err != nil {
+		return atc.Config{}, "", nil, ErrCouldNotDecode
+	}
+
+	var config atc.Config
+	var md mapstructure.Metadata
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &md,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			atc.SanitizeDecodeHook,
+			atc.VersionConfigDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return atc.Config{}, "", nil, ErrFailedToConstructDecoder
+	}
+
+	if err := decoder.Decode(evaluationVars.Evaluate(configStructure)); err != nil {
+		return atc.Config{}, "", nil, ErrCouldNotDecode
+	}
+
+	if len(md.Unused) != 0 {
+		return atc.Config{}, "", nil, ExtraKeysError{extraKeys: md.Unused}
+	}
+
+	return config, atc.RawConfig(configTemplate), vars, nil
+}
+
+// sameValue compares vars by their JSON encoding, as instance vars are decoded
+// from JSON while vars may have been decoded from YAML.
+func sameValue(a interface{}, b interface{}) bool {
+	aPayload, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	bPayload, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(aPayload, bPayload)
+}
+
+// sanitizingDecode converts YAML's map[interface{}]interface{} into
+// map[string]interface{} so that the result can be stored as JSON.
+func sanitizingDecode(input interface{}, result interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     result,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
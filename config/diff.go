@@ -0,0 +1,95 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/concourse/atc"
+)
+
+// Diff compares two configs, reporting the groups, resource types, resources,
+// and jobs that were added, removed, or changed. Names are listed in the order
+// that they appear in the config they are present in.
+func Diff(from atc.Config, to atc.Config) atc.ConfigDiff {
+	return atc.ConfigDiff{
+		Groups:        diffEntries(groupEntries(from.Groups), groupEntries(to.Groups)),
+		ResourceTypes: diffEntries(resourceTypeEntries(from.ResourceTypes), resourceTypeEntries(to.ResourceTypes)),
+		Resources:     diffEntries(resourceEntries(from.Resources), resourceEntries(to.Resources)),
+		Jobs:          diffEntries(jobEntries(from.Jobs), jobEntries(to.Jobs)),
+	}
+}
+
+type namedEntry struct {
+	name   string
+	config interface{}
+}
+
+func diffEntries(from []namedEntry, to []namedEntry) atc.ConfigChanges {
+	changes := atc.ConfigChanges{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []string{},
+	}
+
+	fromByName := map[string]interface{}{}
+	for _, entry := range from {
+		fromByName[entry.name] = entry.config
+	}
+
+	toByName := map[string]interface{}{}
+	for _, entry := range to {
+		toByName[entry.name] = entry.config
+	}
+
+	for _, entry := range from {
+		if _, found := toByName[entry.name]; !found {
+			changes.Removed = append(changes.Removed, entry.name)
+		}
+	}
+
+	for _, entry := range to {
+		old, found := fromByName[entry.name]
+		if !found {
+			changes.Added = append(changes.Added, entry.name)
+		} else if !reflect.DeepEqual(old, entry.config) {
+			changes.Changed = append(changes.Changed, entry.name)
+		}
+	}
+
+	return changes
+}
+
+func groupEntries(groups atc.GroupConfigs) []namedEntry {
+	entries := make([]namedEntry, len(groups))
+	for i, group := range groups {
+		entries[i] = namedEntry{name: group.Name, config: group}
+	}
+
+	return entries
+}
+
+func resourceTypeEntries(resourceTypes atc.ResourceTypes) []namedEntry {
+	entries := make([]namedEntry, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		entries[i] = namedEntry{name: resourceType.Name, config: resourceType}
+	}
+
+	return entries
+}
+
+func resourceEntries(resources atc.ResourceConfigs) []namedEntry {
+	entries := make([]namedEntry, len(resources))
+	for i, resource := range resources {
+		entries[i] = namedEntry{name: resource.Name, config: resource}
+	}
+
+	return entries
+}
+
+func jobEntries(jobs atc.JobConfigs) []namedEntry {
+	entries := make([]namedEntry, len(jobs))
+	for i, job := range jobs {
+		entries[i] = namedEntry{name: job.Name, config: job}
+	}
+
+	return entries
+}
@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var from, to atc.Config
+
+	BeforeEach(func() {
+		from = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			ResourceTypes: atc.ResourceTypes{
+				{Name: "some-type", Type: "docker-image"},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "unchanged-resource", Type: "git"},
+				{Name: "changed-resource", Type: "git", Source: atc.Source{"uri": "old"}},
+				{Name: "removed-resource", Type: "git"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Serial: false},
+			},
+		}
+
+		to = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "added-resource", Type: "git"},
+				{Name: "changed-resource", Type: "git", Source: atc.Source{"uri": "new"}},
+				{Name: "unchanged-resource", Type: "git"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Serial: true},
+				{Name: "some-other-job"},
+			},
+		}
+	})
+
+	It("reports the entries that were added, removed, and changed", func() {
+		Expect(config.Diff(from, to)).To(Equal(atc.ConfigDiff{
+			Groups: atc.ConfigChanges{
+				Added:   []string{},
+				Removed: []string{},
+				Changed: []string{},
+			},
+			ResourceTypes: atc.ConfigChanges{
+				Added:   []string{},
+				Removed: []string{"some-type"},
+				Changed: []string{},
+			},
+			Resources: atc.ConfigChanges{
+				Added:   []string{"added-resource"},
+				Removed: []string{"removed-resource"},
+				Changed: []string{"changed-resource"},
+			},
+			Jobs: atc.ConfigChanges{
+				Added:   []string{"some-other-job"},
+				Removed: []string{},
+				Changed: []string{"some-job"},
+			},
+		}))
+	})
+
+	It("does not consider reordering a change", func() {
+		diff := config.Diff(from, from)
+		Expect(diff.Resources.Changed).To(BeEmpty())
+
+		reordered := from
+		reordered.Resources = atc.ResourceConfigs{from.Resources[2], from.Resources[0], from.Resources[1]}
+
+		diff = config.Diff(from, reordered)
+		Expect(diff.Resources.Added).To(BeEmpty())
+		Expect(diff.Resources.Removed).To(BeEmpty())
+		Expect(diff.Resources.Changed).To(BeEmpty())
+	})
+})
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/concourse/atc"
+import (
+	"strings"
+
+	"github.com/concourse/atc"
+)
 
 // these are expressly tucked away so as to avoid accidental use in public API
 // endpoints as that could leak credentials
@@ -20,12 +24,41 @@ type JobOutput struct {
 	Resource string
 }
 
+// CrossPipelineJob splits a passed constraint of the form pipeline/job into
+// the pipeline and job it refers to. Constraints naming a job in the given
+// config, including ones which happen to contain a slash, are not
+// cross-pipeline.
+func CrossPipelineJob(config atc.Config, passed string) (string, string, bool) {
+	if _, found := config.Jobs.Lookup(passed); found {
+		return "", "", false
+	}
+
+	segments := strings.SplitN(passed, "/", 2)
+	if len(segments) != 2 {
+		return "", "", false
+	}
+
+	return segments[0], segments[1], true
+}
+
 func JobInputs(config atc.JobConfig) []JobInput {
-	return collectInputs(atc.PlanConfig{Do: &config.Plan})
+	return collectInputs(jobPlan(config))
 }
 
 func JobOutputs(config atc.JobConfig) []JobOutput {
-	return collectOutputs(atc.PlanConfig{Do: &config.Plan})
+	return collectOutputs(jobPlan(config))
+}
+
+// jobPlan wraps the job's plan in its hooks, so that the steps in them are
+// treated the same as steps in step hooks.
+func jobPlan(config atc.JobConfig) atc.PlanConfig {
+	return atc.PlanConfig{
+		Do:      &config.Plan,
+		Success: config.Success,
+		Failure: config.Failure,
+		Abort:   config.Abort,
+		Ensure:  config.Ensure,
+	}
 }
 
 func collectInputs(plan atc.PlanConfig) []JobInput {
@@ -39,6 +72,14 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		inputs = append(inputs, collectInputs(*plan.Failure)...)
 	}
 
+	if plan.Abort != nil {
+		inputs = append(inputs, collectInputs(*plan.Abort)...)
+	}
+
+	if plan.Error != nil {
+		inputs = append(inputs, collectInputs(*plan.Error)...)
+	}
+
 	if plan.Ensure != nil {
 		inputs = append(inputs, collectInputs(*plan.Ensure)...)
 	}
@@ -59,6 +100,12 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			inputs = append(inputs, collectInputs(p)...)
+		}
+	}
+
 	if plan.Get != "" {
 		get := plan.Get
 
@@ -92,6 +139,14 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		outputs = append(outputs, collectOutputs(*plan.Failure)...)
 	}
 
+	if plan.Abort != nil {
+		outputs = append(outputs, collectOutputs(*plan.Abort)...)
+	}
+
+	if plan.Error != nil {
+		outputs = append(outputs, collectOutputs(*plan.Error)...)
+	}
+
 	if plan.Ensure != nil {
 		outputs = append(outputs, collectOutputs(*plan.Ensure)...)
 	}
@@ -116,6 +171,16 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		return outputs
 	}
 
+	if plan.InParallel != nil {
+		var outputs []JobOutput
+
+		for _, p := range plan.InParallel.Steps {
+			outputs = append(outputs, collectOutputs(p)...)
+		}
+
+		return outputs
+	}
+
 	if plan.Put != "" {
 		put := plan.Put
 
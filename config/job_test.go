@@ -174,6 +174,78 @@ var _ = Describe("Job config", func() {
 				})
 			})
 
+			Context("when a plan has abort and error hooks on a get", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							Get: "a",
+							Abort: &atc.PlanConfig{
+								Get: "b",
+							},
+							Error: &atc.PlanConfig{
+								Get: "c",
+							},
+						},
+					}
+				})
+
+				It("returns an input config for all get plans", func() {
+					Expect(inputs).To(ConsistOf(
+						config.JobInput{
+							Name:     "a",
+							Resource: "a",
+						},
+						config.JobInput{
+							Name:     "b",
+							Resource: "b",
+						},
+						config.JobInput{
+							Name:     "c",
+							Resource: "c",
+						},
+					))
+				})
+			})
+
+			Context("when the job has hooks with gets", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							Get: "a",
+						},
+					}
+					jobConfig.Success = &atc.PlanConfig{Get: "b"}
+					jobConfig.Failure = &atc.PlanConfig{Get: "c"}
+					jobConfig.Ensure = &atc.PlanConfig{Get: "d"}
+					jobConfig.Abort = &atc.PlanConfig{Get: "e"}
+				})
+
+				It("returns an input config for all get plans", func() {
+					Expect(inputs).To(ConsistOf(
+						config.JobInput{
+							Name:     "a",
+							Resource: "a",
+						},
+						config.JobInput{
+							Name:     "b",
+							Resource: "b",
+						},
+						config.JobInput{
+							Name:     "c",
+							Resource: "c",
+						},
+						config.JobInput{
+							Name:     "d",
+							Resource: "d",
+						},
+						config.JobInput{
+							Name:     "e",
+							Resource: "e",
+						},
+					))
+				})
+			})
+
 			Context("when a resource is specified", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
@@ -233,6 +305,39 @@ var _ = Describe("Job config", func() {
 				})
 			})
 
+			Context("when an in_parallel plan is the first step", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							InParallel: &atc.InParallelConfig{
+								Steps: atc.PlanSequence{
+									{Get: "a"},
+									{Put: "y"},
+									{Get: "b", Resource: "some-resource", Passed: []string{"x"}},
+								},
+								Limit: 1,
+							},
+						},
+					}
+				})
+
+				It("returns an input config for all get plans", func() {
+					Expect(inputs).To(Equal([]config.JobInput{
+						{
+							Name:     "a",
+							Resource: "a",
+							Trigger:  false,
+						},
+						{
+							Name:     "b",
+							Resource: "some-resource",
+							Passed:   []string{"x"},
+							Trigger:  false,
+						},
+					}))
+				})
+			})
+
 			Context("when an overly complicated aggregate plan is the first step", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
@@ -451,4 +556,37 @@ var _ = Describe("Job config", func() {
 			})
 		})
 	})
+
+	Describe("CrossPipelineJob", func() {
+		var pipelineConfig atc.Config
+
+		BeforeEach(func() {
+			pipelineConfig = atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+					{Name: "some/slashed-job"},
+				},
+			}
+		})
+
+		It("splits pipeline/job constraints", func() {
+			pipelineName, jobName, isCrossPipeline := config.CrossPipelineJob(pipelineConfig, "other-pipeline/other-job")
+			Expect(isCrossPipeline).To(BeTrue())
+			Expect(pipelineName).To(Equal("other-pipeline"))
+			Expect(jobName).To(Equal("other-job"))
+		})
+
+		It("does not treat jobs in the same pipeline as cross-pipeline", func() {
+			_, _, isCrossPipeline := config.CrossPipelineJob(pipelineConfig, "some-job")
+			Expect(isCrossPipeline).To(BeFalse())
+
+			_, _, isCrossPipeline = config.CrossPipelineJob(pipelineConfig, "some/slashed-job")
+			Expect(isCrossPipeline).To(BeFalse())
+		})
+
+		It("does not treat unknown jobs without a pipeline as cross-pipeline", func() {
+			_, _, isCrossPipeline := config.CrossPipelineJob(pipelineConfig, "bogus-job")
+			Expect(isCrossPipeline).To(BeFalse())
+		})
+	})
 })
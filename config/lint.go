@@ -0,0 +1,299 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// Codes of the warnings produced by Lint. They are part of the API, so they
+// must not change once released.
+const (
+	LintUnusedResource        = "unused-resource"
+	LintUngroupedJob          = "ungrouped-job"
+	LintPassedWithoutResource = "passed-without-resource"
+	LintPassedCycle           = "passed-cycle"
+)
+
+func newLintWarning(code string, path string, message string) Warning {
+	return Warning{
+		Type:    "lint",
+		Code:    code,
+		Path:    path,
+		Message: message,
+	}
+}
+
+// Lint looks for mistakes in the design of a pipeline which do not make its
+// config invalid, e.g. resources which are never used. It assumes the config
+// has been validated already.
+func Lint(c atc.Config) []Warning {
+	warnings := []Warning{}
+
+	warnings = append(warnings, lintUnusedResources(c)...)
+	warnings = append(warnings, lintUngroupedJobs(c)...)
+	warnings = append(warnings, lintPassedResources(c)...)
+	warnings = append(warnings, lintPassedCycles(c)...)
+
+	return warnings
+}
+
+func lintUnusedResources(c atc.Config) []Warning {
+	warnings := []Warning{}
+
+	used := map[string]bool{}
+	for _, job := range c.Jobs {
+		for _, input := range JobInputs(job) {
+			used[input.Resource] = true
+		}
+
+		for _, output := range JobOutputs(job) {
+			used[output.Resource] = true
+		}
+	}
+
+	for _, resource := range c.Resources {
+		if !used[resource.Name] {
+			warnings = append(warnings, newLintWarning(
+				LintUnusedResource,
+				"resources."+resource.Name,
+				fmt.Sprintf("resource '%s' is not used by any job", resource.Name),
+			))
+		}
+	}
+
+	return warnings
+}
+
+func lintUngroupedJobs(c atc.Config) []Warning {
+	warnings := []Warning{}
+
+	if len(c.Groups) == 0 {
+		// all jobs are shown when there are no groups
+		return warnings
+	}
+
+	grouped := map[string]bool{}
+	for _, group := range c.Groups {
+		for _, job := range group.Jobs {
+			grouped[job] = true
+		}
+	}
+
+	for _, job := range c.Jobs {
+		if !grouped[job.Name] {
+			warnings = append(warnings, newLintWarning(
+				LintUngroupedJob,
+				"jobs."+job.Name,
+				fmt.Sprintf("job '%s' is not in any group", job.Name),
+			))
+		}
+	}
+
+	return warnings
+}
+
+func lintPassedResources(c atc.Config) []Warning {
+	warnings := []Warning{}
+
+	jobResources := map[string]map[string]bool{}
+	for _, job := range c.Jobs {
+		resources := map[string]bool{}
+
+		for _, input := range JobInputs(job) {
+			resources[input.Resource] = true
+		}
+
+		for _, output := range JobOutputs(job) {
+			resources[output.Resource] = true
+		}
+
+		jobResources[job.Name] = resources
+	}
+
+	for _, job := range c.Jobs {
+		walkJob("jobs."+job.Name, job, func(identifier string, plan atc.PlanConfig) {
+			if plan.Get == "" {
+				return
+			}
+
+			resource := plan.Get
+			if plan.Resource != "" {
+				resource = plan.Resource
+			}
+
+			for _, passed := range plan.Passed {
+				resources, found := jobResources[passed]
+				if !found {
+					// either in another pipeline or caught by validation
+					continue
+				}
+
+				if !resources[resource] {
+					warnings = append(warnings, newLintWarning(
+						LintPassedWithoutResource,
+						identifier,
+						fmt.Sprintf("get '%s' has passed constraint on job '%s', which never gets or puts resource '%s'", plan.Get, passed, resource),
+					))
+				}
+			}
+		})
+	}
+
+	return warnings
+}
+
+func lintPassedCycles(c atc.Config) []Warning {
+	warnings := []Warning{}
+
+	dependencies := map[string][]string{}
+	for _, job := range c.Jobs {
+		seen := map[string]bool{}
+
+		for _, input := range JobInputs(job) {
+			for _, passed := range input.Passed {
+				if _, found := c.Jobs.Lookup(passed); !found || seen[passed] {
+					continue
+				}
+
+				seen[passed] = true
+				dependencies[job.Name] = append(dependencies[job.Name], passed)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := map[string]int{}
+	path := []string{}
+
+	var visit func(string)
+	visit = func(job string) {
+		states[job] = visiting
+		path = append(path, job)
+
+		for _, dependency := range dependencies[job] {
+			switch states[dependency] {
+			case unvisited:
+				visit(dependency)
+
+			case visiting:
+				var cycle []string
+				for i, name := range path {
+					if name == dependency {
+						cycle = append([]string{}, path[i:]...)
+						break
+					}
+				}
+
+				cycle = append(cycle, dependency)
+
+				warnings = append(warnings, newLintWarning(
+					LintPassedCycle,
+					"jobs."+dependency,
+					fmt.Sprintf("passed constraints form a cycle: %s", strings.Join(cycle, " -> ")),
+				))
+			}
+		}
+
+		path = path[:len(path)-1]
+		states[job] = visited
+	}
+
+	for _, job := range c.Jobs {
+		if states[job.Name] == unvisited {
+			visit(job.Name)
+		}
+	}
+
+	return warnings
+}
+
+// walkJob calls visit with each step of the job's plan and hooks, identified
+// the same way as in walkPlan.
+func walkJob(identifier string, job atc.JobConfig, visit func(string, atc.PlanConfig)) {
+	walkPlan(identifier+".plan", atc.PlanConfig{Do: &job.Plan}, visit)
+
+	if job.Ensure != nil {
+		walkPlan(identifier+".ensure", *job.Ensure, visit)
+	}
+
+	if job.Success != nil {
+		walkPlan(identifier+".on_success", *job.Success, visit)
+	}
+
+	if job.Failure != nil {
+		walkPlan(identifier+".on_failure", *job.Failure, visit)
+	}
+
+	if job.Abort != nil {
+		walkPlan(identifier+".on_abort", *job.Abort, visit)
+	}
+}
+
+// walkPlan calls visit with each step of the plan and its hooks. Steps are
+// identified the same way as in validatePlan, and hooks by their keys in the
+// config.
+func walkPlan(identifier string, plan atc.PlanConfig, visit func(string, atc.PlanConfig)) {
+	switch {
+	case plan.Do != nil:
+		for i, plan := range *plan.Do {
+			walkPlan(fmt.Sprintf("%s[%d]", identifier, i), plan, visit)
+		}
+
+	case plan.Aggregate != nil:
+		for i, plan := range *plan.Aggregate {
+			walkPlan(fmt.Sprintf("%s.aggregate[%d]", identifier, i), plan, visit)
+		}
+
+	case plan.InParallel != nil:
+		for i, plan := range plan.InParallel.Steps {
+			walkPlan(fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i), plan, visit)
+		}
+
+	case plan.Get != "":
+		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
+
+	case plan.Put != "":
+		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
+
+	case plan.Task != "":
+		identifier = fmt.Sprintf("%s.task.%s", identifier, plan.Task)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+	case plan.Try != nil:
+		walkPlan(identifier+".try", *plan.Try, visit)
+	}
+
+	visit(identifier, plan)
+
+	if plan.Ensure != nil {
+		walkPlan(identifier+".ensure", *plan.Ensure, visit)
+	}
+
+	if plan.Success != nil {
+		walkPlan(identifier+".on_success", *plan.Success, visit)
+	}
+
+	if plan.Failure != nil {
+		walkPlan(identifier+".on_failure", *plan.Failure, visit)
+	}
+
+	if plan.Abort != nil {
+		walkPlan(identifier+".on_abort", *plan.Abort, visit)
+	}
+
+	if plan.Error != nil {
+		walkPlan(identifier+".on_error", *plan.Error, visit)
+	}
+}
@@ -0,0 +1,181 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lint", func() {
+	var (
+		config   atc.Config
+		warnings []Warning
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "some-type"},
+				{Name: "some-other-resource", Type: "some-type"},
+			},
+
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource"},
+						{Put: "some-other-resource"},
+					},
+				},
+				{
+					Name: "some-other-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource", Passed: []string{"some-job"}},
+						{Get: "some-other-resource", Passed: []string{"some-job"}},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		warnings = Lint(config)
+	})
+
+	Context("when the pipeline is well designed", func() {
+		It("returns no warnings", func() {
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("when a resource is not used by any job", func() {
+		BeforeEach(func() {
+			config.Resources = append(config.Resources, atc.ResourceConfig{
+				Name: "unused-resource",
+				Type: "some-type",
+			})
+		})
+
+		It("warns about it", func() {
+			Expect(warnings).To(Equal([]Warning{
+				{
+					Type:    "lint",
+					Code:    LintUnusedResource,
+					Path:    "resources.unused-resource",
+					Message: "resource 'unused-resource' is not used by any job",
+				},
+			}))
+		})
+	})
+
+	Context("when a resource is only used under another name", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0] = atc.PlanConfig{Get: "renamed", Resource: "some-resource"}
+			config.Jobs[1].Plan[0] = atc.PlanConfig{Get: "renamed", Resource: "some-resource", Passed: []string{"some-job"}}
+		})
+
+		It("does not warn", func() {
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("when there are groups", func() {
+		BeforeEach(func() {
+			config.Groups = atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			}
+		})
+
+		It("warns about jobs not in any group", func() {
+			Expect(warnings).To(Equal([]Warning{
+				{
+					Type:    "lint",
+					Code:    LintUngroupedJob,
+					Path:    "jobs.some-other-job",
+					Message: "job 'some-other-job' is not in any group",
+				},
+			}))
+		})
+	})
+
+	Context("when a passed constraint names a job without the resource", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan = atc.PlanSequence{
+				{Get: "some-resource"},
+			}
+
+			config.Jobs[1].Ensure = &atc.PlanConfig{
+				Get:    "some-resource",
+				Passed: []string{"some-job", "some-other-pipeline/some-job"},
+			}
+		})
+
+		It("warns about the get step", func() {
+			Expect(warnings).To(Equal([]Warning{
+				{
+					Type:    "lint",
+					Code:    LintPassedWithoutResource,
+					Path:    "jobs.some-other-job.plan[1].get.some-other-resource",
+					Message: "get 'some-other-resource' has passed constraint on job 'some-job', which never gets or puts resource 'some-other-resource'",
+				},
+			}))
+		})
+	})
+
+	Context("when a passed constraint in a hook names a job without the resource", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan = atc.PlanSequence{
+				{Get: "some-resource"},
+			}
+
+			config.Jobs[1].Plan = atc.PlanSequence{
+				{Get: "some-resource", Passed: []string{"some-job"}},
+			}
+
+			config.Jobs[1].Failure = &atc.PlanConfig{
+				Put: "some-resource",
+				Error: &atc.PlanConfig{
+					Get:    "some-other-resource",
+					Passed: []string{"some-job"},
+				},
+			}
+		})
+
+		It("identifies the step by the hooks' keys", func() {
+			Expect(warnings).To(Equal([]Warning{
+				{
+					Type:    "lint",
+					Code:    LintPassedWithoutResource,
+					Path:    "jobs.some-other-job.on_failure.put.some-resource.on_error.get.some-other-resource",
+					Message: "get 'some-other-resource' has passed constraint on job 'some-job', which never gets or puts resource 'some-other-resource'",
+				},
+			}))
+		})
+	})
+
+	Context("when passed constraints form a cycle", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Passed = []string{"some-third-job"}
+
+			config.Jobs = append(config.Jobs, atc.JobConfig{
+				Name: "some-third-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-resource", Passed: []string{"some-other-job"}},
+				},
+			})
+		})
+
+		It("warns about the cycle once", func() {
+			Expect(warnings).To(Equal([]Warning{
+				{
+					Type:    "lint",
+					Code:    LintPassedCycle,
+					Path:    "jobs.some-job",
+					Message: "passed constraints form a cycle: some-job -> some-third-job -> some-other-job -> some-job",
+				},
+			}))
+		})
+	})
+})
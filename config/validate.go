@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
 )
 
 func formatErr(groupName string, err error) string {
@@ -24,6 +26,11 @@ func formatErr(groupName string, err error) string {
 type Warning struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
+
+	// Code and Path identify lint warnings and the part of the config they
+	// are about
+	Code string `json:"code,omitempty"`
+	Path string `json:"path,omitempty"`
 }
 
 func newDeprecationWarning(message string) Warning {
@@ -33,7 +40,29 @@ func newDeprecationWarning(message string) Warning {
 	}
 }
 
+// Validator validates a pipeline config, returning its warnings and, if it is
+// invalid, its errors.
+type Validator func(atc.Config) ([]Warning, []string)
+
 func ValidateConfig(c atc.Config) ([]Warning, []string) {
+	warnings, errorMessages := validateConfig(c)
+
+	variablesErr := validateVariables(c)
+	if variablesErr != nil {
+		errorMessages = append(errorMessages, formatErr("variables", variablesErr))
+	}
+
+	return warnings, errorMessages
+}
+
+// ValidateConfigWithSecrets is like ValidateConfig, but allows ((placeholders))
+// to remain in the config, as they will be resolved from a credential manager
+// when the build runs.
+func ValidateConfigWithSecrets(c atc.Config) ([]Warning, []string) {
+	return validateConfig(c)
+}
+
+func validateConfig(c atc.Config) ([]Warning, []string) {
 	warnings := []Warning{}
 	errorMessages := []string{}
 
@@ -58,9 +87,84 @@ func ValidateConfig(c atc.Config) ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	serialGroupsErr := validateSerialGroups(c)
+	if serialGroupsErr != nil {
+		errorMessages = append(errorMessages, formatErr("serial groups", serialGroupsErr))
+	}
+
 	return warnings, errorMessages
 }
 
+func validateVariables(c atc.Config) error {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	var tree interface{}
+	err = json.Unmarshal(payload, &tree)
+	if err != nil {
+		return err
+	}
+
+	errorMessages := []string{}
+
+	for _, name := range template.Placeholders(withoutAcrossVars(tree)) {
+		if strings.HasPrefix(name, ".:") {
+			// given a value by a load_var step while the build runs
+			continue
+		}
+
+		errorMessages = append(errorMessages, fmt.Sprintf("undefined variable '%s'", name))
+	}
+
+	return compositeErr(errorMessages)
+}
+
+// withoutAcrossVars removes the placeholders for each step's across vars from
+// the step, as they are only given values when the build plan is constructed.
+func withoutAcrossVars(obj interface{}) interface{} {
+	switch val := obj.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			stripped[k] = withoutAcrossVars(v)
+		}
+
+		acrossVars := map[string]bool{}
+		if across, ok := val["across"].([]interface{}); ok {
+			for _, acrossVar := range across {
+				if acrossVar, ok := acrossVar.(map[string]interface{}); ok {
+					if name, ok := acrossVar["var"].(string); ok {
+						acrossVars[name] = true
+					}
+				}
+			}
+		}
+
+		if len(acrossVars) == 0 {
+			return stripped
+		}
+
+		resolved, _ := template.Resolve(stripped, func(name string) (interface{}, bool, error) {
+			return "", acrossVars[name], nil
+		})
+
+		return resolved
+
+	case []interface{}:
+		stripped := make([]interface{}, len(val))
+		for i, v := range val {
+			stripped[i] = withoutAcrossVars(v)
+		}
+
+		return stripped
+
+	default:
+		return obj
+	}
+}
+
 func validateGroups(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -85,6 +189,50 @@ func validateGroups(c atc.Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateSerialGroups(c atc.Config) error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	usedGroups := map[string]bool{}
+	for _, job := range c.Jobs {
+		for _, serialGroup := range job.SerialGroups {
+			usedGroups[serialGroup] = true
+		}
+	}
+
+	for i, serialGroup := range c.SerialGroups {
+		var identifier string
+		if serialGroup.Name == "" {
+			identifier = fmt.Sprintf("serial_groups[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("serial_groups.%s", serialGroup.Name)
+		}
+
+		if other, exists := names[serialGroup.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"serial_groups[%d] and serial_groups[%d] have the same name ('%s')",
+					other, i, serialGroup.Name))
+		} else if serialGroup.Name != "" {
+			names[serialGroup.Name] = i
+		}
+
+		if serialGroup.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		} else if !usedGroups[serialGroup.Name] {
+			errorMessages = append(errorMessages, identifier+" is not used by any job")
+		}
+
+		if serialGroup.MaxInFlight < 1 {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf("%s has invalid max_in_flight: %d", identifier, serialGroup.MaxInFlight))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func validateResources(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -148,6 +296,14 @@ func validateResourceTypes(c atc.Config) error {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resourceType.CheckEvery != "" {
+			_, err := time.ParseDuration(resourceType.CheckEvery)
+			if err != nil {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has invalid check_every: %s", identifier, err))
+			}
+		}
 	}
 
 	return compositeErr(errorMessages)
@@ -187,14 +343,83 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 			)
 		}
 
+		if job.BuildLogRetention != nil {
+			errorMessages = append(errorMessages, validateBuildLogRetention(identifier, job)...)
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
+
+		if job.Ensure != nil {
+			planWarnings, planErrMessages := validatePlan(c, identifier+".ensure", *job.Ensure)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+		if job.Success != nil {
+			planWarnings, planErrMessages := validatePlan(c, identifier+".success", *job.Success)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+		if job.Failure != nil {
+			planWarnings, planErrMessages := validatePlan(c, identifier+".failure", *job.Failure)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+		if job.Abort != nil {
+			planWarnings, planErrMessages := validatePlan(c, identifier+".abort", *job.Abort)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	}
 
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateBuildLogRetention(identifier string, job atc.JobConfig) []string {
+	errorMessages := []string{}
+
+	if job.BuildLogsToRetain != 0 {
+		errorMessages = append(errorMessages, identifier+" has both build_logs_to_retain and build_log_retention")
+	}
+
+	retention := job.BuildLogRetention
+
+	if retention.Builds < 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(" has negative build_log_retention.builds: %d", retention.Builds),
+		)
+	}
+
+	if retention.Days < 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(" has negative build_log_retention.days: %d", retention.Days),
+		)
+	}
+
+	if retention.MinimumSucceededBuilds < 0 {
+		errorMessages = append(
+			errorMessages,
+			identifier+fmt.Sprintf(" has negative build_log_retention.minimum_succeeded_builds: %d", retention.MinimumSucceededBuilds),
+		)
+	}
+
+	if retention.Builds > 0 && retention.MinimumSucceededBuilds > retention.Builds {
+		errorMessages = append(
+			errorMessages,
+			identifier+" has build_log_retention.minimum_succeeded_builds greater than build_log_retention.builds",
+		)
+	}
+
+	return errorMessages
+}
+
 func doesAnyStepMatch(planSequence atc.PlanSequence, predicate func(step atc.PlanConfig) bool) bool {
 	for _, planStep := range planSequence {
 		if planStep.Aggregate != nil {
@@ -203,6 +428,12 @@ func doesAnyStepMatch(planSequence atc.PlanSequence, predicate func(step atc.Pla
 			}
 		}
 
+		if planStep.InParallel != nil {
+			if doesAnyStepMatch(planStep.InParallel.Steps, predicate) {
+				return true
+			}
+		}
+
 		if planStep.Do != nil {
 			if doesAnyStepMatch(*planStep.Do, predicate) {
 				return true
@@ -264,6 +495,14 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("task")
 	}
 
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -272,6 +511,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -300,11 +543,23 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+		if plan.InParallel.Limit < 0 {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(".in_parallel.limit has an invalid value (%d)", plan.InParallel.Limit))
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "container_limits", "format", "sensitive", "vars", "var_files"},
 			plan, identifier)...,
 		)
 
@@ -334,6 +589,23 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		}
 
 		for _, job := range plan.Passed {
+			if pipelineName, jobName, isCrossPipeline := CrossPipelineJob(c, job); isCrossPipeline {
+				// jobs in other pipelines can't be checked until the
+				// scheduler loads them
+				if pipelineName == "" || jobName == "" {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed references an invalid cross-pipeline job ('%s')",
+							identifier,
+							job,
+						),
+					)
+				}
+
+				continue
+			}
+
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
 				errorMessages = append(
@@ -379,7 +651,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "container_limits", "format", "sensitive", "vars", "var_files"},
 			plan, identifier)...,
 		)
 
@@ -427,7 +699,37 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "format", "sensitive", "vars", "var_files"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a file")
+		}
+
+		switch plan.Format {
+		case "", "raw", "trim", "json", "yaml":
+		default:
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an unknown format ('%s')", plan.Format))
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "container_limits", "vars", "var_files"},
+			plan, identifier)...,
+		)
+
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "container_limits", "format", "sensitive"},
 			plan, identifier)...,
 		)
 
@@ -459,6 +761,20 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
+	if plan.Abort != nil {
+		subIdentifier := fmt.Sprintf("%s.abort", identifier)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Abort)
+		warnings = append(warnings, planWarnings...)
+		errorMessages = append(errorMessages, planErrMessages...)
+	}
+
+	if plan.Error != nil {
+		subIdentifier := fmt.Sprintf("%s.error", identifier)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Error)
+		warnings = append(warnings, planWarnings...)
+		errorMessages = append(errorMessages, planErrMessages...)
+	}
+
 	if plan.Timeout != "" {
 		_, err := time.ParseDuration(plan.Timeout)
 		if err != nil {
@@ -472,9 +788,52 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if len(plan.Across) > 0 {
+		errorMessages = append(errorMessages, validateAcross(identifier, plan)...)
+	} else {
+		if plan.MaxInFlight != 0 {
+			errorMessages = append(errorMessages, identifier+".max_in_flight is only applicable to steps with across")
+		}
+
+		if plan.FailFast {
+			errorMessages = append(errorMessages, identifier+".fail_fast is only applicable to steps with across")
+		}
+	}
+
 	return warnings, errorMessages
 }
 
+func validateAcross(identifier string, plan atc.PlanConfig) []string {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, acrossVar := range plan.Across {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if acrossVar.Var == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no var")
+		} else if other, exists := names[acrossVar.Var]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s.across[%d] and %s.across[%d] have the same var ('%s')",
+					identifier, other, identifier, i, acrossVar.Var))
+		} else {
+			names[acrossVar.Var] = i
+		}
+
+		if len(acrossVar.Values) == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" has no values")
+		}
+	}
+
+	if plan.MaxInFlight < 0 {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(".max_in_flight has an invalid value (%d)", plan.MaxInFlight))
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -505,6 +864,26 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "container_limits":
+			if plan.ContainerLimits != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "format":
+			if plan.Format != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "sensitive":
+			if plan.Sensitive {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "vars":
+			if len(plan.Vars) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "var_files":
+			if len(plan.VarFiles) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -121,6 +121,81 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("serial groups", func() {
+		BeforeEach(func() {
+			config.Jobs[1].SerialGroups = []string{"some-serial-group"}
+		})
+
+		Context("when a serial group is configured with a capacity", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{Name: "some-serial-group", MaxInFlight: 3},
+				}
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a serial group has no name", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{MaxInFlight: 3},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups[0] has no name"))
+			})
+		})
+
+		Context("when two serial groups have the same name", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{Name: "some-serial-group", MaxInFlight: 3},
+					{Name: "some-serial-group", MaxInFlight: 2},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups[0] and serial_groups[1] have the same name ('some-serial-group')"))
+			})
+		})
+
+		Context("when a serial group is not used by any job", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{Name: "bogus-serial-group", MaxInFlight: 3},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups.bogus-serial-group is not used by any job"))
+			})
+		})
+
+		Context("when a serial group has a non-positive max_in_flight", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{Name: "some-serial-group", MaxInFlight: 0},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups.some-serial-group has invalid max_in_flight: 0"))
+			})
+		})
+	})
+
 	Describe("invalid resources", func() {
 		Context("when a resource has no name", func() {
 			BeforeEach(func() {
@@ -212,6 +287,22 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource type has an invalid check_every", func() {
+			BeforeEach(func() {
+				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
+					Name:       "bogus-resource-type",
+					Type:       "some-type",
+					CheckEvery: "bogus",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.bogus-resource-type has invalid check_every: time: invalid duration"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
@@ -241,6 +332,59 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("unresolved variables", func() {
+		Context("when the config contains placeholders with no corresponding variable", func() {
+			BeforeEach(func() {
+				config.Resources[0].Source["uri"] = "https://((host))/repo"
+				config.Jobs[0].Plan[0].Params = atc.Params{
+					"some-param": "((param))",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid variables:"))
+				Expect(errorMessages[0]).To(ContainSubstring("undefined variable 'host'"))
+				Expect(errorMessages[0]).To(ContainSubstring("undefined variable 'param'"))
+			})
+
+			Context("when validating with secrets", func() {
+				It("allows the placeholders", func() {
+					_, errorMessages := ValidateConfigWithSecrets(config)
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when the placeholders are for a step's across vars", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Plan[1].Across = []atc.AcrossVarConfig{
+					{Var: "go_version", Values: []interface{}{"1.6", "1.7"}},
+				}
+				config.Jobs[0].Plan[1].Params = atc.Params{
+					"GO_VERSION": "((go_version))",
+				}
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+
+			Context("when the same placeholder is used outside of the step", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Plan[2].Params = atc.Params{
+						"GO_VERSION": "((go_version))",
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("undefined variable 'go_version'"))
+				})
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job atc.JobConfig
 
@@ -276,6 +420,55 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has negative build_log_retention values", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{
+					Builds:                 -1,
+					Days:                   -2,
+					MinimumSucceededBuilds: -3,
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.builds: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.days: -2"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.minimum_succeeded_builds: -3"))
+			})
+		})
+
+		Context("when a job retains more succeeded builds than builds", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{
+					Builds:                 2,
+					MinimumSucceededBuilds: 3,
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has build_log_retention.minimum_succeeded_builds greater than build_log_retention.builds"))
+			})
+		})
+
+		Context("when a job has both build_logs_to_retain and build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogsToRetain = 5
+				job.BuildLogRetention = &atc.BuildLogRetention{Days: 7}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has both build_logs_to_retain and build_log_retention"))
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -429,6 +622,184 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan specifies container limits", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:             "some-resource",
+						ContainerLimits: &atc.ContainerLimits{CPU: 512},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (container_limits)"))
+				})
+			})
+
+			Context("when a load_var plan is valid", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-resource/version",
+						Format:         "trim",
+						Sensitive:      true,
+					}, atc.PlanConfig{
+						Put:    "some-resource",
+						Params: atc.Params{"version": "((.:some-var))"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a load_var plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						LoadVar: "some-var",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify a file"))
+				})
+			})
+
+			Context("when a load_var plan has an unknown format", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-resource/version",
+						Format:         "toml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has an unknown format ('toml')"))
+				})
+			})
+
+			Context("when a load_var plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-resource/version",
+						Privileged:     true,
+						TaskConfig:     &atc.TaskConfig{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has invalid fields specified (privileged, config)"))
+				})
+			})
+
+			Context("when a task plan specifies load_var fields", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "some-resource/task.yml",
+						Format:         "json",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has invalid fields specified (format)"))
+				})
+			})
+
+			Context("when a set_pipeline plan is valid", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-resource/pipeline.yml",
+						Vars:           map[string]interface{}{"some": "var"},
+						VarFiles:       []string{"some-resource/vars.yml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a set_pipeline plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify a file"))
+				})
+			})
+
+			Context("when a set_pipeline plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-resource/pipeline.yml",
+						Resource:       "some-resource",
+						Format:         "json",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (resource, format)"))
+				})
+			})
+
+			Context("when a task plan specifies set_pipeline fields", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "some-resource/task.yml",
+						VarFiles:       []string{"some-resource/vars.yml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has invalid fields specified (var_files)"))
+				})
+			})
+
 			Context("when a put plan has refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -711,6 +1082,64 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid step within an abort or error hook", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Abort: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+						Error: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error for each hook", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.abort.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.error.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
+			Context("when the job has an invalid step within a job-level hook", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+					})
+					job.Success = &atc.PlanConfig{
+						Put:      "custom-name",
+						Resource: "some-missing-resource",
+					}
+					job.Failure = &atc.PlanConfig{
+						Put:      "custom-name",
+						Resource: "some-missing-resource",
+					}
+					job.Abort = &atc.PlanConfig{
+						Put:      "custom-name",
+						Resource: "some-missing-resource",
+					}
+					job.Ensure = &atc.PlanConfig{
+						Put:      "custom-name",
+						Resource: "some-missing-resource",
+					}
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error for each hook", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.success.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.failure.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.abort.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.ensure.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
 			Context("when a plan has an invalid timeout in a step", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -761,6 +1190,131 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid step within an in_parallel", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Put:      "custom-name",
+									Resource: "some-missing-resource",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[0].put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
+			Context("when an in_parallel has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{Get: "some-resource"},
+							},
+							Limit: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit has an invalid value (-1)"))
+				})
+			})
+
+			Context("when a step runs across some vars", func() {
+				var step atc.PlanConfig
+
+				BeforeEach(func() {
+					step = atc.PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go_version", Values: []interface{}{"1.6", "1.7"}},
+							{Var: "platform", Values: []interface{}{"linux", "darwin"}},
+						},
+						MaxInFlight: 2,
+						FailFast:    true,
+					}
+				})
+
+				JustBeforeEach(func() {
+					job.Plan = append(job.Plan, step)
+					config.Jobs = append(config.Jobs, job)
+					configWarnings, errorMessages = ValidateConfig(config)
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+
+				Context("when a var has no name", func() {
+					BeforeEach(func() {
+						step.Across[1].Var = ""
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] has no var"))
+					})
+				})
+
+				Context("when a var has no values", func() {
+					BeforeEach(func() {
+						step.Across[1].Values = nil
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] has no values"))
+					})
+				})
+
+				Context("when two vars have the same name", func() {
+					BeforeEach(func() {
+						step.Across[1].Var = "go_version"
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[0] and jobs.some-other-job.plan[0].task.some-task.across[1] have the same var ('go_version')"))
+					})
+				})
+
+				Context("when max_in_flight is negative", func() {
+					BeforeEach(func() {
+						step.MaxInFlight = -1
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.max_in_flight has an invalid value (-1)"))
+					})
+				})
+
+				Context("when max_in_flight and fail_fast are given without across", func() {
+					BeforeEach(func() {
+						step.Across = nil
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.max_in_flight is only applicable to steps with across"))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.fail_fast is only applicable to steps with across"))
+					})
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -793,6 +1347,39 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:      "some-input",
+						Resource: "some-resource",
+						Passed:   []string{"some-pipeline/some-job", "some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a cross-pipeline job with no pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:      "some-input",
+						Resource: "some-resource",
+						Passed:   []string{"/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-input.passed references an invalid cross-pipeline job ('/some-job')"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, atc.PlanConfig{
@@ -63,6 +63,31 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("LogRetention", func() {
+			defaultRetention := BuildLogRetention{Builds: 5, Days: 3}
+
+			It("returns the build log retention if set", func() {
+				jobConfig := JobConfig{
+					BuildLogsToRetain: 10,
+					BuildLogRetention: &BuildLogRetention{Days: 7, MinimumSucceededBuilds: 1},
+				}
+
+				Expect(jobConfig.LogRetention(defaultRetention)).To(Equal(BuildLogRetention{Days: 7, MinimumSucceededBuilds: 1}))
+			})
+
+			It("returns the default with build_logs_to_retain as the number of builds if set", func() {
+				jobConfig := JobConfig{
+					BuildLogsToRetain: 10,
+				}
+
+				Expect(jobConfig.LogRetention(defaultRetention)).To(Equal(BuildLogRetention{Builds: 10, Days: 3}))
+			})
+
+			It("returns the default if neither is set", func() {
+				Expect(JobConfig{}.LogRetention(defaultRetention)).To(Equal(defaultRetention))
+			})
+		})
+
 		Describe("GetSerialGroups", func() {
 			It("Returns the values if SerialGroups is specified", func() {
 				jobConfig := JobConfig{
@@ -0,0 +1,34 @@
+package atc
+
+import "github.com/concourse/atc/template"
+
+type PipelineConfigVersion struct {
+	Version int    `json:"version"`
+	SavedBy string `json:"saved_by,omitempty"`
+	SavedAt int64  `json:"saved_at"`
+
+	// only present when requesting a single version
+	Config   *Config            `json:"config,omitempty"`
+	Template RawConfig          `json:"template,omitempty"`
+	Vars     template.Variables `json:"vars,omitempty"`
+}
+
+// ConfigDiff summarizes what changed between two versions of a pipeline's
+// config.
+type ConfigDiff struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+
+	Groups        ConfigChanges `json:"groups"`
+	ResourceTypes ConfigChanges `json:"resource_types"`
+	Resources     ConfigChanges `json:"resources"`
+	Jobs          ConfigChanges `json:"jobs"`
+}
+
+// ConfigChanges lists the names of the entries that were added, removed, or
+// changed between two configs.
+type ConfigChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
@@ -0,0 +1,13 @@
+package configsaver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestConfigsaver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config Saver Suite")
+}
@@ -0,0 +1,44 @@
+package configsaver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+)
+
+// SaveFunc persists a config that has passed validation.
+type SaveFunc func() (db.SavedPipeline, bool, error)
+
+// SavePipeline validates the config and, if it is valid, saves it by calling
+// save. It is used both when configuring a pipeline through the API and by
+// set_pipeline steps, so that both report the same errors and warnings.
+//
+// If the config is invalid, the validation errors are returned and save is
+// not called.
+func SavePipeline(
+	logger lager.Logger,
+	validate config.Validator,
+	pipelineConfig atc.Config,
+	save SaveFunc,
+) ([]config.Warning, []string, db.SavedPipeline, bool, error) {
+	warnings, errorMessages := validate(pipelineConfig)
+	if len(errorMessages) > 0 {
+		logger.Info("ignoring-invalid-config")
+		return warnings, errorMessages, db.SavedPipeline{}, false, nil
+	}
+
+	warnings = append(warnings, config.Lint(pipelineConfig)...)
+
+	logger.Info("saving")
+
+	savedPipeline, created, err := save()
+	if err != nil {
+		logger.Error("failed-to-save-config", err)
+		return warnings, nil, db.SavedPipeline{}, false, err
+	}
+
+	logger.Info("saved")
+
+	return warnings, nil, savedPipeline, created, nil
+}
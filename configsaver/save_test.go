@@ -0,0 +1,105 @@
+package configsaver_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/configsaver"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SavePipeline", func() {
+	var (
+		validate       config.Validator
+		pipelineConfig atc.Config
+
+		saveCalls     int
+		savedPipeline db.SavedPipeline
+		saveErr       error
+
+		warnings      []config.Warning
+		errorMessages []string
+		returned      db.SavedPipeline
+		created       bool
+		err           error
+	)
+
+	BeforeEach(func() {
+		validate = func(atc.Config) ([]config.Warning, []string) {
+			return nil, nil
+		}
+
+		pipelineConfig = atc.Config{
+			Jobs: atc.JobConfigs{{Name: "some-job"}},
+		}
+
+		saveCalls = 0
+		savedPipeline = db.SavedPipeline{ID: 1}
+		saveErr = nil
+	})
+
+	JustBeforeEach(func() {
+		warnings, errorMessages, returned, created, err = configsaver.SavePipeline(
+			lagertest.NewTestLogger("test"),
+			validate,
+			pipelineConfig,
+			func() (db.SavedPipeline, bool, error) {
+				saveCalls++
+				return savedPipeline, true, saveErr
+			},
+		)
+	})
+
+	Context("when the config is valid", func() {
+		It("saves it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errorMessages).To(BeEmpty())
+			Expect(saveCalls).To(Equal(1))
+			Expect(returned).To(Equal(savedPipeline))
+			Expect(created).To(BeTrue())
+		})
+
+		Context("when saving fails", func() {
+			BeforeEach(func() {
+				saveErr = errors.New("disaster")
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(saveErr))
+				Expect(created).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the config has validation warnings", func() {
+		BeforeEach(func() {
+			validate = func(atc.Config) ([]config.Warning, []string) {
+				return []config.Warning{{Type: "some-type", Message: "some-warning"}}, nil
+			}
+		})
+
+		It("saves it and returns the warnings", func() {
+			Expect(saveCalls).To(Equal(1))
+			Expect(warnings).To(ContainElement(config.Warning{Type: "some-type", Message: "some-warning"}))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			validate = func(atc.Config) ([]config.Warning, []string) {
+				return nil, []string{"some-error"}
+			}
+		})
+
+		It("returns the errors without saving", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errorMessages).To(Equal([]string{"some-error"}))
+			Expect(saveCalls).To(BeZero())
+		})
+	})
+})
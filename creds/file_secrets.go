@@ -0,0 +1,64 @@
+package creds
+
+import (
+	"io/ioutil"
+	"path"
+
+	"github.com/concourse/atc"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+// FileSecrets looks up secrets in a YAML file mapping paths to values, e.g.:
+//
+//	main/some-pipeline/password: hunter2
+//	main/password: team-wide-password
+//
+// The file is re-read on every lookup so that it can be updated without
+// restarting the ATC.
+type FileSecrets struct {
+	path string
+}
+
+func NewFileSecrets(path string) *FileSecrets {
+	return &FileSecrets{
+		path: path,
+	}
+}
+
+func (secrets *FileSecrets) Get(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+	err := checkSecretName(secretName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	payload, err := ioutil.ReadFile(secrets.path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var raw interface{}
+	err = yaml.Unmarshal(payload, &raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// convert YAML's map[interface{}]interface{} so that values can be
+	// marshalled as JSON
+	var values map[string]interface{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     &values,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = decoder.Decode(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	val, found := values[path.Join(teamName, pipelineName, secretName)]
+	return val, found, nil
+}
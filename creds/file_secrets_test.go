@@ -0,0 +1,109 @@
+package creds_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileSecrets", func() {
+	var (
+		tmpdir  string
+		path    string
+		secrets *creds.FileSecrets
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "file-secrets")
+		Expect(err).NotTo(HaveOccurred())
+
+		path = filepath.Join(tmpdir, "secrets.yml")
+
+		err = ioutil.WriteFile(path, []byte(`
+main/some-pipeline/password: pipeline-password
+main/password: team-password
+main/nested:
+  key: value
+`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		secrets = creds.NewFileSecrets(path)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	It("looks up pipeline-scoped secrets", func() {
+		val, found, err := secrets.Get("main", "some-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("pipeline-password"))
+	})
+
+	It("looks up team-scoped secrets", func() {
+		val, found, err := secrets.Get("main", "", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("team-password"))
+	})
+
+	It("converts nested maps so that they can be marshalled as JSON", func() {
+		val, found, err := secrets.Get("main", "", "nested")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal(map[string]interface{}{"key": "value"}))
+	})
+
+	It("does not find secrets that are not present", func() {
+		_, found, err := secrets.Get("other-team", "", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("picks up changes to the file", func() {
+		err := ioutil.WriteFile(path, []byte(`main/password: rotated`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		val, found, err := secrets.Get("main", "", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("rotated"))
+	})
+
+	Context("when the secret name escapes the team or pipeline", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(path, []byte(`
+main/some-pipeline/password: pipeline-password
+other-team/some-pipeline/password: other-team-password
+`), 0600)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error without looking it up", func() {
+			for _, name := range []string{"../../other-team/some-pipeline/password", "some-pipeline/password", "..", "."} {
+				val, found, err := secrets.Get("main", "", name)
+				Expect(err).To(Equal(creds.InvalidSecretNameError{Name: name}))
+				Expect(found).To(BeFalse())
+				Expect(val).To(BeNil())
+			}
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		BeforeEach(func() {
+			secrets = creds.NewFileSecrets(filepath.Join(tmpdir, "bogus.yml"))
+		})
+
+		It("returns an error", func() {
+			_, _, err := secrets.Get("main", "", "password")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
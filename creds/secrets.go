@@ -0,0 +1,54 @@
+package creds
+
+import "strings"
+
+//go:generate counterfeiter . Secrets
+
+// Secrets is a store of credentials that ((name)) placeholders in pipeline
+// configs are resolved against when a build runs.
+//
+// An empty pipelineName indicates a team-scoped lookup.
+type Secrets interface {
+	Get(teamName string, pipelineName string, secretName string) (interface{}, bool, error)
+}
+
+// UndefinedSecretError is returned when a placeholder cannot be found in the
+// Secrets for either the pipeline or its team.
+type UndefinedSecretError struct {
+	Name string
+}
+
+func (err UndefinedSecretError) Error() string {
+	return "undefined secret: " + err.Name
+}
+
+// UndefinedLocalVarError is returned when a ((.:name)) placeholder refers to
+// a local var that has not been set by the build.
+type UndefinedLocalVarError struct {
+	Name string
+}
+
+func (err UndefinedLocalVarError) Error() string {
+	return "undefined local var: " + err.Name
+}
+
+// InvalidSecretNameError is returned when a secret name could refer to a
+// secret outside of the team or pipeline it is looked up for, e.g.
+// ((../other-team/password)).
+type InvalidSecretNameError struct {
+	Name string
+}
+
+func (err InvalidSecretNameError) Error() string {
+	return "invalid secret name: " + err.Name
+}
+
+// checkSecretName ensures the secret name is a single path segment, so that
+// joining it onto the team and pipeline cannot escape their scope.
+func checkSecretName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return InvalidSecretNameError{Name: name}
+	}
+
+	return nil
+}
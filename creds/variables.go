@@ -0,0 +1,291 @@
+package creds
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
+)
+
+// Variables resolves ((name)) placeholders for a single pipeline, falling back
+// to secrets scoped to its team.
+//
+// Placeholders prefixed with LocalVarPrefix, e.g. ((.:name)), refer to local
+// vars set during the build (see SetLocal) rather than secrets.
+//
+// The zero value (or one constructed with nil Secrets) leaves secret
+// placeholders untouched.
+type Variables struct {
+	secrets      Secrets
+	teamName     string
+	pipelineName string
+
+	local *localVars
+}
+
+// LocalVarPrefix is the prefix of placeholders referring to local vars.
+const LocalVarPrefix = ".:"
+
+type localVars struct {
+	lock      sync.RWMutex
+	values    map[string]interface{}
+	sensitive map[string]bool
+}
+
+func NewVariables(secrets Secrets, teamName string, pipelineName string) Variables {
+	return Variables{
+		secrets:      secrets,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+
+		local: &localVars{
+			values:    map[string]interface{}{},
+			sensitive: map[string]bool{},
+		},
+	}
+}
+
+// SetLocal gives a value to the ((.:name)) placeholder for every copy of
+// these Variables. Sensitive values are redacted from output written through
+// Redact.
+func (v Variables) SetLocal(name string, value interface{}, sensitive bool) {
+	if v.local == nil {
+		return
+	}
+
+	v.local.lock.Lock()
+	defer v.local.lock.Unlock()
+
+	v.local.values[name] = value
+
+	if sensitive {
+		v.local.sensitive[name] = true
+	} else {
+		delete(v.local.sensitive, name)
+	}
+}
+
+// Redact wraps the writer so that the values of any sensitive local vars are
+// replaced with ((redacted)). Only the vars set by the time Redact is called
+// are redacted, and only if each value is written in a single call.
+//
+// If there are no sensitive local vars the writer is returned as-is.
+func (v Variables) Redact(dest io.Writer) io.Writer {
+	if v.local == nil {
+		return dest
+	}
+
+	v.local.lock.RLock()
+
+	var secrets []string
+	for name := range v.local.sensitive {
+		secrets = append(secrets, redactableForms(v.local.values[name])...)
+	}
+
+	v.local.lock.RUnlock()
+
+	if len(secrets) == 0 {
+		return dest
+	}
+
+	return redactingWriter{dest: dest, secrets: secrets}
+}
+
+// EvaluateSource returns a copy of the source with all placeholders resolved.
+func (v Variables) EvaluateSource(source atc.Source) (atc.Source, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	resolved, err := v.resolve(map[string]interface{}(source))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(resolved.(map[string]interface{})), nil
+}
+
+// EvaluateParams returns a copy of the params with all placeholders resolved.
+func (v Variables) EvaluateParams(params atc.Params) (atc.Params, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	resolved, err := v.resolve(map[string]interface{}(params))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(resolved.(map[string]interface{})), nil
+}
+
+// EvaluateResourceTypes returns a copy of the resource types with all
+// placeholders in their sources resolved.
+func (v Variables) EvaluateResourceTypes(resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+	if resourceTypes == nil {
+		return nil, nil
+	}
+
+	evaluated := make(atc.ResourceTypes, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		source, err := v.EvaluateSource(resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+		evaluated[i] = resourceType
+	}
+
+	return evaluated, nil
+}
+
+// EvaluateTaskParams returns a copy of the task params with all placeholders
+// resolved. Values that are not strings are given in their JSON form.
+func (v Variables) EvaluateTaskParams(params map[string]string) (map[string]string, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	evaluated := make(map[string]string, len(params))
+	for name, value := range params {
+		str, err := v.EvaluateString(value)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[name] = str
+	}
+
+	return evaluated, nil
+}
+
+// EvaluateString resolves all placeholders in the string. If the string is a
+// single placeholder whose value is not a string, its JSON form is returned.
+func (v Variables) EvaluateString(str string) (string, error) {
+	resolved, err := v.resolve(str)
+	if err != nil {
+		return "", err
+	}
+
+	if evaluated, ok := resolved.(string); ok {
+		return evaluated, nil
+	}
+
+	payload, err := json.Marshal(resolved)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+func (v Variables) resolve(obj interface{}) (interface{}, error) {
+	if v.secrets == nil && v.local == nil {
+		return obj, nil
+	}
+
+	return template.Resolve(obj, v.lookup)
+}
+
+func (v Variables) lookup(name string) (interface{}, bool, error) {
+	if strings.HasPrefix(name, LocalVarPrefix) {
+		return v.lookupLocal(strings.TrimPrefix(name, LocalVarPrefix))
+	}
+
+	if v.secrets == nil {
+		return nil, false, nil
+	}
+
+	if v.pipelineName != "" {
+		val, found, err := v.secrets.Get(v.teamName, v.pipelineName, name)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if found {
+			return val, true, nil
+		}
+	}
+
+	val, found, err := v.secrets.Get(v.teamName, "", name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, UndefinedSecretError{Name: name}
+	}
+
+	return val, true, nil
+}
+
+func (v Variables) lookupLocal(name string) (interface{}, bool, error) {
+	if v.local == nil {
+		return nil, false, UndefinedLocalVarError{Name: name}
+	}
+
+	v.local.lock.RLock()
+	defer v.local.lock.RUnlock()
+
+	val, found := v.local.values[name]
+	if !found {
+		return nil, false, UndefinedLocalVarError{Name: name}
+	}
+
+	return val, true, nil
+}
+
+type redactingWriter struct {
+	dest    io.Writer
+	secrets []string
+}
+
+func (writer redactingWriter) Write(p []byte) (int, error) {
+	redacted := string(p)
+	for _, secret := range writer.secrets {
+		redacted = strings.Replace(redacted, secret, "((redacted))", -1)
+	}
+
+	_, err := writer.dest.Write([]byte(redacted))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// redactableForms returns the strings within the value that should not appear
+// in output.
+func redactableForms(val interface{}) []string {
+	switch v := val.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+
+		return []string{v}
+
+	case map[string]interface{}:
+		var forms []string
+		for _, sub := range v {
+			forms = append(forms, redactableForms(sub)...)
+		}
+
+		return forms
+
+	case []interface{}:
+		var forms []string
+		for _, sub := range v {
+			forms = append(forms, redactableForms(sub)...)
+		}
+
+		return forms
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,302 @@
+package creds_test
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	var (
+		fakeSecrets *credsfakes.FakeSecrets
+
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeSecrets = new(credsfakes.FakeSecrets)
+		fakeSecrets.GetStub = func(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+			switch {
+			case pipelineName == "some-pipeline" && secretName == "pipeline-secret":
+				return "pipeline-value", true, nil
+			case pipelineName == "" && secretName == "pipeline-secret":
+				return "shadowed-team-value", true, nil
+			case pipelineName == "" && secretName == "team-secret":
+				return "team-value", true, nil
+			case pipelineName == "" && secretName == "nested-secret":
+				return map[string]interface{}{"key": "value"}, true, nil
+			default:
+				return nil, false, nil
+			}
+		}
+
+		variables = creds.NewVariables(fakeSecrets, "some-team", "some-pipeline")
+	})
+
+	Describe("EvaluateSource", func() {
+		It("resolves pipeline-scoped secrets before team-scoped ones", func() {
+			source, err := variables.EvaluateSource(atc.Source{
+				"a": "((pipeline-secret))",
+				"b": "((team-secret))",
+				"c": []interface{}{"prefix-((team-secret))"},
+				"d": "((nested-secret))",
+				"e": "plain",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source).To(Equal(atc.Source{
+				"a": "pipeline-value",
+				"b": "team-value",
+				"c": []interface{}{"prefix-team-value"},
+				"d": map[string]interface{}{"key": "value"},
+				"e": "plain",
+			}))
+		})
+
+		It("looks up the secret for the pipeline, then its team", func() {
+			_, err := variables.EvaluateSource(atc.Source{"a": "((team-secret))"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSecrets.GetCallCount()).To(Equal(2))
+
+			teamName, pipelineName, secretName := fakeSecrets.GetArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(secretName).To(Equal("team-secret"))
+
+			teamName, pipelineName, secretName = fakeSecrets.GetArgsForCall(1)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal(""))
+			Expect(secretName).To(Equal("team-secret"))
+		})
+
+		It("does not modify the original source", func() {
+			original := atc.Source{"a": "((team-secret))"}
+			_, err := variables.EvaluateSource(original)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(original).To(Equal(atc.Source{"a": "((team-secret))"}))
+		})
+
+		It("returns an error when a secret is undefined", func() {
+			_, err := variables.EvaluateSource(atc.Source{"a": "((bogus))"})
+			Expect(err).To(Equal(creds.UndefinedSecretError{Name: "bogus"}))
+		})
+
+		Context("when looking up a secret fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeSecrets.GetStub = nil
+				fakeSecrets.GetReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := variables.EvaluateSource(atc.Source{"a": "((team-secret))"})
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
+		Context("when the build does not belong to a pipeline", func() {
+			BeforeEach(func() {
+				variables = creds.NewVariables(fakeSecrets, "some-team", "")
+			})
+
+			It("only looks up team-scoped secrets", func() {
+				source, err := variables.EvaluateSource(atc.Source{"a": "((pipeline-secret))"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source).To(Equal(atc.Source{"a": "shadowed-team-value"}))
+				Expect(fakeSecrets.GetCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when no secrets are configured", func() {
+			BeforeEach(func() {
+				variables = creds.NewVariables(nil, "some-team", "some-pipeline")
+			})
+
+			It("leaves placeholders untouched", func() {
+				source, err := variables.EvaluateSource(atc.Source{"a": "((team-secret))"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source).To(Equal(atc.Source{"a": "((team-secret))"}))
+			})
+		})
+	})
+
+	Describe("EvaluateParams", func() {
+		It("resolves secrets", func() {
+			params, err := variables.EvaluateParams(atc.Params{"a": "((team-secret))"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(atc.Params{"a": "team-value"}))
+		})
+
+		It("returns nil for nil params", func() {
+			params, err := variables.EvaluateParams(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(BeNil())
+		})
+	})
+
+	Describe("EvaluateResourceTypes", func() {
+		It("resolves secrets in the sources of the resource types", func() {
+			resourceTypes := atc.ResourceTypes{
+				{
+					Name:   "some-type",
+					Type:   "docker-image",
+					Source: atc.Source{"password": "((team-secret))"},
+				},
+				{
+					Name: "some-other-type",
+					Type: "docker-image",
+				},
+			}
+
+			evaluated, err := variables.EvaluateResourceTypes(resourceTypes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evaluated).To(Equal(atc.ResourceTypes{
+				{
+					Name:   "some-type",
+					Type:   "docker-image",
+					Source: atc.Source{"password": "team-value"},
+				},
+				{
+					Name: "some-other-type",
+					Type: "docker-image",
+				},
+			}))
+
+			Expect(resourceTypes[0].Source).To(Equal(atc.Source{"password": "((team-secret))"}))
+		})
+
+		It("returns an error when a secret is undefined", func() {
+			_, err := variables.EvaluateResourceTypes(atc.ResourceTypes{
+				{Name: "some-type", Source: atc.Source{"password": "((bogus))"}},
+			})
+			Expect(err).To(Equal(creds.UndefinedSecretError{Name: "bogus"}))
+		})
+	})
+
+	Describe("EvaluateString", func() {
+		It("resolves secrets", func() {
+			str, err := variables.EvaluateString("token-((team-secret))")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(str).To(Equal("token-team-value"))
+		})
+
+		It("returns an error when a secret is undefined", func() {
+			_, err := variables.EvaluateString("((bogus))")
+			Expect(err).To(Equal(creds.UndefinedSecretError{Name: "bogus"}))
+		})
+	})
+
+	Describe("EvaluateTaskParams", func() {
+		It("resolves secrets, marshalling non-string values as JSON", func() {
+			params, err := variables.EvaluateTaskParams(map[string]string{
+				"A": "((team-secret))",
+				"B": "((nested-secret))",
+				"C": "plain",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(map[string]string{
+				"A": "team-value",
+				"B": `{"key":"value"}`,
+				"C": "plain",
+			}))
+		})
+
+		It("returns an error when a secret is undefined", func() {
+			_, err := variables.EvaluateTaskParams(map[string]string{"A": "((bogus))"})
+			Expect(err).To(Equal(creds.UndefinedSecretError{Name: "bogus"}))
+		})
+	})
+
+	Describe("local vars", func() {
+		It("resolves ((.:name)) placeholders once the var is set", func() {
+			copied := variables
+			copied.SetLocal("some-var", "some-value", false)
+
+			source, err := variables.EvaluateSource(atc.Source{
+				"a": "((.:some-var))",
+				"b": "prefix-((.:some-var))",
+				"c": "((team-secret))",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source).To(Equal(atc.Source{
+				"a": "some-value",
+				"b": "prefix-some-value",
+				"c": "team-value",
+			}))
+		})
+
+		It("does not look up local vars in the secrets", func() {
+			variables.SetLocal("some-var", "some-value", false)
+
+			_, err := variables.EvaluateSource(atc.Source{"a": "((.:some-var))"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSecrets.GetCallCount()).To(BeZero())
+		})
+
+		It("returns an error when a local var is undefined", func() {
+			_, err := variables.EvaluateSource(atc.Source{"a": "((.:bogus))"})
+			Expect(err).To(Equal(creds.UndefinedLocalVarError{Name: "bogus"}))
+		})
+
+		Context("when no secrets are configured", func() {
+			BeforeEach(func() {
+				variables = creds.NewVariables(nil, "some-team", "some-pipeline")
+			})
+
+			It("still resolves local vars, leaving other placeholders untouched", func() {
+				variables.SetLocal("some-var", map[string]interface{}{"key": "value"}, false)
+
+				source, err := variables.EvaluateSource(atc.Source{
+					"a": "((.:some-var))",
+					"b": "((team-secret))",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source).To(Equal(atc.Source{
+					"a": map[string]interface{}{"key": "value"},
+					"b": "((team-secret))",
+				}))
+			})
+		})
+
+		Describe("Redact", func() {
+			var buf *bytes.Buffer
+
+			BeforeEach(func() {
+				buf = new(bytes.Buffer)
+			})
+
+			It("redacts sensitive values", func() {
+				variables.SetLocal("sensitive-var", "hunter2", true)
+				variables.SetLocal("nested-var", map[string]interface{}{"key": "s3cret"}, true)
+				variables.SetLocal("plain-var", "visible", false)
+
+				n, err := variables.Redact(buf).Write([]byte("hunter2 visible s3cret\n"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(len("hunter2 visible s3cret\n")))
+				Expect(buf.String()).To(Equal("((redacted)) visible ((redacted))\n"))
+			})
+
+			It("returns the writer as-is when no vars are sensitive", func() {
+				variables.SetLocal("plain-var", "visible", false)
+
+				Expect(variables.Redact(buf)).To(Equal(buf))
+			})
+
+			It("stops redacting a var once it is set as not sensitive", func() {
+				variables.SetLocal("some-var", "hunter2", true)
+				variables.SetLocal("some-var", "hunter2", false)
+
+				_, err := variables.Redact(buf).Write([]byte("hunter2"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buf.String()).To(Equal("hunter2"))
+			})
+		})
+	})
+})
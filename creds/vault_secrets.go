@@ -0,0 +1,91 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// VaultSecrets looks up secrets in a Vault-compatible key/value store, under
+// {prefix}/{team}/{pipeline}/{name} for pipeline-scoped secrets and
+// {prefix}/{team}/{name} for team-scoped secrets.
+//
+// A secret whose only key is "value" resolves to that value; otherwise the
+// secret resolves to the full map of its keys.
+type VaultSecrets struct {
+	url        string
+	token      string
+	pathPrefix string
+
+	httpClient *http.Client
+}
+
+func NewVaultSecrets(url string, token string, pathPrefix string) *VaultSecrets {
+	return &VaultSecrets{
+		url:        strings.TrimRight(url, "/"),
+		token:      token,
+		pathPrefix: pathPrefix,
+
+		httpClient: &http.Client{},
+	}
+}
+
+type vaultSecretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// UnexpectedVaultResponseError is returned when the store responds with
+// anything other than the secret or a 404.
+type UnexpectedVaultResponseError struct {
+	StatusCode int
+}
+
+func (err UnexpectedVaultResponseError) Error() string {
+	return fmt.Sprintf("unexpected response from vault: %d %s", err.StatusCode, http.StatusText(err.StatusCode))
+}
+
+func (secrets *VaultSecrets) Get(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+	err := checkSecretName(secretName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	secretPath := path.Join("/v1", secrets.pathPrefix, teamName, pipelineName, secretName)
+
+	request, err := http.NewRequest("GET", secrets.url+(&url.URL{Path: secretPath}).EscapedPath(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	request.Header.Set("X-Vault-Token", secrets.token)
+
+	response, err := secrets.httpClient.Do(request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, false, UnexpectedVaultResponseError{StatusCode: response.StatusCode}
+	}
+
+	var secret vaultSecretResponse
+	err = json.NewDecoder(response.Body).Decode(&secret)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if val, found := secret.Data["value"]; found && len(secret.Data) == 1 {
+		return val, true, nil
+	}
+
+	return secret.Data, true, nil
+}
@@ -0,0 +1,112 @@
+package creds_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/creds"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VaultSecrets", func() {
+	var (
+		vaultServer *ghttp.Server
+		secrets     *creds.VaultSecrets
+	)
+
+	BeforeEach(func() {
+		vaultServer = ghttp.NewServer()
+		secrets = creds.NewVaultSecrets(vaultServer.URL(), "some-token", "concourse")
+	})
+
+	AfterEach(func() {
+		vaultServer.Close()
+	})
+
+	Context("when the secret has a single value", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/some-pipeline/password"),
+					ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"value":"hunter2"}}`),
+				),
+			)
+		})
+
+		It("returns the value", func() {
+			val, found, err := secrets.Get("main", "some-pipeline", "password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("hunter2"))
+		})
+	})
+
+	Context("when the secret has multiple keys", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/creds"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"username":"admin","password":"hunter2"}}`),
+				),
+			)
+		})
+
+		It("returns all of them", func() {
+			val, found, err := secrets.Get("main", "", "creds")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{
+				"username": "admin",
+				"password": "hunter2",
+			}))
+		})
+	})
+
+	Context("when the secret does not exist", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/password"),
+					ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				),
+			)
+		})
+
+		It("is not found", func() {
+			_, found, err := secrets.Get("main", "", "password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the secret name escapes the team or pipeline", func() {
+		It("returns an error without requesting it", func() {
+			for _, name := range []string{"../../other-team/some-pipeline/password", "some-pipeline/password", "..", "."} {
+				_, found, err := secrets.Get("main", "some-pipeline", name)
+				Expect(err).To(Equal(creds.InvalidSecretNameError{Name: name}))
+				Expect(found).To(BeFalse())
+			}
+
+			Expect(vaultServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Context("when the server responds with an error", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/password"),
+					ghttp.RespondWith(http.StatusForbidden, `{"errors":["permission denied"]}`),
+				),
+			)
+		})
+
+		It("returns an error", func() {
+			_, _, err := secrets.Get("main", "", "password")
+			Expect(err).To(Equal(creds.UnexpectedVaultResponseError{StatusCode: http.StatusForbidden}))
+		})
+	})
+})
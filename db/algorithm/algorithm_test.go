@@ -667,6 +667,58 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("resolves to the version the resource is pinned to", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			PinnedVersions: []DBRow{
+				{Resource: "resource-x", Version: "rxv2"},
+			},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x"},
+			{Name: "resource-x-every", Resource: "resource-x", Version: Version{Every: true}},
+			{Name: "resource-x-pinned", Resource: "resource-x", Version: Version{Pinned: "rxv3"}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x":        "rxv2",
+				"resource-x-every":  "rxv2",
+				"resource-x-pinned": "rxv2",
+			},
+		},
+	}),
+
+	Entry("does not resolve a version when the version the resource is pinned to has not passed the constraint", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+			BuildOutputs: []DBRow{
+				{Job: "some-job", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+			PinnedVersions: []DBRow{
+				{Resource: "resource-x", Version: "rxv2"},
+			},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Passed: []string{"some-job"}},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
+
 	Entry("check orders take precedence over version ID", Example{
 		DB: DB{
 			Resources: []DBRow{
@@ -687,6 +739,35 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("can use jobs in other pipelines together with jobs in the same pipeline", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				// outputs of another pipeline's job, loaded as versions of the
+				// shared resource in this pipeline
+				{Job: "upstream/integration", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "upstream/integration", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 4, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"upstream/integration", "simple-a"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
 	Entry("bosh memory leak regression test", Example{
 		LoadDB: "testdata/bosh-versions.json",
 
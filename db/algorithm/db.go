@@ -8,7 +8,13 @@ type VersionsDB struct {
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
+	PinnedVersions   map[int]int
 	CachedAt         time.Time
+
+	// disabled versions and the succeeded builds which output them are never
+	// candidates, and are only kept to explain which versions were excluded
+	DisabledVersions     []ResourceVersion
+	DisabledBuildOutputs []BuildOutput
 }
 
 type ResourceVersion struct {
@@ -30,6 +36,13 @@ type BuildInput struct {
 	InputName string
 }
 
+// PinnedVersionForResource returns the version ID the resource has been
+// pinned to pipeline-wide, if any.
+func (db VersionsDB) PinnedVersionForResource(resourceID int) (int, bool) {
+	versionID, found := db.PinnedVersions[resourceID]
+	return versionID, found
+}
+
 func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputName string) bool {
 	for _, buildInput := range db.BuildInputs {
 		if buildInput.VersionID == versionID &&
@@ -0,0 +1,191 @@
+package algorithm
+
+import "sort"
+
+// Explanation is a trace of how the inputs of a job were resolved, used for
+// figuring out why a job is not running.
+type Explanation struct {
+	Inputs []InputExplanation
+
+	// passed constraints shared by inputs which have no build in common, only
+	// determined if every input has candidate versions
+	Conflicts []ConflictExplanation
+
+	Resolved bool
+}
+
+type InputExplanation struct {
+	Name string
+
+	// number of enabled versions of the resource
+	Versions int
+
+	// number of versions which satisfy the passed constraints, or the number
+	// of versions if there are none
+	Candidates int
+
+	Passed []PassedExplanation
+
+	PinnedVersionID int
+
+	// whether the pinned version is not one of the candidates, e.g. because it
+	// has not passed the jobs or has been disabled
+	PinnedVersionExcluded bool
+
+	// disabled versions which would otherwise have been candidates
+	DisabledVersionIDs []int
+}
+
+type PassedExplanation struct {
+	JobID   int
+	JobName string
+
+	// number of versions of the resource output by the job
+	Versions int
+
+	// versions which were output by another of the input's passed jobs but not
+	// by this one
+	EliminatedVersionIDs []int
+}
+
+type ConflictExplanation struct {
+	JobID   int
+	JobName string
+
+	Inputs []string
+}
+
+func explainInput(db *VersionsDB, inputConfig InputConfig, versionCandidates VersionCandidates, pinnedVersionID int) InputExplanation {
+	explanation := InputExplanation{
+		Name:            inputConfig.Name,
+		Versions:        len(db.AllVersionsForResource(inputConfig.ResourceID)),
+		Candidates:      len(versionCandidates.VersionIDs()),
+		PinnedVersionID: pinnedVersionID,
+	}
+
+	if pinnedVersionID != 0 {
+		explanation.PinnedVersionExcluded = len(versionCandidates.ForVersion(pinnedVersionID)) == 0
+	}
+
+	passedVersions := map[int]map[int]bool{}
+	allPassedVersions := map[int]bool{}
+
+	for jobID := range inputConfig.Passed {
+		versions := map[int]bool{}
+		for _, output := range db.BuildOutputs {
+			if output.ResourceID == inputConfig.ResourceID && output.JobID == jobID {
+				versions[output.VersionID] = true
+				allPassedVersions[output.VersionID] = true
+			}
+		}
+
+		passedVersions[jobID] = versions
+	}
+
+	for _, jobID := range inputConfig.Passed.sorted() {
+		eliminated := []int{}
+		for versionID := range allPassedVersions {
+			if !passedVersions[jobID][versionID] {
+				eliminated = append(eliminated, versionID)
+			}
+		}
+
+		sort.Ints(eliminated)
+
+		explanation.Passed = append(explanation.Passed, PassedExplanation{
+			JobID:                jobID,
+			JobName:              db.jobName(jobID),
+			Versions:             len(passedVersions[jobID]),
+			EliminatedVersionIDs: eliminated,
+		})
+	}
+
+	explanation.DisabledVersionIDs = excludedDisabledVersionIDs(db, inputConfig)
+
+	return explanation
+}
+
+// excludedDisabledVersionIDs finds the disabled versions of the input's
+// resource which have passed all of its jobs, i.e. those which would have been
+// candidates had they not been disabled.
+func excludedDisabledVersionIDs(db *VersionsDB, inputConfig InputConfig) []int {
+	passedJobs := map[int]map[int]bool{}
+	for _, output := range db.DisabledBuildOutputs {
+		if output.ResourceID != inputConfig.ResourceID || !inputConfig.Passed.Contains(output.JobID) {
+			continue
+		}
+
+		if passedJobs[output.VersionID] == nil {
+			passedJobs[output.VersionID] = map[int]bool{}
+		}
+
+		passedJobs[output.VersionID][output.JobID] = true
+	}
+
+	excluded := []int{}
+	for _, version := range db.DisabledVersions {
+		if version.ResourceID != inputConfig.ResourceID {
+			continue
+		}
+
+		if len(passedJobs[version.VersionID]) < len(inputConfig.Passed) {
+			continue
+		}
+
+		excluded = append(excluded, version.VersionID)
+	}
+
+	sort.Ints(excluded)
+
+	return excluded
+}
+
+// conflicts finds the jobs which more than one input must have passed but for
+// which the inputs' candidates have no build in common.
+func (candidates InputCandidates) conflicts(db *VersionsDB, jobs JobSet) []ConflictExplanation {
+	conflicts := []ConflictExplanation{}
+
+	for _, jobID := range jobs.sorted() {
+		inputs := []string{}
+		for _, inputCandidates := range candidates {
+			if inputCandidates.Passed.Contains(jobID) {
+				inputs = append(inputs, inputCandidates.Input)
+			}
+		}
+
+		if len(inputs) < 2 || len(candidates.commonBuildIDs(jobID)) > 0 {
+			continue
+		}
+
+		sort.Strings(inputs)
+
+		conflicts = append(conflicts, ConflictExplanation{
+			JobID:   jobID,
+			JobName: db.jobName(jobID),
+			Inputs:  inputs,
+		})
+	}
+
+	return conflicts
+}
+
+func (db VersionsDB) jobName(jobID int) string {
+	for name, id := range db.JobIDs {
+		if id == jobID {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func (set JobSet) sorted() []int {
+	ids := []int{}
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
@@ -0,0 +1,218 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explaining input resolution", func() {
+	const (
+		currentJobID = 1
+		jobAID       = 2
+		jobBID       = 3
+
+		resourceXID = 1
+		resourceYID = 2
+	)
+
+	var (
+		db           *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+
+		resolved    bool
+		explanation algorithm.Explanation
+	)
+
+	output := func(versionID int, resourceID int, jobID int, buildID int) algorithm.BuildOutput {
+		return algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{
+				VersionID:  versionID,
+				ResourceID: resourceID,
+				CheckOrder: versionID,
+			},
+			JobID:   jobID,
+			BuildID: buildID,
+		}
+	}
+
+	BeforeEach(func() {
+		db = &algorithm.VersionsDB{
+			JobIDs: map[string]int{
+				"current": currentJobID,
+				"job-a":   jobAID,
+				"job-b":   jobBID,
+			},
+			ResourceIDs: map[string]int{
+				"resource-x": resourceXID,
+				"resource-y": resourceYID,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		_, resolved, explanation = inputConfigs.Explain(db)
+	})
+
+	Context("when an input has no versions", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "x", ResourceID: resourceXID, JobID: currentJobID},
+				{Name: "y", ResourceID: resourceYID, JobID: currentJobID},
+			}
+
+			db.ResourceVersions = []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: resourceYID, CheckOrder: 1},
+			}
+		})
+
+		It("does not resolve", func() {
+			Expect(resolved).To(BeFalse())
+			Expect(explanation.Resolved).To(BeFalse())
+		})
+
+		It("still explains every input", func() {
+			Expect(explanation.Inputs).To(Equal([]algorithm.InputExplanation{
+				{Name: "x", Versions: 0, Candidates: 0, DisabledVersionIDs: []int{}},
+				{Name: "y", Versions: 1, Candidates: 1, DisabledVersionIDs: []int{}},
+			}))
+		})
+	})
+
+	Context("when passed jobs eliminate versions", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "x",
+					ResourceID: resourceXID,
+					JobID:      currentJobID,
+					Passed:     algorithm.JobSet{jobAID: {}, jobBID: {}},
+				},
+			}
+
+			db.ResourceVersions = []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: resourceXID, CheckOrder: 1},
+				{VersionID: 2, ResourceID: resourceXID, CheckOrder: 2},
+			}
+
+			db.BuildOutputs = []algorithm.BuildOutput{
+				output(1, resourceXID, jobAID, 1),
+				output(1, resourceXID, jobBID, 2),
+				output(2, resourceXID, jobAID, 3),
+			}
+		})
+
+		It("resolves", func() {
+			Expect(resolved).To(BeTrue())
+			Expect(explanation.Resolved).To(BeTrue())
+		})
+
+		It("describes which versions each job eliminated", func() {
+			Expect(explanation.Inputs).To(Equal([]algorithm.InputExplanation{
+				{
+					Name:       "x",
+					Versions:   2,
+					Candidates: 1,
+					Passed: []algorithm.PassedExplanation{
+						{JobID: jobAID, JobName: "job-a", Versions: 2, EliminatedVersionIDs: []int{}},
+						{JobID: jobBID, JobName: "job-b", Versions: 1, EliminatedVersionIDs: []int{2}},
+					},
+					DisabledVersionIDs: []int{},
+				},
+			}))
+		})
+
+		Context("when versions have been disabled", func() {
+			BeforeEach(func() {
+				db.DisabledVersions = []algorithm.ResourceVersion{
+					{VersionID: 3, ResourceID: resourceXID, CheckOrder: 3},
+					{VersionID: 4, ResourceID: resourceXID, CheckOrder: 4},
+					{VersionID: 5, ResourceID: resourceYID, CheckOrder: 1},
+				}
+
+				db.DisabledBuildOutputs = []algorithm.BuildOutput{
+					output(3, resourceXID, jobAID, 4),
+					output(3, resourceXID, jobBID, 5),
+					output(4, resourceXID, jobAID, 6),
+					output(5, resourceYID, jobAID, 7),
+					output(5, resourceYID, jobBID, 8),
+				}
+			})
+
+			It("describes the disabled versions which passed all of the jobs as excluded", func() {
+				Expect(explanation.Inputs[0].DisabledVersionIDs).To(Equal([]int{3}))
+			})
+
+			Context("when the input has no passed constraints", func() {
+				BeforeEach(func() {
+					inputConfigs[0].Passed = nil
+				})
+
+				It("describes all of the resource's disabled versions as excluded", func() {
+					Expect(explanation.Inputs[0].DisabledVersionIDs).To(Equal([]int{3, 4}))
+				})
+			})
+		})
+
+		Context("when the input is pinned to an eliminated version", func() {
+			BeforeEach(func() {
+				inputConfigs[0].PinnedVersionID = 2
+			})
+
+			It("does not resolve", func() {
+				Expect(resolved).To(BeFalse())
+			})
+
+			It("describes the pinned version as excluded", func() {
+				Expect(explanation.Inputs[0].PinnedVersionID).To(Equal(2))
+				Expect(explanation.Inputs[0].PinnedVersionExcluded).To(BeTrue())
+			})
+		})
+
+		Context("when the resource is pinned to an available version", func() {
+			BeforeEach(func() {
+				db.PinnedVersions = map[int]int{resourceXID: 1}
+			})
+
+			It("describes the pinned version as available", func() {
+				Expect(resolved).To(BeTrue())
+				Expect(explanation.Inputs[0].PinnedVersionID).To(Equal(1))
+				Expect(explanation.Inputs[0].PinnedVersionExcluded).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when inputs which share a passed job have no build in common", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "x",
+					ResourceID: resourceXID,
+					JobID:      currentJobID,
+					Passed:     algorithm.JobSet{jobAID: {}},
+				},
+				{
+					Name:       "y",
+					ResourceID: resourceYID,
+					JobID:      currentJobID,
+					Passed:     algorithm.JobSet{jobAID: {}},
+				},
+			}
+
+			db.BuildOutputs = []algorithm.BuildOutput{
+				output(1, resourceXID, jobAID, 1),
+				output(2, resourceYID, jobAID, 2),
+			}
+		})
+
+		It("does not resolve", func() {
+			Expect(resolved).To(BeFalse())
+		})
+
+		It("describes the conflicting constraint", func() {
+			Expect(explanation.Conflicts).To(Equal([]algorithm.ConflictExplanation{
+				{JobID: jobAID, JobName: "job-a", Inputs: []string{"x", "y"}},
+			}))
+		})
+	})
+})
@@ -50,15 +50,15 @@ func (candidates InputCandidates) reduce(jobs JobSet, lastSatisfiedMapping map[s
 		versionIDs := inputVersionCandidates.VersionIDs()
 
 		switch {
-		case len(versionIDs) == 1:
-			// already reduced
-			continue
 		case inputVersionCandidates.PinnedVersionID != 0:
 			limitedToVersion := inputVersionCandidates.ForVersion(inputVersionCandidates.PinnedVersionID)
 
 			inputCandidates := newInputCandidates[i]
 			inputCandidates.VersionCandidates = limitedToVersion
 			newInputCandidates[i] = inputCandidates
+		case len(versionIDs) == 1:
+			// already reduced
+			continue
 		default:
 			usingEveryVersion := inputVersionCandidates.UsingEveryVersion()
 
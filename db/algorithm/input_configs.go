@@ -20,18 +20,34 @@ type InputConfig struct {
 }
 
 func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
+	return configs.resolve(db, nil)
+}
+
+// Explain resolves the inputs the same way as Resolve, and also returns a
+// trace of the candidates considered for each input and the constraints which
+// could not be satisfied.
+func (configs InputConfigs) Explain(db *VersionsDB) (InputMapping, bool, Explanation) {
+	explanation := &Explanation{}
+
+	mapping, ok := configs.resolve(db, explanation)
+	explanation.Resolved = ok
+
+	return mapping, ok, *explanation
+}
+
+func (configs InputConfigs) resolve(db *VersionsDB, explanation *Explanation) (InputMapping, bool) {
 	jobs := JobSet{}
 	inputCandidates := InputCandidates{}
 
+	// when explaining, carry on with the other inputs so that they're all
+	// described
+	missingCandidates := false
+
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
 		if len(inputConfig.Passed) == 0 {
 			versionCandidates = db.AllVersionsForResource(inputConfig.ResourceID)
-
-			if len(versionCandidates) == 0 {
-				return nil, false
-			}
 		} else {
 			jobs = jobs.Union(inputConfig.Passed)
 
@@ -39,10 +55,23 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 				inputConfig.ResourceID,
 				inputConfig.Passed,
 			)
+		}
 
-			if len(versionCandidates) == 0 {
+		if len(versionCandidates) == 0 {
+			if explanation == nil {
 				return nil, false
 			}
+
+			missingCandidates = true
+		}
+
+		pinnedVersionID := inputConfig.PinnedVersionID
+		if resourcePinnedVersionID, found := db.PinnedVersionForResource(inputConfig.ResourceID); found {
+			pinnedVersionID = resourcePinnedVersionID
+		}
+
+		if explanation != nil {
+			explanation.Inputs = append(explanation.Inputs, explainInput(db, inputConfig, versionCandidates, pinnedVersionID))
 		}
 
 		existingBuildResolver := &ExistingBuildResolver{
@@ -55,16 +84,24 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
 			UseEveryVersion:       inputConfig.UseEveryVersion,
-			PinnedVersionID:       inputConfig.PinnedVersionID,
+			PinnedVersionID:       pinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
 	}
 
+	if missingCandidates {
+		return nil, false
+	}
+
 	sort.Sort(byTotalVersions(inputCandidates))
 
 	basicMapping, ok := inputCandidates.Reduce(jobs)
 	if !ok {
+		if explanation != nil {
+			explanation.Conflicts = inputCandidates.conflicts(db, jobs)
+		}
+
 		return nil, false
 	}
 
@@ -10,9 +10,10 @@ import (
 )
 
 type DB struct {
-	BuildInputs  []DBRow
-	BuildOutputs []DBRow
-	Resources    []DBRow
+	BuildInputs    []DBRow
+	BuildOutputs   []DBRow
+	Resources      []DBRow
+	PinnedVersions []DBRow
 }
 
 type DBRow struct {
@@ -134,6 +135,13 @@ func (example Example) Run() {
 				JobID:           jobIDs.ID(row.Job),
 			})
 		}
+		for _, row := range example.DB.PinnedVersions {
+			if db.PinnedVersions == nil {
+				db.PinnedVersions = map[int]int{}
+			}
+
+			db.PinnedVersions[resourceIDs.ID(row.Resource)] = versionIDs.ID(row.Version)
+		}
 	}
 
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
@@ -23,8 +23,8 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time, manually_pinned"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.manually_pinned, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -43,6 +43,7 @@ type Build interface {
 	ReapTime() time.Time
 	IsOneOff() bool
 	IsScheduled() bool
+	IsManuallyPinned() bool
 	IsRunning() bool
 
 	Reload() (bool, error)
@@ -82,6 +83,8 @@ type build struct {
 	status    Status
 	scheduled bool
 
+	manuallyPinned bool
+
 	jobName      string
 	pipelineName string
 	pipelineID   int
@@ -155,6 +158,10 @@ func (b *build) IsScheduled() bool {
 	return b.scheduled
 }
 
+func (b *build) IsManuallyPinned() bool {
+	return b.manuallyPinned
+}
+
 func (b *build) IsRunning() bool {
 	switch b.status {
 	case StatusPending, StatusStarted:
@@ -186,6 +193,7 @@ func (b *build) Reload() (bool, error) {
 	b.name = newBuild.Name()
 	b.status = newBuild.Status()
 	b.scheduled = newBuild.IsScheduled()
+	b.manuallyPinned = newBuild.IsManuallyPinned()
 	b.engine = newBuild.Engine()
 	b.engineMetadata = newBuild.EngineMetadata()
 	b.startTime = newBuild.StartTime()
@@ -571,11 +579,12 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		pausedJob              bool
 		maxInFlightReached     bool
 		pipelineID             int
+		jobID                  int
 		resourceCheckIsRunning bool
 		jobName                string
 	)
 	err := b.conn.QueryRow(`
-			SELECT p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name,
+			SELECT p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.id, j.name,
 				j.resource_check_finished_at > now() AND j.resource_check_waiver_end < $1
 			FROM builds b
 			JOIN jobs j
@@ -583,7 +592,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			JOIN pipelines p
 				ON j.pipeline_id = p.id
 			WHERE b.id = $1
-		`, b.id).Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName, &resourceCheckIsRunning)
+		`, b.id).Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobID, &jobName, &resourceCheckIsRunning)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -606,21 +615,30 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	queuePosition, err := b.getQueuePosition(pipelineID, jobID)
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
 	if resourceCheckIsRunning {
 		return BuildPreparation{
 			BuildID:             b.id,
 			PausedPipeline:      pausedPipelineStatus,
 			PausedJob:           pausedJobStatus,
 			MaxRunningBuilds:    maxInFlightReachedStatus,
+			QueuePosition:       queuePosition,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusUnknown,
 			MissingInputReasons: MissingInputReasons{},
 		}, true, nil
 	}
 
-	tdbf := NewTeamDBFactory(b.conn, b.bus)
-	tdb := tdbf.GetTeamDB(b.teamName)
-	savedPipeline, err := tdb.GetPipelineByName(b.pipelineName)
+	savedPipeline, err := scanPipeline(b.conn.QueryRow(`
+		SELECT `+pipelineColumns+`
+		FROM pipelines p
+		INNER JOIN teams t ON t.id = p.team_id
+		WHERE p.id = $1
+	`, pipelineID))
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -717,6 +735,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		QueuePosition:       queuePosition,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
@@ -725,6 +744,48 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 	return buildPreparation, true, nil
 }
 
+// getQueuePosition returns the build's one-based position in line among the
+// pending builds of its job's serial groups, or 0 if the job is not in any
+// serial group.
+func (b *build) getQueuePosition(pipelineID int, jobID int) (int, error) {
+	var serialGroups int
+	err := b.conn.QueryRow(`
+		SELECT COUNT(*)
+		FROM jobs_serial_groups
+		WHERE job_id = $1
+	`, jobID).Scan(&serialGroups)
+	if err != nil {
+		return 0, err
+	}
+
+	if serialGroups == 0 {
+		return 0, nil
+	}
+
+	var ahead int
+	err = b.conn.QueryRow(`
+		SELECT COUNT(DISTINCT b.id)
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
+		WHERE jsg.serial_group IN (
+				SELECT serial_group
+				FROM jobs_serial_groups
+				WHERE job_id = $3
+			)
+			AND b.status = 'pending'
+			AND b.scheduled = false
+			AND j.inputs_determined = true
+			AND j.pipeline_id = $2
+			AND b.id < $1
+	`, b.id, pipelineID, jobID).Scan(&ahead)
+	if err != nil {
+		return 0, err
+	}
+
+	return ahead + 1, nil
+}
+
 func (b *build) SaveInput(input BuildInput) (SavedVersionedResource, error) {
 	row := b.conn.QueryRow(`
 		SELECT `+pipelineColumns+`
@@ -23,14 +23,14 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var name string
 	var jobID, pipelineID, teamID sql.NullInt64
 	var status string
-	var scheduled bool
+	var scheduled, manuallyPinned bool
 	var engine, engineMetadata, jobName, pipelineName sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
 	var teamName string
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &manuallyPinned, &jobName, &pipelineID, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -48,6 +48,8 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		status:    Status(status),
 		scheduled: scheduled,
 
+		manuallyPinned: manuallyPinned,
+
 		engine:         engine.String,
 		engineMetadata: engineMetadata.String,
 
@@ -64,7 +64,7 @@ var _ = Describe("Build", func() {
 		}
 
 		var err error
-		pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+		pipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
@@ -628,6 +628,38 @@ var _ = Describe("Build", func() {
 						Expect(buildPrep).To(Equal(expectedBuildPrep))
 					})
 				})
+
+				Context("when the job is in a serial group", func() {
+					BeforeEach(func() {
+						_, err := pipelineDB.GetSerialGroupQueues("some-job", []string{"serial-group"})
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.QueuePosition = 1
+					})
+
+					It("returns build preparation with the build's position in the queue", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+
+					Context("when another build is ahead in the queue", func() {
+						var laterBuild db.Build
+
+						BeforeEach(func() {
+							laterBuild, err = pipelineDB.CreateJobBuild("some-job")
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("returns the later build's position behind it", func() {
+							buildPrep, found, err := laterBuild.GetPreparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep.QueuePosition).To(Equal(2))
+						})
+					})
+				})
 			})
 
 			Context("when inputs are not satisfied", func() {
@@ -680,7 +712,7 @@ var _ = Describe("Build", func() {
 						},
 					}
 
-					pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+					pipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					err = pipelineDB.SaveResourceVersions(
@@ -756,7 +788,7 @@ var _ = Describe("Build", func() {
 					},
 				}
 
-				pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+				pipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				build1, err = pipelineDB.CreateJobBuild("some-job")
@@ -51,6 +51,8 @@ type ContainerMetadata struct {
 	EnvironmentVariables []string
 	Attempts             []int
 	User                 string
+	CPULimit             uint64
+	MemoryLimit          uint64
 }
 
 type Container struct {
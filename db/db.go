@@ -91,6 +91,7 @@ type DB interface {
 	GetLease(logger lager.Logger, taskName string, interval time.Duration) (Lease, bool, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
+	GetUnreapedSucceededBuildIDs(jobID int) ([]int, error)
 
 	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
 	GetWorker(workerName string) (SavedWorker, bool, error)
@@ -109,6 +110,7 @@ type DB interface {
 	InsertVolume(data Volume) error
 	GetVolumes() ([]SavedVolume, error)
 	GetVolumesByIdentifier(VolumeIdentifier) ([]SavedVolume, error)
+	SetTaskCacheVolume(handle string, id TaskCacheIdentifier) error
 	ReapVolume(string) error
 	SetVolumeTTL(string, time.Duration) error
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
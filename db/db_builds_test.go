@@ -88,7 +88,7 @@ var _ = Describe("Keeping track of builds", func() {
 			},
 		}
 
-		pipeline, _, err = teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+		pipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, config, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
@@ -310,4 +310,26 @@ var _ = Describe("Keeping track of builds", func() {
 			Expect(build4DB.ReapTime()).To(Equal(build1DB.ReapTime()))
 		})
 	})
+
+	Describe("GetUnreapedSucceededBuildIDs", func() {
+		It("returns the job's succeeded builds whose events have not been reaped, latest first", func() {
+			job, err := pipelineDB.GetJob("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			succeeded1 := createAndFinishBuild(database, pipelineDB, "some-job", db.StatusSucceeded)
+			succeeded2 := createAndFinishBuild(database, pipelineDB, "some-job", db.StatusSucceeded)
+			createAndFinishBuild(database, pipelineDB, "some-job", db.StatusFailed)
+			reaped := createAndFinishBuild(database, pipelineDB, "some-job", db.StatusSucceeded)
+			succeeded3 := createAndFinishBuild(database, pipelineDB, "some-job", db.StatusSucceeded)
+			createAndStartBuild(database, pipelineDB, "some-job", "some-engine")
+			createAndFinishBuild(database, pipelineDB, "some-other-job", db.StatusSucceeded)
+
+			err = database.DeleteBuildEventsByBuildIDs([]int{reaped.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			buildIDs, err := database.GetUnreapedSucceededBuildIDs(job.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs).To(Equal([]int{succeeded3.ID(), succeeded2.ID(), succeeded1.ID()}))
+		})
+	})
 })
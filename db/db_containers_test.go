@@ -75,10 +75,10 @@ var _ = Describe("Keeping track of containers", func() {
 		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
 		teamDB = teamDBFactory.GetTeamDB("team-name")
 
-		savedPipeline, _, err = teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		savedOtherPipeline, _, err = teamDB.SaveConfig("some-other-pipeline", config, 0, db.PipelineUnpaused)
+		savedOtherPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-other-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
@@ -325,6 +325,8 @@ var _ = Describe("Keeping track of containers", func() {
 				User:                 "test-user",
 				Attempts:             []int{1, 2, 4},
 				TeamID:               teamID,
+				CPULimit:             512,
+				MemoryLimit:          1024,
 			},
 		}
 
@@ -403,6 +405,8 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(actualContainer.EnvironmentVariables).To(Equal(containerToCreate.EnvironmentVariables))
 		Expect(actualContainer.User).To(Equal(containerToCreate.User))
 		Expect(actualContainer.Attempts).To(Equal(containerToCreate.Attempts))
+		Expect(actualContainer.CPULimit).To(Equal(containerToCreate.CPULimit))
+		Expect(actualContainer.MemoryLimit).To(Equal(containerToCreate.MemoryLimit))
 
 		Expect(actualContainer.ResourceID).To(Equal(0))
 		Expect(actualContainer.ResourceName).To(Equal(""))
@@ -454,6 +458,8 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(actualContainer.JobName).To(Equal("some-job"))
 		Expect(actualContainer.User).To(Equal("root"))
 		Expect(actualContainer.TeamID).To(Equal(teamID))
+		Expect(actualContainer.CPULimit).To(BeZero())
+		Expect(actualContainer.MemoryLimit).To(BeZero())
 	})
 
 	Describe("UpdateExpiresAtOnContainer", func() {
@@ -45,7 +45,7 @@ var _ = Describe("Image Versions", func() {
 			},
 		}
 
-		savedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
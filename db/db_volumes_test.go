@@ -45,7 +45,7 @@ var _ = Describe("Keeping track of volumes", func() {
 		}
 		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
-		savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, config, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -543,6 +543,142 @@ var _ = Describe("Keeping track of volumes", func() {
 				Expect(handles).To(ConsistOf([]string{"my-import-handle", "my-other-import-handle"}))
 			})
 		})
+
+		Describe("task cache volumes", func() {
+			var cacheVolume db.Volume
+			var cacheIdentifier db.VolumeIdentifier
+
+			BeforeEach(func() {
+				cacheIdentifier = db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						WorkerName: "some-worker",
+						PipelineID: pipelineDB.GetPipelineID(),
+						JobName:    "some-job",
+						StepName:   "some-task",
+						Path:       "some/cache",
+					},
+				}
+				cacheVolume = db.Volume{
+					WorkerName: "some-worker",
+					TeamID:     teamID,
+					TTL:        0,
+					Handle:     "my-cache-handle",
+					Identifier: cacheIdentifier,
+				}
+
+				err := database.InsertVolume(cacheVolume)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("can be retrieved", func() {
+				savedCacheVolumes, err := database.GetVolumesByIdentifier(cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(savedCacheVolumes)).To(Equal(1))
+
+				savedCacheVolume := savedCacheVolumes[0]
+				Expect(savedCacheVolume.WorkerName).To(Equal(cacheVolume.WorkerName))
+				Expect(savedCacheVolume.TTL).To(BeZero())
+				Expect(savedCacheVolume.Handle).To(Equal(cacheVolume.Handle))
+				Expect(savedCacheVolume.Volume.Identifier).To(Equal(cacheIdentifier))
+			})
+
+			It("is not found on other workers", func() {
+				otherWorkerIdentifier := *cacheIdentifier.TaskCache
+				otherWorkerIdentifier.WorkerName = "some-other-worker"
+
+				savedCacheVolumes, err := database.GetVolumesByIdentifier(db.VolumeIdentifier{
+					TaskCache: &otherWorkerIdentifier,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(BeEmpty())
+			})
+
+			It("can be found for the job", func() {
+				savedCacheVolumes, err := pipelineDB.GetJobTaskCacheVolumes("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(savedCacheVolumes)).To(Equal(1))
+				Expect(savedCacheVolumes[0].Handle).To(Equal("my-cache-handle"))
+
+				savedCacheVolumes, err = pipelineDB.GetJobTaskCacheVolumes("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(BeEmpty())
+			})
+
+			Context("when a newer volume is saved as the cache", func() {
+				BeforeEach(func() {
+					err := database.InsertVolume(db.Volume{
+						WorkerName: "some-worker",
+						TeamID:     teamID,
+						TTL:        5 * time.Minute,
+						Handle:     "my-new-cache-handle",
+						Identifier: db.VolumeIdentifier{
+							Output: &db.OutputIdentifier{
+								Name: "task-cache",
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = database.SetTaskCacheVolume("my-new-cache-handle", *cacheIdentifier.TaskCache)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is found after the cache it replaces", func() {
+					savedCacheVolumes, err := database.GetVolumesByIdentifier(cacheIdentifier)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(savedCacheVolumes)).To(Equal(2))
+					Expect(savedCacheVolumes[0].Handle).To(Equal("my-cache-handle"))
+					Expect(savedCacheVolumes[1].Handle).To(Equal("my-new-cache-handle"))
+					Expect(savedCacheVolumes[1].Volume.Identifier).To(Equal(cacheIdentifier))
+				})
+
+				It("lives as long as the job", func() {
+					ttl, found, err := database.GetVolumeTTL("my-new-cache-handle")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(ttl).To(BeZero())
+				})
+			})
+
+			It("cannot save a volume from another worker as the cache", func() {
+				otherWorkerIdentifier := *cacheIdentifier.TaskCache
+				otherWorkerIdentifier.WorkerName = "some-other-worker"
+
+				err := database.SetTaskCacheVolume("my-cache-handle", otherWorkerIdentifier)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("can be reaped", func() {
+				err := pipelineDB.ReapTaskCacheVolume("my-cache-handle")
+				Expect(err).NotTo(HaveOccurred())
+
+				savedCacheVolumes, err := database.GetVolumesByIdentifier(cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(BeEmpty())
+			})
+
+			It("does not reap volumes that are not the pipeline's caches", func() {
+				err := database.InsertVolume(db.Volume{
+					WorkerName: "some-worker",
+					TTL:        5 * time.Minute,
+					Handle:     "my-output-handle",
+					Identifier: db.VolumeIdentifier{
+						Output: &db.OutputIdentifier{
+							Name: "some-output",
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.ReapTaskCacheVolume("my-output-handle")
+				Expect(err).NotTo(HaveOccurred())
+
+				ttl, found, err := database.GetVolumeTTL("my-output-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(ttl).To(Equal(5 * time.Minute))
+			})
+		})
 	})
 
 	Describe("GetVolumesForOneOffBuildImageResources", func() {
@@ -95,6 +95,12 @@ type FakeBuild struct {
 	isScheduledReturns     struct {
 		result1 bool
 	}
+	IsManuallyPinnedStub        func() bool
+	isManuallyPinnedMutex       sync.RWMutex
+	isManuallyPinnedArgsForCall []struct{}
+	isManuallyPinnedReturns     struct {
+		result1 bool
+	}
 	IsRunningStub        func() bool
 	isRunningMutex       sync.RWMutex
 	isRunningArgsForCall []struct{}
@@ -610,6 +616,31 @@ func (fake *FakeBuild) IsScheduledReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IsManuallyPinned() bool {
+	fake.isManuallyPinnedMutex.Lock()
+	fake.isManuallyPinnedArgsForCall = append(fake.isManuallyPinnedArgsForCall, struct{}{})
+	fake.recordInvocation("IsManuallyPinned", []interface{}{})
+	fake.isManuallyPinnedMutex.Unlock()
+	if fake.IsManuallyPinnedStub != nil {
+		return fake.IsManuallyPinnedStub()
+	} else {
+		return fake.isManuallyPinnedReturns.result1
+	}
+}
+
+func (fake *FakeBuild) IsManuallyPinnedCallCount() int {
+	fake.isManuallyPinnedMutex.RLock()
+	defer fake.isManuallyPinnedMutex.RUnlock()
+	return len(fake.isManuallyPinnedArgsForCall)
+}
+
+func (fake *FakeBuild) IsManuallyPinnedReturns(result1 bool) {
+	fake.IsManuallyPinnedStub = nil
+	fake.isManuallyPinnedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsRunning() bool {
 	fake.isRunningMutex.Lock()
 	fake.isRunningArgsForCall = append(fake.isRunningArgsForCall, struct{}{})
@@ -1242,6 +1273,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isOneOffMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
 	defer fake.isScheduledMutex.RUnlock()
+	fake.isManuallyPinnedMutex.RLock()
+	defer fake.isManuallyPinnedMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -18,6 +18,12 @@ type FakePipelineDB struct {
 	getPipelineNameReturns     struct {
 		result1 string
 	}
+	GetTeamNameStub        func() string
+	getTeamNameMutex       sync.RWMutex
+	getTeamNameArgsForCall []struct{}
+	getTeamNameReturns     struct {
+		result1 string
+	}
 	GetPipelineIDStub        func() int
 	getPipelineIDMutex       sync.RWMutex
 	getPipelineIDArgsForCall []struct{}
@@ -77,6 +83,12 @@ type FakePipelineDB struct {
 	destroyReturns     struct {
 		result1 error
 	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct{}
+	archiveReturns     struct {
+		result1 error
+	}
 	GetConfigStub        func() (atc.Config, db.ConfigVersion, bool, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct{}
@@ -192,6 +204,15 @@ type FakePipelineDB struct {
 		result2 bool
 		result3 error
 	}
+	GetDisabledVersionsStub        func(resourceName string) ([]db.Version, error)
+	getDisabledVersionsMutex       sync.RWMutex
+	getDisabledVersionsArgsForCall []struct {
+		resourceName string
+	}
+	getDisabledVersionsReturns struct {
+		result1 []db.Version
+		result2 error
+	}
 	EnableVersionedResourceStub        func(versionedResourceID int) error
 	enableVersionedResourceMutex       sync.RWMutex
 	enableVersionedResourceArgsForCall []struct {
@@ -208,6 +229,26 @@ type FakePipelineDB struct {
 	disableVersionedResourceReturns struct {
 		result1 error
 	}
+	PinVersionedResourceStub        func(resourceName string, versionedResourceID int, comment string, pinnedBy string) error
+	pinVersionedResourceMutex       sync.RWMutex
+	pinVersionedResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+		pinnedBy            string
+	}
+	pinVersionedResourceReturns struct {
+		result1 error
+	}
+	UnpinVersionedResourceStub        func(resourceName string, versionedResourceID int) error
+	unpinVersionedResourceMutex       sync.RWMutex
+	unpinVersionedResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	unpinVersionedResourceReturns struct {
+		result1 error
+	}
 	SetResourceCheckErrorStub        func(resource db.SavedResource, err error) error
 	setResourceCheckErrorMutex       sync.RWMutex
 	setResourceCheckErrorArgsForCall []struct {
@@ -286,6 +327,23 @@ type FakePipelineDB struct {
 	updateFirstLoggedBuildIDReturns struct {
 		result1 error
 	}
+	GetJobTaskCacheVolumesStub        func(job string) ([]db.SavedVolume, error)
+	getJobTaskCacheVolumesMutex       sync.RWMutex
+	getJobTaskCacheVolumesArgsForCall []struct {
+		job string
+	}
+	getJobTaskCacheVolumesReturns struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
+	ReapTaskCacheVolumeStub        func(handle string) error
+	reapTaskCacheVolumeMutex       sync.RWMutex
+	reapTaskCacheVolumeArgsForCall []struct {
+		handle string
+	}
+	reapTaskCacheVolumeReturns struct {
+		result1 error
+	}
 	GetJobFinishedAndNextBuildStub        func(job string) (db.Build, db.Build, error)
 	getJobFinishedAndNextBuildMutex       sync.RWMutex
 	getJobFinishedAndNextBuildArgsForCall []struct {
@@ -336,6 +394,16 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateManualJobBuildStub        func(job string, inputMapping algorithm.InputMapping) (db.Build, error)
+	createManualJobBuildMutex       sync.RWMutex
+	createManualJobBuildArgsForCall []struct {
+		job          string
+		inputMapping algorithm.InputMapping
+	}
+	createManualJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -448,6 +516,15 @@ type FakePipelineDB struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetRunningJobBuildsStub        func(jobName string) ([]db.Build, error)
+	getRunningJobBuildsMutex       sync.RWMutex
+	getRunningJobBuildsArgsForCall []struct {
+		jobName string
+	}
+	getRunningJobBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetNextPendingBuildBySerialGroupStub        func(jobName string, serialGroups []string) (db.Build, bool, error)
 	getNextPendingBuildBySerialGroupMutex       sync.RWMutex
 	getNextPendingBuildBySerialGroupArgsForCall []struct {
@@ -459,6 +536,16 @@ type FakePipelineDB struct {
 		result2 bool
 		result3 error
 	}
+	GetSerialGroupQueuesStub        func(jobName string, serialGroups []string) (map[string]db.SerialGroupQueue, error)
+	getSerialGroupQueuesMutex       sync.RWMutex
+	getSerialGroupQueuesArgsForCall []struct {
+		jobName      string
+		serialGroups []string
+	}
+	getSerialGroupQueuesReturns struct {
+		result1 map[string]db.SerialGroupQueue
+		result2 error
+	}
 	UpdateBuildToScheduledStub        func(buildID int) (bool, error)
 	updateBuildToScheduledMutex       sync.RWMutex
 	updateBuildToScheduledArgsForCall []struct {
@@ -556,6 +643,31 @@ func (fake *FakePipelineDB) GetPipelineNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetTeamName() string {
+	fake.getTeamNameMutex.Lock()
+	fake.getTeamNameArgsForCall = append(fake.getTeamNameArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamName", []interface{}{})
+	fake.getTeamNameMutex.Unlock()
+	if fake.GetTeamNameStub != nil {
+		return fake.GetTeamNameStub()
+	} else {
+		return fake.getTeamNameReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) GetTeamNameCallCount() int {
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
+	return len(fake.getTeamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetTeamNameReturns(result1 string) {
+	fake.GetTeamNameStub = nil
+	fake.getTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipelineDB) GetPipelineID() int {
 	fake.getPipelineIDMutex.Lock()
 	fake.getPipelineIDArgsForCall = append(fake.getPipelineIDArgsForCall, struct{}{})
@@ -798,6 +910,31 @@ func (fake *FakePipelineDB) DestroyReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) Archive() error {
+	fake.archiveMutex.Lock()
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct{}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	} else {
+		return fake.archiveReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipelineDB) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) GetConfig() (atc.Config, db.ConfigVersion, bool, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct{}{})
@@ -1206,6 +1343,40 @@ func (fake *FakePipelineDB) GetLatestEnabledVersionedResourceReturns(result1 db.
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipelineDB) GetDisabledVersions(resourceName string) ([]db.Version, error) {
+	fake.getDisabledVersionsMutex.Lock()
+	fake.getDisabledVersionsArgsForCall = append(fake.getDisabledVersionsArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.recordInvocation("GetDisabledVersions", []interface{}{resourceName})
+	fake.getDisabledVersionsMutex.Unlock()
+	if fake.GetDisabledVersionsStub != nil {
+		return fake.GetDisabledVersionsStub(resourceName)
+	} else {
+		return fake.getDisabledVersionsReturns.result1, fake.getDisabledVersionsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetDisabledVersionsCallCount() int {
+	fake.getDisabledVersionsMutex.RLock()
+	defer fake.getDisabledVersionsMutex.RUnlock()
+	return len(fake.getDisabledVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetDisabledVersionsArgsForCall(i int) string {
+	fake.getDisabledVersionsMutex.RLock()
+	defer fake.getDisabledVersionsMutex.RUnlock()
+	return fake.getDisabledVersionsArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) GetDisabledVersionsReturns(result1 []db.Version, result2 error) {
+	fake.GetDisabledVersionsStub = nil
+	fake.getDisabledVersionsReturns = struct {
+		result1 []db.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) EnableVersionedResource(versionedResourceID int) error {
 	fake.enableVersionedResourceMutex.Lock()
 	fake.enableVersionedResourceArgsForCall = append(fake.enableVersionedResourceArgsForCall, struct {
@@ -1272,6 +1443,76 @@ func (fake *FakePipelineDB) DisableVersionedResourceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) PinVersionedResource(resourceName string, versionedResourceID int, comment string, pinnedBy string) error {
+	fake.pinVersionedResourceMutex.Lock()
+	fake.pinVersionedResourceArgsForCall = append(fake.pinVersionedResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+		pinnedBy            string
+	}{resourceName, versionedResourceID, comment, pinnedBy})
+	fake.recordInvocation("PinVersionedResource", []interface{}{resourceName, versionedResourceID, comment, pinnedBy})
+	fake.pinVersionedResourceMutex.Unlock()
+	if fake.PinVersionedResourceStub != nil {
+		return fake.PinVersionedResourceStub(resourceName, versionedResourceID, comment, pinnedBy)
+	} else {
+		return fake.pinVersionedResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceCallCount() int {
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	return len(fake.pinVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceArgsForCall(i int) (string, int, string, string) {
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	return fake.pinVersionedResourceArgsForCall[i].resourceName, fake.pinVersionedResourceArgsForCall[i].versionedResourceID, fake.pinVersionedResourceArgsForCall[i].comment, fake.pinVersionedResourceArgsForCall[i].pinnedBy
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceReturns(result1 error) {
+	fake.PinVersionedResourceStub = nil
+	fake.pinVersionedResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResource(resourceName string, versionedResourceID int) error {
+	fake.unpinVersionedResourceMutex.Lock()
+	fake.unpinVersionedResourceArgsForCall = append(fake.unpinVersionedResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.recordInvocation("UnpinVersionedResource", []interface{}{resourceName, versionedResourceID})
+	fake.unpinVersionedResourceMutex.Unlock()
+	if fake.UnpinVersionedResourceStub != nil {
+		return fake.UnpinVersionedResourceStub(resourceName, versionedResourceID)
+	} else {
+		return fake.unpinVersionedResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResourceCallCount() int {
+	fake.unpinVersionedResourceMutex.RLock()
+	defer fake.unpinVersionedResourceMutex.RUnlock()
+	return len(fake.unpinVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResourceArgsForCall(i int) (string, int) {
+	fake.unpinVersionedResourceMutex.RLock()
+	defer fake.unpinVersionedResourceMutex.RUnlock()
+	return fake.unpinVersionedResourceArgsForCall[i].resourceName, fake.unpinVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) UnpinVersionedResourceReturns(result1 error) {
+	fake.UnpinVersionedResourceStub = nil
+	fake.unpinVersionedResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) SetResourceCheckError(resource db.SavedResource, err error) error {
 	fake.setResourceCheckErrorMutex.Lock()
 	fake.setResourceCheckErrorArgsForCall = append(fake.setResourceCheckErrorArgsForCall, struct {
@@ -1550,6 +1791,73 @@ func (fake *FakePipelineDB) UpdateFirstLoggedBuildIDReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetJobTaskCacheVolumes(job string) ([]db.SavedVolume, error) {
+	fake.getJobTaskCacheVolumesMutex.Lock()
+	fake.getJobTaskCacheVolumesArgsForCall = append(fake.getJobTaskCacheVolumesArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("GetJobTaskCacheVolumes", []interface{}{job})
+	fake.getJobTaskCacheVolumesMutex.Unlock()
+	if fake.GetJobTaskCacheVolumesStub != nil {
+		return fake.GetJobTaskCacheVolumesStub(job)
+	} else {
+		return fake.getJobTaskCacheVolumesReturns.result1, fake.getJobTaskCacheVolumesReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetJobTaskCacheVolumesCallCount() int {
+	fake.getJobTaskCacheVolumesMutex.RLock()
+	defer fake.getJobTaskCacheVolumesMutex.RUnlock()
+	return len(fake.getJobTaskCacheVolumesArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobTaskCacheVolumesArgsForCall(i int) string {
+	fake.getJobTaskCacheVolumesMutex.RLock()
+	defer fake.getJobTaskCacheVolumesMutex.RUnlock()
+	return fake.getJobTaskCacheVolumesArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) GetJobTaskCacheVolumesReturns(result1 []db.SavedVolume, result2 error) {
+	fake.GetJobTaskCacheVolumesStub = nil
+	fake.getJobTaskCacheVolumesReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) ReapTaskCacheVolume(handle string) error {
+	fake.reapTaskCacheVolumeMutex.Lock()
+	fake.reapTaskCacheVolumeArgsForCall = append(fake.reapTaskCacheVolumeArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("ReapTaskCacheVolume", []interface{}{handle})
+	fake.reapTaskCacheVolumeMutex.Unlock()
+	if fake.ReapTaskCacheVolumeStub != nil {
+		return fake.ReapTaskCacheVolumeStub(handle)
+	} else {
+		return fake.reapTaskCacheVolumeReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ReapTaskCacheVolumeCallCount() int {
+	fake.reapTaskCacheVolumeMutex.RLock()
+	defer fake.reapTaskCacheVolumeMutex.RUnlock()
+	return len(fake.reapTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakePipelineDB) ReapTaskCacheVolumeArgsForCall(i int) string {
+	fake.reapTaskCacheVolumeMutex.RLock()
+	defer fake.reapTaskCacheVolumeMutex.RUnlock()
+	return fake.reapTaskCacheVolumeArgsForCall[i].handle
+}
+
+func (fake *FakePipelineDB) ReapTaskCacheVolumeReturns(result1 error) {
+	fake.ReapTaskCacheVolumeStub = nil
+	fake.reapTaskCacheVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) GetJobFinishedAndNextBuild(job string) (db.Build, db.Build, error) {
 	fake.getJobFinishedAndNextBuildMutex.Lock()
 	fake.getJobFinishedAndNextBuildArgsForCall = append(fake.getJobFinishedAndNextBuildArgsForCall, struct {
@@ -1725,6 +2033,41 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) CreateManualJobBuild(job string, inputMapping algorithm.InputMapping) (db.Build, error) {
+	fake.createManualJobBuildMutex.Lock()
+	fake.createManualJobBuildArgsForCall = append(fake.createManualJobBuildArgsForCall, struct {
+		job          string
+		inputMapping algorithm.InputMapping
+	}{job, inputMapping})
+	fake.recordInvocation("CreateManualJobBuild", []interface{}{job, inputMapping})
+	fake.createManualJobBuildMutex.Unlock()
+	if fake.CreateManualJobBuildStub != nil {
+		return fake.CreateManualJobBuildStub(job, inputMapping)
+	} else {
+		return fake.createManualJobBuildReturns.result1, fake.createManualJobBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateManualJobBuildCallCount() int {
+	fake.createManualJobBuildMutex.RLock()
+	defer fake.createManualJobBuildMutex.RUnlock()
+	return len(fake.createManualJobBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateManualJobBuildArgsForCall(i int) (string, algorithm.InputMapping) {
+	fake.createManualJobBuildMutex.RLock()
+	defer fake.createManualJobBuildMutex.RUnlock()
+	return fake.createManualJobBuildArgsForCall[i].job, fake.createManualJobBuildArgsForCall[i].inputMapping
+}
+
+func (fake *FakePipelineDB) CreateManualJobBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateManualJobBuildStub = nil
+	fake.createManualJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
@@ -2141,6 +2484,40 @@ func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Bu
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) GetRunningJobBuilds(jobName string) ([]db.Build, error) {
+	fake.getRunningJobBuildsMutex.Lock()
+	fake.getRunningJobBuildsArgsForCall = append(fake.getRunningJobBuildsArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetRunningJobBuilds", []interface{}{jobName})
+	fake.getRunningJobBuildsMutex.Unlock()
+	if fake.GetRunningJobBuildsStub != nil {
+		return fake.GetRunningJobBuildsStub(jobName)
+	} else {
+		return fake.getRunningJobBuildsReturns.result1, fake.getRunningJobBuildsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetRunningJobBuildsCallCount() int {
+	fake.getRunningJobBuildsMutex.RLock()
+	defer fake.getRunningJobBuildsMutex.RUnlock()
+	return len(fake.getRunningJobBuildsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetRunningJobBuildsArgsForCall(i int) string {
+	fake.getRunningJobBuildsMutex.RLock()
+	defer fake.getRunningJobBuildsMutex.RUnlock()
+	return fake.getRunningJobBuildsArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) GetRunningJobBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningJobBuildsStub = nil
+	fake.getRunningJobBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error) {
 	var serialGroupsCopy []string
 	if serialGroups != nil {
@@ -2182,6 +2559,46 @@ func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupReturns(result1 db.B
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipelineDB) GetSerialGroupQueues(jobName string, serialGroups []string) (map[string]db.SerialGroupQueue, error) {
+	var serialGroupsCopy []string
+	if serialGroups != nil {
+		serialGroupsCopy = make([]string, len(serialGroups))
+		copy(serialGroupsCopy, serialGroups)
+	}
+	fake.getSerialGroupQueuesMutex.Lock()
+	fake.getSerialGroupQueuesArgsForCall = append(fake.getSerialGroupQueuesArgsForCall, struct {
+		jobName      string
+		serialGroups []string
+	}{jobName, serialGroupsCopy})
+	fake.recordInvocation("GetSerialGroupQueues", []interface{}{jobName, serialGroupsCopy})
+	fake.getSerialGroupQueuesMutex.Unlock()
+	if fake.GetSerialGroupQueuesStub != nil {
+		return fake.GetSerialGroupQueuesStub(jobName, serialGroups)
+	} else {
+		return fake.getSerialGroupQueuesReturns.result1, fake.getSerialGroupQueuesReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetSerialGroupQueuesCallCount() int {
+	fake.getSerialGroupQueuesMutex.RLock()
+	defer fake.getSerialGroupQueuesMutex.RUnlock()
+	return len(fake.getSerialGroupQueuesArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetSerialGroupQueuesArgsForCall(i int) (string, []string) {
+	fake.getSerialGroupQueuesMutex.RLock()
+	defer fake.getSerialGroupQueuesMutex.RUnlock()
+	return fake.getSerialGroupQueuesArgsForCall[i].jobName, fake.getSerialGroupQueuesArgsForCall[i].serialGroups
+}
+
+func (fake *FakePipelineDB) GetSerialGroupQueuesReturns(result1 map[string]db.SerialGroupQueue, result2 error) {
+	fake.GetSerialGroupQueuesStub = nil
+	fake.getSerialGroupQueuesReturns = struct {
+		result1 map[string]db.SerialGroupQueue
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	fake.updateBuildToScheduledMutex.Lock()
 	fake.updateBuildToScheduledArgsForCall = append(fake.updateBuildToScheduledArgsForCall, struct {
@@ -2437,6 +2854,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.getPipelineNameMutex.RLock()
 	defer fake.getPipelineNameMutex.RUnlock()
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
 	fake.getPipelineIDMutex.RLock()
 	defer fake.getPipelineIDMutex.RUnlock()
 	fake.scopedNameMutex.RLock()
@@ -2455,6 +2874,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.updateNameMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
 	fake.leaseSchedulingMutex.RLock()
@@ -2479,10 +2900,16 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getLatestVersionedResourceMutex.RUnlock()
 	fake.getLatestEnabledVersionedResourceMutex.RLock()
 	defer fake.getLatestEnabledVersionedResourceMutex.RUnlock()
+	fake.getDisabledVersionsMutex.RLock()
+	defer fake.getDisabledVersionsMutex.RUnlock()
 	fake.enableVersionedResourceMutex.RLock()
 	defer fake.enableVersionedResourceMutex.RUnlock()
 	fake.disableVersionedResourceMutex.RLock()
 	defer fake.disableVersionedResourceMutex.RUnlock()
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	fake.unpinVersionedResourceMutex.RLock()
+	defer fake.unpinVersionedResourceMutex.RUnlock()
 	fake.setResourceCheckErrorMutex.RLock()
 	defer fake.setResourceCheckErrorMutex.RUnlock()
 	fake.leaseResourceCheckingMutex.RLock()
@@ -2499,6 +2926,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
+	fake.getJobTaskCacheVolumesMutex.RLock()
+	defer fake.getJobTaskCacheVolumesMutex.RUnlock()
+	fake.reapTaskCacheVolumeMutex.RLock()
+	defer fake.reapTaskCacheVolumeMutex.RUnlock()
 	fake.getJobFinishedAndNextBuildMutex.RLock()
 	defer fake.getJobFinishedAndNextBuildMutex.RUnlock()
 	fake.getJobBuildsMutex.RLock()
@@ -2509,6 +2940,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getJobBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createManualJobBuildMutex.RLock()
+	defer fake.createManualJobBuildMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.getNextPendingBuildMutex.RLock()
@@ -2533,8 +2966,12 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.getRunningBuildsBySerialGroupMutex.RLock()
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	fake.getRunningJobBuildsMutex.RLock()
+	defer fake.getRunningJobBuildsMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	fake.getSerialGroupQueuesMutex.RLock()
+	defer fake.getSerialGroupQueuesMutex.RUnlock()
 	fake.updateBuildToScheduledMutex.RLock()
 	defer fake.updateBuildToScheduledMutex.RUnlock()
 	fake.saveInputMutex.RLock()
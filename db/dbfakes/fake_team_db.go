@@ -6,6 +6,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 )
 
 type FakeTeamDB struct {
@@ -22,12 +23,19 @@ type FakeTeamDB struct {
 		result1 []db.SavedPipeline
 		result2 error
 	}
-	GetPipelineByNameStub        func(pipelineName string) (db.SavedPipeline, error)
-	getPipelineByNameMutex       sync.RWMutex
-	getPipelineByNameArgsForCall []struct {
-		pipelineName string
+	GetArchivedPipelinesStub        func() ([]db.SavedPipeline, error)
+	getArchivedPipelinesMutex       sync.RWMutex
+	getArchivedPipelinesArgsForCall []struct{}
+	getArchivedPipelinesReturns     struct {
+		result1 []db.SavedPipeline
+		result2 error
 	}
-	getPipelineByNameReturns struct {
+	GetPipelineByRefStub        func(pipelineRef atc.PipelineRef) (db.SavedPipeline, error)
+	getPipelineByRefMutex       sync.RWMutex
+	getPipelineByRefArgsForCall []struct {
+		pipelineRef atc.PipelineRef
+	}
+	getPipelineByRefReturns struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
@@ -81,10 +89,19 @@ type FakeTeamDB struct {
 		result1 db.SavedTeam
 		result2 error
 	}
-	GetConfigStub        func(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
+	UpdateContainerLimitsStub        func(containerLimits *atc.ContainerLimits) (db.SavedTeam, error)
+	updateContainerLimitsMutex       sync.RWMutex
+	updateContainerLimitsArgsForCall []struct {
+		containerLimits *atc.ContainerLimits
+	}
+	updateContainerLimitsReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	GetConfigStub        func(pipelineRef atc.PipelineRef) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct {
-		pipelineName string
+		pipelineRef atc.PipelineRef
 	}
 	getConfigReturns struct {
 		result1 atc.Config
@@ -92,19 +109,79 @@ type FakeTeamDB struct {
 		result3 db.ConfigVersion
 		result4 error
 	}
-	SaveConfigStub        func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	GetConfigTemplateStub        func(pipelineRef atc.PipelineRef) (atc.RawConfig, template.Variables, db.ConfigVersion, error)
+	getConfigTemplateMutex       sync.RWMutex
+	getConfigTemplateArgsForCall []struct {
+		pipelineRef atc.PipelineRef
+	}
+	getConfigTemplateReturns struct {
+		result1 atc.RawConfig
+		result2 template.Variables
+		result3 db.ConfigVersion
+		result4 error
+	}
+	SaveConfigStub        func(atc.PipelineRef, atc.Config, atc.RawConfig, template.Variables, db.ConfigVersion, db.PipelinePausedState, string) (db.SavedPipeline, bool, error)
 	saveConfigMutex       sync.RWMutex
 	saveConfigArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Config
-		arg3 db.ConfigVersion
-		arg4 db.PipelinePausedState
+		arg3 atc.RawConfig
+		arg4 template.Variables
+		arg5 db.ConfigVersion
+		arg6 db.PipelinePausedState
+		arg7 string
 	}
 	saveConfigReturns struct {
 		result1 db.SavedPipeline
 		result2 bool
 		result3 error
 	}
+	SaveConfigFromBuildStub        func(arg1 atc.PipelineRef, arg2 atc.Config, arg3 atc.RawConfig, arg4 template.Variables, arg5 db.ConfigVersion, arg6 string, arg7 int) (db.SavedPipeline, bool, error)
+	saveConfigFromBuildMutex       sync.RWMutex
+	saveConfigFromBuildArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 atc.Config
+		arg3 atc.RawConfig
+		arg4 template.Variables
+		arg5 db.ConfigVersion
+		arg6 string
+		arg7 int
+	}
+	saveConfigFromBuildReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	ImportPipelinesStub        func(pipelines []atc.ExportedPipeline, savedBy string, pinnedBy string) error
+	importPipelinesMutex       sync.RWMutex
+	importPipelinesArgsForCall []struct {
+		pipelines []atc.ExportedPipeline
+		savedBy   string
+		pinnedBy  string
+	}
+	importPipelinesReturns struct {
+		result1 error
+	}
+	GetConfigVersionsStub        func(pipelineRef atc.PipelineRef) ([]db.PipelineConfigVersion, error)
+	getConfigVersionsMutex       sync.RWMutex
+	getConfigVersionsArgsForCall []struct {
+		pipelineRef atc.PipelineRef
+	}
+	getConfigVersionsReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
+	GetConfigVersionStub        func(pipelineRef atc.PipelineRef, version int) (db.PipelineConfigVersion, bool, error)
+	getConfigVersionMutex       sync.RWMutex
+	getConfigVersionArgsForCall []struct {
+		pipelineRef atc.PipelineRef
+		version     int
+	}
+	getConfigVersionReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
 	CreateOneOffBuildStub        func() (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
 	createOneOffBuildArgsForCall []struct{}
@@ -221,35 +298,61 @@ func (fake *FakeTeamDB) GetPipelinesReturns(result1 []db.SavedPipeline, result2
 	}{result1, result2}
 }
 
-func (fake *FakeTeamDB) GetPipelineByName(pipelineName string) (db.SavedPipeline, error) {
-	fake.getPipelineByNameMutex.Lock()
-	fake.getPipelineByNameArgsForCall = append(fake.getPipelineByNameArgsForCall, struct {
-		pipelineName string
-	}{pipelineName})
-	fake.recordInvocation("GetPipelineByName", []interface{}{pipelineName})
-	fake.getPipelineByNameMutex.Unlock()
-	if fake.GetPipelineByNameStub != nil {
-		return fake.GetPipelineByNameStub(pipelineName)
+func (fake *FakeTeamDB) GetArchivedPipelines() ([]db.SavedPipeline, error) {
+	fake.getArchivedPipelinesMutex.Lock()
+	fake.getArchivedPipelinesArgsForCall = append(fake.getArchivedPipelinesArgsForCall, struct{}{})
+	fake.recordInvocation("GetArchivedPipelines", []interface{}{})
+	fake.getArchivedPipelinesMutex.Unlock()
+	if fake.GetArchivedPipelinesStub != nil {
+		return fake.GetArchivedPipelinesStub()
 	} else {
-		return fake.getPipelineByNameReturns.result1, fake.getPipelineByNameReturns.result2
+		return fake.getArchivedPipelinesReturns.result1, fake.getArchivedPipelinesReturns.result2
 	}
 }
 
-func (fake *FakeTeamDB) GetPipelineByNameCallCount() int {
-	fake.getPipelineByNameMutex.RLock()
-	defer fake.getPipelineByNameMutex.RUnlock()
-	return len(fake.getPipelineByNameArgsForCall)
+func (fake *FakeTeamDB) GetArchivedPipelinesCallCount() int {
+	fake.getArchivedPipelinesMutex.RLock()
+	defer fake.getArchivedPipelinesMutex.RUnlock()
+	return len(fake.getArchivedPipelinesArgsForCall)
 }
 
-func (fake *FakeTeamDB) GetPipelineByNameArgsForCall(i int) string {
-	fake.getPipelineByNameMutex.RLock()
-	defer fake.getPipelineByNameMutex.RUnlock()
-	return fake.getPipelineByNameArgsForCall[i].pipelineName
+func (fake *FakeTeamDB) GetArchivedPipelinesReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.GetArchivedPipelinesStub = nil
+	fake.getArchivedPipelinesReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeamDB) GetPipelineByNameReturns(result1 db.SavedPipeline, result2 error) {
-	fake.GetPipelineByNameStub = nil
-	fake.getPipelineByNameReturns = struct {
+func (fake *FakeTeamDB) GetPipelineByRef(pipelineRef atc.PipelineRef) (db.SavedPipeline, error) {
+	fake.getPipelineByRefMutex.Lock()
+	fake.getPipelineByRefArgsForCall = append(fake.getPipelineByRefArgsForCall, struct {
+		pipelineRef atc.PipelineRef
+	}{pipelineRef})
+	fake.recordInvocation("GetPipelineByRef", []interface{}{pipelineRef})
+	fake.getPipelineByRefMutex.Unlock()
+	if fake.GetPipelineByRefStub != nil {
+		return fake.GetPipelineByRefStub(pipelineRef)
+	} else {
+		return fake.getPipelineByRefReturns.result1, fake.getPipelineByRefReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetPipelineByRefCallCount() int {
+	fake.getPipelineByRefMutex.RLock()
+	defer fake.getPipelineByRefMutex.RUnlock()
+	return len(fake.getPipelineByRefArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetPipelineByRefArgsForCall(i int) atc.PipelineRef {
+	fake.getPipelineByRefMutex.RLock()
+	defer fake.getPipelineByRefMutex.RUnlock()
+	return fake.getPipelineByRefArgsForCall[i].pipelineRef
+}
+
+func (fake *FakeTeamDB) GetPipelineByRefReturns(result1 db.SavedPipeline, result2 error) {
+	fake.GetPipelineByRefStub = nil
+	fake.getPipelineByRefReturns = struct {
 		result1 db.SavedPipeline
 		result2 error
 	}{result1, result2}
@@ -448,15 +551,49 @@ func (fake *FakeTeamDB) UpdateUAAAuthReturns(result1 db.SavedTeam, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTeamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
+func (fake *FakeTeamDB) UpdateContainerLimits(containerLimits *atc.ContainerLimits) (db.SavedTeam, error) {
+	fake.updateContainerLimitsMutex.Lock()
+	fake.updateContainerLimitsArgsForCall = append(fake.updateContainerLimitsArgsForCall, struct {
+		containerLimits *atc.ContainerLimits
+	}{containerLimits})
+	fake.recordInvocation("UpdateContainerLimits", []interface{}{containerLimits})
+	fake.updateContainerLimitsMutex.Unlock()
+	if fake.UpdateContainerLimitsStub != nil {
+		return fake.UpdateContainerLimitsStub(containerLimits)
+	} else {
+		return fake.updateContainerLimitsReturns.result1, fake.updateContainerLimitsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateContainerLimitsCallCount() int {
+	fake.updateContainerLimitsMutex.RLock()
+	defer fake.updateContainerLimitsMutex.RUnlock()
+	return len(fake.updateContainerLimitsArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateContainerLimitsArgsForCall(i int) *atc.ContainerLimits {
+	fake.updateContainerLimitsMutex.RLock()
+	defer fake.updateContainerLimitsMutex.RUnlock()
+	return fake.updateContainerLimitsArgsForCall[i].containerLimits
+}
+
+func (fake *FakeTeamDB) UpdateContainerLimitsReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateContainerLimitsStub = nil
+	fake.updateContainerLimitsReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetConfig(pipelineRef atc.PipelineRef) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct {
-		pipelineName string
-	}{pipelineName})
-	fake.recordInvocation("GetConfig", []interface{}{pipelineName})
+		pipelineRef atc.PipelineRef
+	}{pipelineRef})
+	fake.recordInvocation("GetConfig", []interface{}{pipelineRef})
 	fake.getConfigMutex.Unlock()
 	if fake.GetConfigStub != nil {
-		return fake.GetConfigStub(pipelineName)
+		return fake.GetConfigStub(pipelineRef)
 	} else {
 		return fake.getConfigReturns.result1, fake.getConfigReturns.result2, fake.getConfigReturns.result3, fake.getConfigReturns.result4
 	}
@@ -468,10 +605,10 @@ func (fake *FakeTeamDB) GetConfigCallCount() int {
 	return len(fake.getConfigArgsForCall)
 }
 
-func (fake *FakeTeamDB) GetConfigArgsForCall(i int) string {
+func (fake *FakeTeamDB) GetConfigArgsForCall(i int) atc.PipelineRef {
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
-	return fake.getConfigArgsForCall[i].pipelineName
+	return fake.getConfigArgsForCall[i].pipelineRef
 }
 
 func (fake *FakeTeamDB) GetConfigReturns(result1 atc.Config, result2 atc.RawConfig, result3 db.ConfigVersion, result4 error) {
@@ -484,18 +621,57 @@ func (fake *FakeTeamDB) GetConfigReturns(result1 atc.Config, result2 atc.RawConf
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeamDB) SaveConfig(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+func (fake *FakeTeamDB) GetConfigTemplate(pipelineRef atc.PipelineRef) (atc.RawConfig, template.Variables, db.ConfigVersion, error) {
+	fake.getConfigTemplateMutex.Lock()
+	fake.getConfigTemplateArgsForCall = append(fake.getConfigTemplateArgsForCall, struct {
+		pipelineRef atc.PipelineRef
+	}{pipelineRef})
+	fake.recordInvocation("GetConfigTemplate", []interface{}{pipelineRef})
+	fake.getConfigTemplateMutex.Unlock()
+	if fake.GetConfigTemplateStub != nil {
+		return fake.GetConfigTemplateStub(pipelineRef)
+	} else {
+		return fake.getConfigTemplateReturns.result1, fake.getConfigTemplateReturns.result2, fake.getConfigTemplateReturns.result3, fake.getConfigTemplateReturns.result4
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigTemplateCallCount() int {
+	fake.getConfigTemplateMutex.RLock()
+	defer fake.getConfigTemplateMutex.RUnlock()
+	return len(fake.getConfigTemplateArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigTemplateArgsForCall(i int) atc.PipelineRef {
+	fake.getConfigTemplateMutex.RLock()
+	defer fake.getConfigTemplateMutex.RUnlock()
+	return fake.getConfigTemplateArgsForCall[i].pipelineRef
+}
+
+func (fake *FakeTeamDB) GetConfigTemplateReturns(result1 atc.RawConfig, result2 template.Variables, result3 db.ConfigVersion, result4 error) {
+	fake.GetConfigTemplateStub = nil
+	fake.getConfigTemplateReturns = struct {
+		result1 atc.RawConfig
+		result2 template.Variables
+		result3 db.ConfigVersion
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeamDB) SaveConfig(arg1 atc.PipelineRef, arg2 atc.Config, arg3 atc.RawConfig, arg4 template.Variables, arg5 db.ConfigVersion, arg6 db.PipelinePausedState, arg7 string) (db.SavedPipeline, bool, error) {
 	fake.saveConfigMutex.Lock()
 	fake.saveConfigArgsForCall = append(fake.saveConfigArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Config
-		arg3 db.ConfigVersion
-		arg4 db.PipelinePausedState
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("SaveConfig", []interface{}{arg1, arg2, arg3, arg4})
+		arg3 atc.RawConfig
+		arg4 template.Variables
+		arg5 db.ConfigVersion
+		arg6 db.PipelinePausedState
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("SaveConfig", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.saveConfigMutex.Unlock()
 	if fake.SaveConfigStub != nil {
-		return fake.SaveConfigStub(arg1, arg2, arg3, arg4)
+		return fake.SaveConfigStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	} else {
 		return fake.saveConfigReturns.result1, fake.saveConfigReturns.result2, fake.saveConfigReturns.result3
 	}
@@ -507,10 +683,10 @@ func (fake *FakeTeamDB) SaveConfigCallCount() int {
 	return len(fake.saveConfigArgsForCall)
 }
 
-func (fake *FakeTeamDB) SaveConfigArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+func (fake *FakeTeamDB) SaveConfigArgsForCall(i int) (atc.PipelineRef, atc.Config, atc.RawConfig, template.Variables, db.ConfigVersion, db.PipelinePausedState, string) {
 	fake.saveConfigMutex.RLock()
 	defer fake.saveConfigMutex.RUnlock()
-	return fake.saveConfigArgsForCall[i].arg1, fake.saveConfigArgsForCall[i].arg2, fake.saveConfigArgsForCall[i].arg3, fake.saveConfigArgsForCall[i].arg4
+	return fake.saveConfigArgsForCall[i].arg1, fake.saveConfigArgsForCall[i].arg2, fake.saveConfigArgsForCall[i].arg3, fake.saveConfigArgsForCall[i].arg4, fake.saveConfigArgsForCall[i].arg5, fake.saveConfigArgsForCall[i].arg6, fake.saveConfigArgsForCall[i].arg7
 }
 
 func (fake *FakeTeamDB) SaveConfigReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
@@ -522,6 +698,157 @@ func (fake *FakeTeamDB) SaveConfigReturns(result1 db.SavedPipeline, result2 bool
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeamDB) SaveConfigFromBuild(arg1 atc.PipelineRef, arg2 atc.Config, arg3 atc.RawConfig, arg4 template.Variables, arg5 db.ConfigVersion, arg6 string, arg7 int) (db.SavedPipeline, bool, error) {
+	fake.saveConfigFromBuildMutex.Lock()
+	fake.saveConfigFromBuildArgsForCall = append(fake.saveConfigFromBuildArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 atc.Config
+		arg3 atc.RawConfig
+		arg4 template.Variables
+		arg5 db.ConfigVersion
+		arg6 string
+		arg7 int
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("SaveConfigFromBuild", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.saveConfigFromBuildMutex.Unlock()
+	if fake.SaveConfigFromBuildStub != nil {
+		return fake.SaveConfigFromBuildStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	} else {
+		return fake.saveConfigFromBuildReturns.result1, fake.saveConfigFromBuildReturns.result2, fake.saveConfigFromBuildReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveConfigFromBuildCallCount() int {
+	fake.saveConfigFromBuildMutex.RLock()
+	defer fake.saveConfigFromBuildMutex.RUnlock()
+	return len(fake.saveConfigFromBuildArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveConfigFromBuildArgsForCall(i int) (atc.PipelineRef, atc.Config, atc.RawConfig, template.Variables, db.ConfigVersion, string, int) {
+	fake.saveConfigFromBuildMutex.RLock()
+	defer fake.saveConfigFromBuildMutex.RUnlock()
+	return fake.saveConfigFromBuildArgsForCall[i].arg1, fake.saveConfigFromBuildArgsForCall[i].arg2, fake.saveConfigFromBuildArgsForCall[i].arg3, fake.saveConfigFromBuildArgsForCall[i].arg4, fake.saveConfigFromBuildArgsForCall[i].arg5, fake.saveConfigFromBuildArgsForCall[i].arg6, fake.saveConfigFromBuildArgsForCall[i].arg7
+}
+
+func (fake *FakeTeamDB) SaveConfigFromBuildReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveConfigFromBuildStub = nil
+	fake.saveConfigFromBuildReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) ImportPipelines(pipelines []atc.ExportedPipeline, savedBy string, pinnedBy string) error {
+	var pipelinesCopy []atc.ExportedPipeline
+	if pipelines != nil {
+		pipelinesCopy = make([]atc.ExportedPipeline, len(pipelines))
+		copy(pipelinesCopy, pipelines)
+	}
+	fake.importPipelinesMutex.Lock()
+	fake.importPipelinesArgsForCall = append(fake.importPipelinesArgsForCall, struct {
+		pipelines []atc.ExportedPipeline
+		savedBy   string
+		pinnedBy  string
+	}{pipelinesCopy, savedBy, pinnedBy})
+	fake.recordInvocation("ImportPipelines", []interface{}{pipelinesCopy, savedBy, pinnedBy})
+	fake.importPipelinesMutex.Unlock()
+	if fake.ImportPipelinesStub != nil {
+		return fake.ImportPipelinesStub(pipelines, savedBy, pinnedBy)
+	} else {
+		return fake.importPipelinesReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) ImportPipelinesCallCount() int {
+	fake.importPipelinesMutex.RLock()
+	defer fake.importPipelinesMutex.RUnlock()
+	return len(fake.importPipelinesArgsForCall)
+}
+
+func (fake *FakeTeamDB) ImportPipelinesArgsForCall(i int) ([]atc.ExportedPipeline, string, string) {
+	fake.importPipelinesMutex.RLock()
+	defer fake.importPipelinesMutex.RUnlock()
+	return fake.importPipelinesArgsForCall[i].pipelines, fake.importPipelinesArgsForCall[i].savedBy, fake.importPipelinesArgsForCall[i].pinnedBy
+}
+
+func (fake *FakeTeamDB) ImportPipelinesReturns(result1 error) {
+	fake.ImportPipelinesStub = nil
+	fake.importPipelinesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) GetConfigVersions(pipelineRef atc.PipelineRef) ([]db.PipelineConfigVersion, error) {
+	fake.getConfigVersionsMutex.Lock()
+	fake.getConfigVersionsArgsForCall = append(fake.getConfigVersionsArgsForCall, struct {
+		pipelineRef atc.PipelineRef
+	}{pipelineRef})
+	fake.recordInvocation("GetConfigVersions", []interface{}{pipelineRef})
+	fake.getConfigVersionsMutex.Unlock()
+	if fake.GetConfigVersionsStub != nil {
+		return fake.GetConfigVersionsStub(pipelineRef)
+	} else {
+		return fake.getConfigVersionsReturns.result1, fake.getConfigVersionsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsCallCount() int {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return len(fake.getConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsArgsForCall(i int) atc.PipelineRef {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return fake.getConfigVersionsArgsForCall[i].pipelineRef
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsReturns(result1 []db.PipelineConfigVersion, result2 error) {
+	fake.GetConfigVersionsStub = nil
+	fake.getConfigVersionsReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetConfigVersion(pipelineRef atc.PipelineRef, version int) (db.PipelineConfigVersion, bool, error) {
+	fake.getConfigVersionMutex.Lock()
+	fake.getConfigVersionArgsForCall = append(fake.getConfigVersionArgsForCall, struct {
+		pipelineRef atc.PipelineRef
+		version     int
+	}{pipelineRef, version})
+	fake.recordInvocation("GetConfigVersion", []interface{}{pipelineRef, version})
+	fake.getConfigVersionMutex.Unlock()
+	if fake.GetConfigVersionStub != nil {
+		return fake.GetConfigVersionStub(pipelineRef, version)
+	} else {
+		return fake.getConfigVersionReturns.result1, fake.getConfigVersionReturns.result2, fake.getConfigVersionReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionCallCount() int {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return len(fake.getConfigVersionArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionArgsForCall(i int) (atc.PipelineRef, int) {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return fake.getConfigVersionArgsForCall[i].pipelineRef, fake.getConfigVersionArgsForCall[i].version
+}
+
+func (fake *FakeTeamDB) GetConfigVersionReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.GetConfigVersionStub = nil
+	fake.getConfigVersionReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeamDB) CreateOneOffBuild() (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
 	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct{}{})
@@ -747,8 +1074,10 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.hasTeamNameMutex.RUnlock()
 	fake.getPipelinesMutex.RLock()
 	defer fake.getPipelinesMutex.RUnlock()
-	fake.getPipelineByNameMutex.RLock()
-	defer fake.getPipelineByNameMutex.RUnlock()
+	fake.getArchivedPipelinesMutex.RLock()
+	defer fake.getArchivedPipelinesMutex.RUnlock()
+	fake.getPipelineByRefMutex.RLock()
+	defer fake.getPipelineByRefMutex.RUnlock()
 	fake.getAllPipelinesMutex.RLock()
 	defer fake.getAllPipelinesMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
@@ -761,10 +1090,22 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.updateGitHubAuthMutex.RUnlock()
 	fake.updateUAAAuthMutex.RLock()
 	defer fake.updateUAAAuthMutex.RUnlock()
+	fake.updateContainerLimitsMutex.RLock()
+	defer fake.updateContainerLimitsMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
+	fake.getConfigTemplateMutex.RLock()
+	defer fake.getConfigTemplateMutex.RUnlock()
 	fake.saveConfigMutex.RLock()
 	defer fake.saveConfigMutex.RUnlock()
+	fake.saveConfigFromBuildMutex.RLock()
+	defer fake.saveConfigFromBuildMutex.RUnlock()
+	fake.importPipelinesMutex.RLock()
+	defer fake.importPipelinesMutex.RUnlock()
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.getBuildsMutex.RLock()
@@ -7,6 +7,8 @@ var ErrNoBuild = errors.New("no build found")
 
 var ErrPipelineNotFound = errors.New("pipeline not found")
 
+var ErrVersionedResourceNotFound = errors.New("versioned resource not found")
+
 var ErrLockRowNotPresentOrAlreadyDeleted = errors.New("lock could not be acquired because it didn't exist or was already cleaned up")
 
 var ErrLockNotAvailable = errors.New("lock is currently held and cannot be immediately acquired")
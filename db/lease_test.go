@@ -83,7 +83,7 @@ var _ = Describe("Leases", func() {
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 		teamDB := teamDBFactory.GetTeamDB("some-team")
-		savedPipeline, _, err := teamDB.SaveConfig("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name"}, pipelineConfig, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTemplateAndVarsToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+			ADD COLUMN template text,
+			ADD COLUMN vars text
+	`)
+	return err
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreatePipelineConfigVersions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE pipeline_config_versions (
+			id serial PRIMARY KEY,
+			pipeline_id int NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			version int NOT NULL,
+			config text NOT NULL,
+			template text,
+			vars text,
+			saved_by text,
+			saved_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (pipeline_id, version)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, template, vars)
+		SELECT id, 1, config, template, vars
+		FROM pipelines
+	`)
+	return err
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTaskCacheToVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN task_cache_pipeline_id int DEFAULT null,
+		ADD COLUMN task_cache_job_name text DEFAULT null,
+		ADD COLUMN task_cache_step_name text DEFAULT null,
+		ADD COLUMN task_cache_path text DEFAULT null
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
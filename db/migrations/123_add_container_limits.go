@@ -0,0 +1,24 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddContainerLimits(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN container_limits text DEFAULT null
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE containers
+		ADD COLUMN cpu_limit bigint DEFAULT null,
+		ADD COLUMN memory_limit bigint DEFAULT null
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
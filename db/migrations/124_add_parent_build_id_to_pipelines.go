@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddParentBuildIDToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN parent_build_id integer REFERENCES builds (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddInstanceVarsToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN instance_vars text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		DROP CONSTRAINT pipelines_name_team_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX pipelines_name_team_id_instance_vars
+		ON pipelines (name, team_id, COALESCE(instance_vars, ''))
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddIDToBuildInputsAndOutputs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE build_inputs
+		ADD COLUMN id serial PRIMARY KEY
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE build_outputs
+		ADD COLUMN id serial PRIMARY KEY
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
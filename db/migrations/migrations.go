@@ -120,4 +120,14 @@ var Migrations = []migration.Migrator{
 	AddNextBuildInputs,
 	AddCaseInsenstiveUniqueIndexToTeamsName,
 	AddNonEmptyConstraintToTeamName,
+	AddTemplateAndVarsToPipelines,
+	CreatePipelineConfigVersions,
+	AddTaskCacheToVolumes,
+	AddContainerLimits,
+	AddParentBuildIDToPipelines,
+	AddPinnedVersionToResources,
+	AddArchivedToPipelines,
+	AddInstanceVarsToPipelines,
+	AddIDToBuildInputsAndOutputs,
+	AddManuallyPinnedToBuilds,
 }
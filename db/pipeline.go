@@ -3,9 +3,17 @@ package db
 import "github.com/concourse/atc"
 
 type Pipeline struct {
-	Name    string
-	Config  atc.Config
-	Version ConfigVersion
+	Name         string
+	InstanceVars atc.InstanceVars
+	Config       atc.Config
+	Version      ConfigVersion
+}
+
+func (pipeline Pipeline) Ref() atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         pipeline.Name,
+		InstanceVars: pipeline.InstanceVars,
+	}
 }
 
 type SavedPipeline struct {
@@ -15,5 +23,11 @@ type SavedPipeline struct {
 	TeamID   int
 	TeamName string
 
+	// the build whose set_pipeline step last configured the pipeline, if any
+	ParentBuildID int
+
+	// archived pipelines are read-only until their config is saved again
+	Archived bool
+
 	Pipeline
 }
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
+)
+
+// PipelineConfigVersion is a config as it was saved for a pipeline at some
+// point in its history. Versions are numbered from 1 for each pipeline.
+type PipelineConfigVersion struct {
+	Version  int
+	Config   atc.Config
+	Template atc.RawConfig
+	Vars     template.Variables
+	SavedBy  string
+	SavedAt  time.Time
+}
@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
 )
 
@@ -17,6 +20,7 @@ import (
 
 type PipelineDB interface {
 	GetPipelineName() string
+	GetTeamName() string
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
@@ -28,6 +32,7 @@ type PipelineDB interface {
 	UpdateName(string) error
 
 	Destroy() error
+	Archive() error
 
 	GetConfig() (atc.Config, ConfigVersion, bool, error)
 
@@ -45,8 +50,11 @@ type PipelineDB interface {
 	SaveResourceTypeVersion(atc.ResourceType, atc.Version) error
 	GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
+	GetDisabledVersions(resourceName string) ([]Version, error)
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
+	PinVersionedResource(resourceName string, versionedResourceID int, comment string, pinnedBy string) error
+	UnpinVersionedResource(resourceName string, versionedResourceID int) error
 	SetResourceCheckError(resource SavedResource, err error) error
 	LeaseResourceChecking(logger lager.Logger, resource string, length time.Duration, immediate bool) (Lease, bool, error)
 	LeaseResourceTypeChecking(logger lager.Logger, resourceType string, length time.Duration, immediate bool) (Lease, bool, error)
@@ -56,6 +64,8 @@ type PipelineDB interface {
 	UnpauseJob(job string) error
 	SetMaxInFlightReached(string, bool) error
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
+	GetJobTaskCacheVolumes(job string) ([]SavedVolume, error)
+	ReapTaskCacheVolume(handle string) error
 
 	GetJobFinishedAndNextBuild(job string) (Build, Build, error)
 
@@ -64,6 +74,7 @@ type PipelineDB interface {
 
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
+	CreateManualJobBuild(job string, inputMapping algorithm.InputMapping) (Build, error)
 	EnsurePendingBuildExists(jobName string) error
 	GetNextPendingBuild(jobName string) (Build, bool, error)
 	UseInputsForBuild(buildID int, inputs []BuildInput) error
@@ -78,7 +89,9 @@ type PipelineDB interface {
 	DeleteNextInputMapping(jobName string) error
 
 	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error)
+	GetRunningJobBuilds(jobName string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (Build, bool, error)
+	GetSerialGroupQueues(jobName string, serialGroups []string) (map[string]SerialGroupQueue, error)
 
 	UpdateBuildToScheduled(buildID int) (bool, error)
 	SaveInput(buildID int, input BuildInput) (SavedVersionedResource, error)
@@ -98,7 +111,7 @@ type pipelineDB struct {
 
 	SavedPipeline
 
-	versionsDB *algorithm.VersionsDB
+	versionsDBCache *versionsDBCache
 
 	buildFactory *buildFactory
 }
@@ -125,6 +138,10 @@ func (pdb *pipelineDB) GetPipelineName() string {
 	return pdb.Name
 }
 
+func (pdb *pipelineDB) GetTeamName() string {
+	return pdb.SavedPipeline.TeamName
+}
+
 func (pdb *pipelineDB) GetPipelineID() int {
 	return pdb.ID
 }
@@ -206,7 +223,198 @@ func (pdb *pipelineDB) Destroy() error {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return pdb.versionsDBCache.remove(pdb.ID)
+}
+
+// Archive pauses the pipeline and hides it from the pipeline listings, which
+// stops it from being scheduled or checked, while keeping its builds. The
+// credentials are dropped from the config and from all of its previous
+// versions, and its check containers and the containers of its finished
+// builds are expired. Builds which are still running keep their containers
+// until they finish. Saving the config again unarchives the pipeline.
+func (pdb *pipelineDB) Archive() error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var configBlob []byte
+	err = tx.QueryRow(`
+		SELECT config
+		FROM pipelines
+		WHERE id = $1
+		FOR UPDATE
+	`, pdb.ID).Scan(&configBlob)
+	if err != nil {
+		return err
+	}
+
+	sanitizedConfig, err := sanitizeConfigBlob(configBlob)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE pipelines
+		SET archived = true, paused = true, config = $1, template = NULL, vars = NULL, version = nextval('config_version_seq')
+		WHERE id = $2
+	`, sanitizedConfig, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT version, config
+		FROM pipeline_config_versions
+		WHERE pipeline_id = $1
+	`, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	sanitizedVersions := map[int][]byte{}
+	for rows.Next() {
+		var version int
+		var versionConfigBlob []byte
+		err = rows.Scan(&version, &versionConfigBlob)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		sanitizedVersions[version], err = sanitizeConfigBlob(versionConfigBlob)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+	}
+
+	rows.Close()
+
+	for version, sanitizedVersionConfig := range sanitizedVersions {
+		_, err = tx.Exec(`
+			UPDATE pipeline_config_versions
+			SET config = $1, template = NULL, vars = NULL
+			WHERE pipeline_id = $2
+			AND version = $3
+		`, sanitizedVersionConfig, pdb.ID, version)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE containers
+		SET expires_at = NOW()
+		WHERE (pipeline_id = $1 AND build_id IS NULL)
+		OR build_id IN (
+			SELECT b.id
+			FROM builds b
+			INNER JOIN jobs j ON j.id = b.job_id
+			WHERE j.pipeline_id = $1
+			AND b.status NOT IN ('pending', 'started')
+		)
+	`, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return pdb.versionsDBCache.remove(pdb.ID)
+}
+
+func sanitizeConfigBlob(configBlob []byte) ([]byte, error) {
+	var config atc.Config
+	err := json.Unmarshal(configBlob, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(sanitizeConfig(config))
+}
+
+// sanitizeConfig removes everything from the config that may carry
+// credentials: resource and resource type sources, step params and vars, the
+// values of across steps, task args, and webhook tokens.
+func sanitizeConfig(config atc.Config) atc.Config {
+	for i := range config.Resources {
+		config.Resources[i].Source = nil
+		config.Resources[i].WebhookToken = ""
+	}
+
+	for i := range config.ResourceTypes {
+		config.ResourceTypes[i].Source = nil
+		config.ResourceTypes[i].Params = nil
+	}
+
+	for i := range config.Jobs {
+		job := &config.Jobs[i]
+
+		sanitizePlanSequence(job.Plan)
+
+		for _, hook := range []*atc.PlanConfig{job.Failure, job.Ensure, job.Success, job.Abort} {
+			sanitizePlan(hook)
+		}
+	}
+
+	return config
+}
+
+func sanitizePlanSequence(plans atc.PlanSequence) {
+	for i := range plans {
+		sanitizePlan(&plans[i])
+	}
+}
+
+func sanitizePlan(plan *atc.PlanConfig) {
+	if plan == nil {
+		return
+	}
+
+	plan.Params = nil
+	plan.GetParams = nil
+	plan.Vars = nil
+
+	for i := range plan.Across {
+		plan.Across[i].Values = nil
+	}
+
+	if plan.TaskConfig != nil {
+		plan.TaskConfig.Params = nil
+		plan.TaskConfig.Run.Args = nil
+
+		if plan.TaskConfig.ImageResource != nil {
+			plan.TaskConfig.ImageResource.Source = nil
+			plan.TaskConfig.ImageResource.Params = nil
+		}
+	}
+
+	if plan.Do != nil {
+		sanitizePlanSequence(*plan.Do)
+	}
+
+	if plan.Aggregate != nil {
+		sanitizePlanSequence(*plan.Aggregate)
+	}
+
+	if plan.InParallel != nil {
+		sanitizePlanSequence(plan.InParallel.Steps)
+	}
+
+	for _, hook := range []*atc.PlanConfig{plan.Failure, plan.Ensure, plan.Success, plan.Abort, plan.Error, plan.Try} {
+		sanitizePlan(hook)
+	}
 }
 
 func (pdb *pipelineDB) GetConfig() (atc.Config, ConfigVersion, bool, error) {
@@ -258,9 +466,10 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]DashboardResource, atc.GroupConfigs, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, check_error, paused
-			FROM resources
-			WHERE pipeline_id = $1
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources pv ON pv.id = r.pinned_version_id
+			WHERE r.pipeline_id = $1
 		`, pdb.ID)
 
 	if err != nil {
@@ -272,16 +481,12 @@ func (pdb *pipelineDB) GetResources() ([]DashboardResource, atc.GroupConfigs, bo
 	savedResources := map[string]SavedResource{}
 
 	for rows.Next() {
-		savedResource := SavedResource{PipelineName: pdb.Name}
-		var checkErr sql.NullString
-		err := rows.Scan(&savedResource.ID, &savedResource.Name, &checkErr, &savedResource.Paused)
+		savedResource, err := scanResource(rows)
 		if err != nil {
 			return nil, nil, false, err
 		}
 
-		if checkErr.Valid {
-			savedResource.CheckError = errors.New(checkErr.String)
-		}
+		savedResource.PipelineName = pdb.Name
 		savedResources[savedResource.Name] = savedResource
 	}
 
@@ -661,15 +866,13 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 }
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
-	var checkErr sql.NullString
-	var resource SavedResource
-
-	err := tx.QueryRow(`
-			SELECT id, name, check_error, paused
-			FROM resources
-			WHERE name = $1
-				AND pipeline_id = $2
-		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused)
+	resource, err := scanResource(tx.QueryRow(`
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources pv ON pv.id = r.pinned_version_id
+			WHERE r.name = $1
+				AND r.pipeline_id = $2
+		`, name, pdb.ID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -680,11 +883,35 @@ func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, err
 
 	resource.PipelineName = pdb.GetPipelineName()
 
+	return resource, true, nil
+}
+
+const resourceColumns = "r.id, r.name, r.check_error, r.paused, r.pinned_version_id, pv.version, r.pin_comment, r.pinned_by"
+
+func scanResource(row scannable) (SavedResource, error) {
+	var resource SavedResource
+	var checkErr, pinnedVersion sql.NullString
+	var pinnedVersionID sql.NullInt64
+
+	err := row.Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused, &pinnedVersionID, &pinnedVersion, &resource.PinComment, &resource.PinnedBy)
+	if err != nil {
+		return SavedResource{}, err
+	}
+
 	if checkErr.Valid {
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
-	return resource, true, nil
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, err
+		}
+	}
+
+	return resource, nil
 }
 
 func (pdb *pipelineDB) GetResourceType(name string) (SavedResourceType, bool, error) {
@@ -782,6 +1009,8 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 
 	defer tx.Rollback()
 
+	reordered := false
+
 	for _, version := range versions {
 		vr := VersionedResource{
 			Resource: config.Name,
@@ -803,15 +1032,22 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 			return ResourceNotFoundError{Name: vr.Resource}
 		}
 
-		_, _, err = pdb.saveVersionedResource(tx, savedResource, vr)
+		svr, created, err := pdb.saveVersionedResource(tx, savedResource, vr)
 		if err != nil {
 			return err
 		}
 
-		err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
+		maxCheckOrder, err := pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
 		if err != nil {
 			return err
 		}
+
+		// bumping the check order of what was already the latest version doesn't
+		// change the order of the versions, so the cached versions DB is still
+		// valid
+		if !created && svr.CheckOrder < maxCheckOrder {
+			reordered = true
+		}
 	}
 
 	err = tx.Commit()
@@ -819,6 +1055,10 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 		return err
 	}
 
+	if reordered {
+		return pdb.versionsDBCache.invalidate(pdb.ID)
+	}
+
 	return nil
 }
 
@@ -884,7 +1124,103 @@ func (pdb *pipelineDB) toggleVersionedResource(versionedResourceID int, enable b
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	return pdb.versionsDBCache.invalidate(pdb.ID)
+}
+
+// PinVersionedResource pins the named resource to one of its versions. If the
+// version does not belong to the resource, ErrVersionedResourceNotFound is
+// returned.
+func (pdb *pipelineDB) PinVersionedResource(resourceName string, versionedResourceID int, comment string, pinnedBy string) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// bump the modified time of both the previously and newly pinned versions
+	// so that the cached versions DB is reloaded
+	_, err = tx.Exec(`
+		UPDATE versioned_resources
+		SET modified_time = now()
+		WHERE id = $1
+		OR id IN (
+			SELECT r.pinned_version_id
+			FROM resources r, versioned_resources v
+			WHERE v.id = $1
+			AND r.id = v.resource_id
+		)
+	`, versionedResourceID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE resources r
+		SET pinned_version_id = v.id, pin_comment = $2, pinned_by = $3
+		FROM versioned_resources v
+		WHERE v.id = $1
+		AND r.id = v.resource_id
+		AND r.pipeline_id = $4
+		AND r.name = $5
+	`, versionedResourceID, comment, pinnedBy, pdb.ID, resourceName)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return ErrVersionedResourceNotFound
+	}
+
+	return tx.Commit()
+}
+
+// UnpinVersionedResource unpins the named resource from the version. If the
+// resource is not pinned to the version, ErrVersionedResourceNotFound is
+// returned.
+func (pdb *pipelineDB) UnpinVersionedResource(resourceName string, versionedResourceID int) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL, pin_comment = '', pinned_by = ''
+		WHERE pinned_version_id = $1
+		AND pipeline_id = $2
+		AND name = $3
+	`, versionedResourceID, pdb.ID, resourceName)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return ErrVersionedResourceNotFound
+	}
+
+	_, err = tx.Exec(`
+		UPDATE versioned_resources
+		SET modified_time = now()
+		WHERE id = $1
+	`, versionedResourceID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error) {
@@ -928,6 +1264,42 @@ func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (S
 	return svr, true, nil
 }
 
+func (pdb *pipelineDB) GetDisabledVersions(resourceName string) ([]Version, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT v.version
+		FROM versioned_resources v, resources r
+		WHERE v.resource_id = r.id
+			AND r.name = $1
+			AND r.pipeline_id = $2
+			AND enabled = false
+		ORDER BY check_order ASC
+	`, resourceName, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := []Version{}
+	for rows.Next() {
+		var versionBytes string
+		err := rows.Scan(&versionBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var version Version
+		err = json.Unmarshal([]byte(versionBytes), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
 func (pdb *pipelineDB) GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
 
@@ -996,8 +1368,11 @@ func (pdb *pipelineDB) SetResourceCheckError(resource SavedResource, cause error
 	return err
 }
 
-func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) error {
-	_, err := tx.Exec(`
+// incrementCheckOrderWhenNewerVersion makes the version the latest one of the
+// resource, returning the previous highest check order.
+func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) (int, error) {
+	var maxCheckOrder int
+	err := tx.QueryRow(`
 		WITH max_checkorder AS (
 			SELECT max(check_order) co
 			FROM versioned_resources
@@ -1011,12 +1386,17 @@ func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int
 		WHERE resource_id = $1
 		AND type = $2
 		AND version = $3
-		AND check_order <= mc.co;`, resourceID, resourceType, version)
+		AND check_order <= mc.co
+		RETURNING mc.co`, resourceID, resourceType, version).Scan(&maxCheckOrder)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return maxCheckOrder, nil
 }
 
 func (pdb *pipelineDB) saveVersionedResource(tx Tx, savedResource SavedResource, vr VersionedResource) (SavedVersionedResource, bool, error) {
@@ -1164,7 +1544,7 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 
 	defer tx.Rollback()
 
-	_, err = tx.Exec(`
+	result, err := tx.Exec(`
 		DELETE FROM build_inputs
 		WHERE build_id = $1
 	`, buildID)
@@ -1172,6 +1552,11 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 		return err
 	}
 
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
 	for _, input := range inputs {
 		_, err := pdb.saveBuildInput(tx, buildID, input)
 		if err != nil {
@@ -1179,7 +1564,17 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 		}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	// the cached versions DB can't forget inputs which have been replaced
+	if deleted > 0 {
+		return pdb.versionsDBCache.invalidate(pdb.ID)
+	}
+
+	return nil
 }
 
 func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
@@ -1190,6 +1585,53 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 
 	defer tx.Rollback()
 
+	build, err := pdb.createJobBuild(tx, jobName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateManualJobBuild creates a pending build which will run with the given
+// inputs rather than the job's next build inputs.
+func (pdb *pipelineDB) CreateManualJobBuild(jobName string, inputMapping algorithm.InputMapping) (Build, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	build, err := pdb.createJobBuild(tx, jobName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for inputName, inputVersion := range inputMapping {
+		_, err := tx.Exec(`
+			INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+			VALUES ($1, $2, $3)
+		`, build.ID(), inputVersion.VersionID, inputName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (pdb *pipelineDB) createJobBuild(tx Tx, jobName string, manuallyPinned bool) (Build, error) {
 	buildName, jobID, err := getNewBuildNameForJob(tx, jobName, pdb.ID)
 	if err != nil {
 		return nil, err
@@ -1198,14 +1640,14 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	// We had to resort to sub-selects here because you can't paramaterize a
 	// RETURNING statement in lib/pq... sorry
 	build, _, err := pdb.buildFactory.ScanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, job_id, team_id, status)
-		VALUES ($1, $2, $3, 'pending')
+		INSERT INTO builds (name, job_id, team_id, status, manually_pinned)
+		VALUES ($1, $2, $3, 'pending', $5)
 		RETURNING `+buildColumns+`,
 			(SELECT name FROM jobs WHERE id = $2),
 			(SELECT id FROM pipelines WHERE id = $4),
 			(SELECT name FROM pipelines WHERE id = $4),
 			(SELECT name FROM teams WHERE id = $3)
-	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID))
+	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID, manuallyPinned))
 	if err != nil {
 		return nil, err
 	}
@@ -1215,11 +1657,6 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 		return nil, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, err
-	}
-
 	return build, nil
 }
 
@@ -1428,7 +1865,7 @@ func (pdb *pipelineDB) SaveOutput(buildID int, vr VersionedResource, explicit bo
 			return SavedVersionedResource{}, err
 		}
 
-		err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
+		_, err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
 		if err != nil {
 			return SavedVersionedResource{}, err
 		}
@@ -1593,6 +2030,121 @@ func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroup
 	return bs, nil
 }
 
+func (pdb *pipelineDB) GetRunningJobBuilds(jobName string) ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		INNER JOIN teams t ON b.team_id = t.id
+		WHERE (
+				b.status = 'started'
+				OR
+				(b.scheduled = true AND b.status = 'pending')
+			)
+			AND j.name = $1
+			AND j.pipeline_id = $2
+	`, jobName, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := pdb.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+func (pdb *pipelineDB) GetSerialGroupQueues(jobName string, serialGroups []string) (map[string]SerialGroupQueue, error) {
+	pdb.updateSerialGroupsForJob(jobName, serialGroups)
+
+	args := []interface{}{pdb.ID}
+	refs := make([]string, len(serialGroups))
+
+	queues := map[string]SerialGroupQueue{}
+	for i, serialGroup := range serialGroups {
+		args = append(args, serialGroup)
+		refs[i] = fmt.Sprintf("$%d", i+2)
+		queues[serialGroup] = SerialGroupQueue{Pending: []int{}}
+	}
+
+	rows, err := pdb.conn.Query(`
+		SELECT jsg.serial_group, COUNT(DISTINCT b.id)
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
+				AND jsg.serial_group IN (`+strings.Join(refs, ",")+`)
+		WHERE (
+				b.status = 'started'
+				OR
+				(b.scheduled = true AND b.status = 'pending')
+			)
+			AND j.pipeline_id = $1
+		GROUP BY jsg.serial_group
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var serialGroup string
+		var running int
+		err := rows.Scan(&serialGroup, &running)
+		if err != nil {
+			return nil, err
+		}
+
+		queue := queues[serialGroup]
+		queue.Running = running
+		queues[serialGroup] = queue
+	}
+
+	rows, err = pdb.conn.Query(`
+		SELECT jsg.serial_group, b.id
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
+				AND jsg.serial_group IN (`+strings.Join(refs, ",")+`)
+		WHERE b.status = 'pending'
+			AND b.scheduled = false
+			AND j.inputs_determined = true
+			AND j.pipeline_id = $1
+		ORDER BY b.id ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var serialGroup string
+		var buildID int
+		err := rows.Scan(&serialGroup, &buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		queue := queues[serialGroup]
+		queue.Pending = append(queue.Pending, buildID)
+		queues[serialGroup] = queue
+	}
+
+	return queues, nil
+}
+
 func (pdb *pipelineDB) IsPaused() (bool, error) {
 	var paused bool
 
@@ -1627,7 +2179,7 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+func (pdb *pipelineDB) getLatestModifiedTime(pipelineID int) (time.Time, error) {
 	var max_modified_time time.Time
 
 	err := pdb.conn.QueryRow(`
@@ -1658,105 +2210,252 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
 			WHERE r.pipeline_id = $1
 		) vr
-	`, pdb.ID).Scan(&max_modified_time)
+	`, pipelineID).Scan(&max_modified_time)
 
 	return max_modified_time, err
 }
 
-func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
+// crossPipelineJob is a job in another pipeline of the same team which is
+// referenced by a passed constraint, along with the resources the two
+// pipelines share.
+type crossPipelineJob struct {
+	passed       string
+	pipelineID   int
+	pipelineName string
+	jobName      string
+
+	// names of resources in the other pipeline, keyed by the name of the
+	// resource in this pipeline with the same type and source
+	sharedResources map[string]string
+}
+
+// getCrossPipelineJobs finds the jobs in other pipelines referenced by passed
+// constraints in the pipeline's config. It also returns a key identifying the
+// configs involved, so that changes to them invalidate the cached VersionsDB.
+//
+// A constraint such as pipeline/job refers to the pipeline with that name and
+// no instance vars. Archived pipelines are ignored.
+func (pdb *pipelineDB) getCrossPipelineJobs() ([]crossPipelineJob, string, error) {
+	pipelineConfig, configVersion, found, err := pdb.GetConfig()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	if pdb.versionsDB != nil && pdb.versionsDB.CachedAt.Equal(latestModifiedTime) {
-		return pdb.versionsDB, nil
+	if !found {
+		return nil, "", nil
+	}
+
+	configKey := fmt.Sprintf("%d:%d", pdb.ID, configVersion)
+
+	otherConfigs := map[string]atc.Config{}
+	otherPipelineIDs := map[string]int{}
+
+	jobs := []crossPipelineJob{}
+	seen := map[string]bool{}
+
+	for _, jobConfig := range pipelineConfig.Jobs {
+		for _, input := range config.JobInputs(jobConfig) {
+			for _, passed := range input.Passed {
+				pipelineName, jobName, isCrossPipeline := config.CrossPipelineJob(pipelineConfig, passed)
+				if !isCrossPipeline || seen[passed] {
+					continue
+				}
+
+				seen[passed] = true
+
+				otherConfig, loaded := otherConfigs[pipelineName]
+				if !loaded {
+					var configBlob []byte
+					var otherPipelineID, otherConfigVersion int
+					err := pdb.conn.QueryRow(`
+						SELECT id, config, version
+						FROM pipelines
+						WHERE team_id = $1
+						AND name = $2
+						AND instance_vars IS NULL
+						AND archived = false
+					`, pdb.TeamID(), pipelineName).Scan(&otherPipelineID, &configBlob, &otherConfigVersion)
+					if err == sql.ErrNoRows {
+						continue
+					}
+
+					if err != nil {
+						return nil, "", err
+					}
+
+					err = json.Unmarshal(configBlob, &otherConfig)
+					if err != nil {
+						return nil, "", err
+					}
+
+					otherConfigs[pipelineName] = otherConfig
+					otherPipelineIDs[pipelineName] = otherPipelineID
+					configKey += fmt.Sprintf(",%d:%d", otherPipelineID, otherConfigVersion)
+				}
+
+				sharedResources := map[string]string{}
+				for _, resource := range pipelineConfig.Resources {
+					for _, otherResource := range otherConfig.Resources {
+						if resource.Type == otherResource.Type && reflect.DeepEqual(resource.Source, otherResource.Source) {
+							sharedResources[resource.Name] = otherResource.Name
+							break
+						}
+					}
+				}
+
+				jobs = append(jobs, crossPipelineJob{
+					passed:          passed,
+					pipelineID:      otherPipelineIDs[pipelineName],
+					pipelineName:    pipelineName,
+					jobName:         jobName,
+					sharedResources: sharedResources,
+				})
+			}
+		}
 	}
 
-	db := &algorithm.VersionsDB{
-		BuildOutputs:     []algorithm.BuildOutput{},
-		BuildInputs:      []algorithm.BuildInput{},
-		ResourceVersions: []algorithm.ResourceVersion{},
-		JobIDs:           map[string]int{},
-		ResourceIDs:      map[string]int{},
-		CachedAt:         latestModifiedTime,
+	return jobs, configKey, nil
+}
+
+// loadCrossPipelineJob adds the succeeded build outputs of a job in another
+// pipeline to the VersionsDB, translated to the equivalent versions of this
+// pipeline's resources, so that passed constraints can be satisfied by them.
+func (pdb *pipelineDB) loadCrossPipelineJob(db *algorithm.VersionsDB, job crossPipelineJob) error {
+	var jobID int
+	err := pdb.conn.QueryRow(`
+		SELECT id
+		FROM jobs
+		WHERE pipeline_id = $1
+		AND name = $2
+	`, job.pipelineID, job.jobName).Scan(&jobID)
+	if err == sql.ErrNoRows {
+		return nil
 	}
 
-	rows, err := pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id, o.build_id, j.id
-    FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
-    WHERE v.id = o.versioned_resource_id
-    AND b.id = o.build_id
-    AND j.id = b.job_id
-    AND r.id = v.resource_id
-    AND v.enabled
-		AND b.status = 'succeeded'
-		AND r.pipeline_id = $1
-  `, pdb.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	for rows.Next() {
-		var output algorithm.BuildOutput
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+	db.JobIDs[job.passed] = jobID
+
+	for resourceName, otherResourceName := range job.sharedResources {
+		rows, err := pdb.conn.Query(`
+			SELECT lv.id, lv.check_order, lr.id, o.build_id
+			FROM build_outputs o, builds b, versioned_resources v, resources r, versioned_resources lv, resources lr
+			WHERE v.id = o.versioned_resource_id
+			AND b.id = o.build_id
+			AND r.id = v.resource_id
+			AND lv.version = v.version
+			AND lr.id = lv.resource_id
+			AND b.job_id = $1
+			AND b.status = 'succeeded'
+			AND v.enabled
+			AND lv.enabled
+			AND r.pipeline_id = $2
+			AND r.name = $3
+			AND lr.pipeline_id = $4
+			AND lr.name = $5
+		`, jobID, job.pipelineID, otherResourceName, pdb.ID, resourceName)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		output.ResourceVersion.CheckOrder = output.CheckOrder
+		for rows.Next() {
+			output := algorithm.BuildOutput{JobID: jobID}
+			err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID)
+			if err != nil {
+				rows.Close()
+				return err
+			}
 
-		db.BuildOutputs = append(db.BuildOutputs, output)
+			db.BuildOutputs = append(db.BuildOutputs, output)
+		}
+
+		rows.Close()
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
-    FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
-    WHERE v.id = i.versioned_resource_id
-    AND b.id = i.build_id
-    AND j.id = b.job_id
-    AND r.id = v.resource_id
-    AND v.enabled
-		AND r.pipeline_id = $1
-  `, pdb.ID)
+	return nil
+}
+
+func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
+	crossPipelineJobs, configKey, err := pdb.getCrossPipelineJobs()
 	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-		var input algorithm.BuildInput
-		err := rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID)
+	entry, err := pdb.versionsDBCache.entry(pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Lock()
+	defer entry.Unlock()
+
+	var versionsDB *algorithm.VersionsDB
+	if len(crossPipelineJobs) > 0 {
+		versionsDB, err = pdb.loadCrossPipelineVersionsDB(entry, crossPipelineJobs, configKey)
+	} else if entry.needsReload(configKey) {
+		atomic.AddInt64(&versionsDBCacheMisses, 1)
+		versionsDB, err = pdb.loadFullVersionsDB(entry, configKey)
+	} else {
+		atomic.AddInt64(&versionsDBCacheHits, 1)
+		versionsDB, err = pdb.loadVersionsDBIncrementally(entry)
+	}
+	if err != nil {
+		entry.invalidated = true
+		return nil, err
+	}
+
+	return versionsDB, nil
+}
+
+// loadCrossPipelineVersionsDB loads the versions DB of a pipeline with passed
+// constraints on jobs in other pipelines, which can't be updated incrementally
+// and so is fully reloaded whenever any of the pipelines have been modified.
+func (pdb *pipelineDB) loadCrossPipelineVersionsDB(entry *versionsDBCacheEntry, crossPipelineJobs []crossPipelineJob, configKey string) (*algorithm.VersionsDB, error) {
+	latestModifiedTime, err := pdb.getLatestModifiedTime(pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range crossPipelineJobs {
+		modifiedTime, err := pdb.getLatestModifiedTime(job.pipelineID)
 		if err != nil {
 			return nil, err
 		}
 
-		input.ResourceVersion.CheckOrder = input.CheckOrder
+		if modifiedTime.After(latestModifiedTime) {
+			latestModifiedTime = modifiedTime
+		}
+	}
 
-		db.BuildInputs = append(db.BuildInputs, input)
+	if !entry.needsReload(configKey) && entry.latestModifiedTime.Equal(latestModifiedTime) {
+		atomic.AddInt64(&versionsDBCacheHits, 1)
+		return entry.versionsDB, nil
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id
-    FROM versioned_resources v, resources r
-    WHERE r.id = v.resource_id
-    AND v.enabled
-		AND r.pipeline_id = $1
-  `, pdb.ID)
+	atomic.AddInt64(&versionsDBCacheMisses, 1)
+
+	versionsDB, err := pdb.loadFullVersionsDB(entry, configKey)
 	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-		var output algorithm.ResourceVersion
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
+	for _, job := range crossPipelineJobs {
+		err := pdb.loadCrossPipelineJob(versionsDB, job)
 		if err != nil {
 			return nil, err
 		}
-
-		db.ResourceVersions = append(db.ResourceVersions, output)
 	}
 
-	rows, err = pdb.conn.Query(`
+	versionsDB.CachedAt = latestModifiedTime
+	entry.latestModifiedTime = latestModifiedTime
+
+	return versionsDB, nil
+}
+
+func (pdb *pipelineDB) loadJobIDs() (map[string]int, error) {
+	rows, err := pdb.conn.Query(`
     SELECT j.name, j.id
     FROM jobs j
     WHERE j.pipeline_id = $1
@@ -1765,6 +2464,10 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		return nil, err
 	}
 
+	defer rows.Close()
+
+	jobIDs := map[string]int{}
+
 	for rows.Next() {
 		var name string
 		var id int
@@ -1773,32 +2476,44 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			return nil, err
 		}
 
-		db.JobIDs[name] = id
+		jobIDs[name] = id
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT r.name, r.id
+	return jobIDs, nil
+}
+
+func (pdb *pipelineDB) loadResourceIDsAndPinnedVersions() (map[string]int, map[int]int, error) {
+	rows, err := pdb.conn.Query(`
+    SELECT r.name, r.id, r.pinned_version_id
     FROM resources r
     WHERE r.pipeline_id = $1
   `, pdb.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	defer rows.Close()
+
+	resourceIDs := map[string]int{}
+	pinnedVersions := map[int]int{}
+
 	for rows.Next() {
 		var name string
 		var id int
-		err := rows.Scan(&name, &id)
+		var pinnedVersionID sql.NullInt64
+		err := rows.Scan(&name, &id, &pinnedVersionID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		db.ResourceIDs[name] = id
-	}
+		resourceIDs[name] = id
 
-	pdb.versionsDB = db
+		if pinnedVersionID.Valid {
+			pinnedVersions[id] = int(pinnedVersionID.Int64)
+		}
+	}
 
-	return db, nil
+	return resourceIDs, pinnedVersions, nil
 }
 
 func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error) {
@@ -2050,6 +2765,49 @@ func (pdb *pipelineDB) UnpauseJob(job string) error {
 	return pdb.updatePausedJob(job, false)
 }
 
+func (pdb *pipelineDB) GetJobTaskCacheVolumes(job string) ([]SavedVolume, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT
+			v.worker_name,
+			v.ttl,
+			EXTRACT(epoch FROM v.expires_at - NOW()),
+			v.handle,
+			v.resource_version,
+			v.resource_hash,
+			v.id,
+			v.original_volume_handle,
+			v.output_name,
+			v.replicated_from,
+			v.path,
+			v.host_path_version,
+			v.size_in_bytes,
+			c.ttl,
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
+		FROM volumes v `+volumeJoins+`
+		WHERE v.task_cache_pipeline_id = $1
+		AND v.task_cache_job_name = $2
+		ORDER BY v.id ASC
+	`, pdb.ID, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanVolumes(rows)
+}
+
+func (pdb *pipelineDB) ReapTaskCacheVolume(handle string) error {
+	_, err := pdb.conn.Exec(`
+		DELETE FROM volumes
+		WHERE handle = $1
+		AND task_cache_pipeline_id = $2
+	`, handle, pdb.ID)
+	return err
+}
+
 func (pdb *pipelineDB) SetMaxInFlightReached(jobName string, reached bool) error {
 	result, err := pdb.conn.Exec(`
 		UPDATE jobs
@@ -9,6 +9,8 @@ type PipelineDBFactory interface {
 type pipelineDBFactory struct {
 	conn Conn
 	bus  *notificationsBus
+
+	versionsDBCache *versionsDBCache
 }
 
 func NewPipelineDBFactory(
@@ -18,6 +20,8 @@ func NewPipelineDBFactory(
 	return &pipelineDBFactory{
 		conn: sqldbConnection,
 		bus:  bus,
+
+		versionsDBCache: newVersionsDBCache(bus),
 	}
 }
 
@@ -26,6 +30,8 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
+		versionsDBCache: pdbf.versionsDBCache,
+
 		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus),
 
 		SavedPipeline: pipeline,
@@ -45,13 +45,13 @@ var _ = Describe("Jobs Builds", func() {
 			},
 		}
 
-		savedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 		Expect(err).NotTo(HaveOccurred())
 
-		otherSavedPipeline, _, err := teamDB.SaveConfig("another-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+		otherSavedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "another-pipeline"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		otherPipelineDB = pipelineDBFactory.Build(otherSavedPipeline)
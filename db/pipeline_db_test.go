@@ -207,10 +207,10 @@ var _ = Describe("PipelineDB", func() {
 
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
-		savedPipeline, _, err = teamDB.SaveConfig("a-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+		savedPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, pipelineConfig, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		otherSavedPipeline, _, err = teamDB.SaveConfig("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+		otherSavedPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -220,10 +220,10 @@ var _ = Describe("PipelineDB", func() {
 	Describe("destroying a pipeline", func() {
 		It("can be deleted", func() {
 			// populate pipelines table
-			pipelineThatWillBeDeleted, _, err := teamDB.SaveConfig("a-pipeline-that-will-be-deleted", pipelineConfig, 0, db.PipelineUnpaused)
+			pipelineThatWillBeDeleted, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-that-will-be-deleted"}, pipelineConfig, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			fetchedPipeline, err := teamDB.GetPipelineByName("a-pipeline-that-will-be-deleted")
+			fetchedPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "a-pipeline-that-will-be-deleted"})
 			Expect(err).NotTo(HaveOccurred())
 
 			fetchedPipelineDB := pipelineDBFactory.Build(fetchedPipeline)
@@ -371,6 +371,381 @@ var _ = Describe("PipelineDB", func() {
 		})
 	})
 
+	Describe("archiving a pipeline", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.StartTask{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			err := pipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("pauses the pipeline", func() {
+			paused, err := pipelineDB.IsPaused()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paused).To(BeTrue())
+		})
+
+		It("hides the pipeline from the pipeline listings", func() {
+			pipelines, err := teamDB.GetPipelines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].Name).To(Equal("other-pipeline-name"))
+
+			pipelines, err = teamDB.GetAllPipelines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].Name).To(Equal("other-pipeline-name"))
+
+			pipelines, err = sqlDB.GetAllPipelines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].Name).To(Equal("other-pipeline-name"))
+		})
+
+		It("lists the pipeline as archived", func() {
+			pipelines, err := teamDB.GetArchivedPipelines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].Name).To(Equal("a-pipeline-name"))
+			Expect(pipelines[0].Archived).To(BeTrue())
+			Expect(pipelines[0].Paused).To(BeTrue())
+		})
+
+		It("drops the credentials from the config and its previous versions", func() {
+			config, _, found, err := pipelineDB.GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(config.Resources[0].Source).To(BeNil())
+			Expect(config.ResourceTypes[0].Source).To(BeNil())
+			Expect(config.Jobs[0].Plan[0].Params).To(BeNil())
+			Expect(config.Jobs[0].Plan[1].Params).To(BeNil())
+			Expect(config.Jobs[0].Plan[1].Passed).To(Equal([]string{"job-1", "job-2"}))
+
+			configVersions, err := teamDB.GetConfigVersions(atc.PipelineRef{Name: "a-pipeline-name"})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, configVersion := range configVersions {
+				Expect(configVersion.Config.Resources[0].Source).To(BeNil())
+				Expect(configVersion.Config.Jobs[0].Plan[0].Params).To(BeNil())
+			}
+		})
+
+		Context("when steps carry values in their vars, across values, or task args", func() {
+			BeforeEach(func() {
+				_, _, version, err := teamDB.GetConfig(atc.PipelineRef{Name: "a-pipeline-name"})
+				Expect(err).NotTo(HaveOccurred())
+
+				config := pipelineConfig
+				config.Jobs = append(atc.JobConfigs{}, pipelineConfig.Jobs...)
+				config.Jobs[0].Plan = atc.PlanSequence{
+					{
+						SetPipeline:    "some-child",
+						TaskConfigPath: "some/pipeline.yml",
+						Vars:           map[string]interface{}{"password": "some-secret"},
+					},
+					{
+						Task: "some-task",
+						TaskConfig: &atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "some-script",
+								Args: []string{"--password", "some-secret"},
+							},
+						},
+						Across: []atc.AcrossVarConfig{
+							{Var: "password", Values: []interface{}{"some-secret"}},
+						},
+					},
+				}
+
+				_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, config, "", nil, version, db.PipelineNoChange, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("drops them from the config", func() {
+				config, _, found, err := pipelineDB.GetConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(config.Jobs[0].Plan[0].SetPipeline).To(Equal("some-child"))
+				Expect(config.Jobs[0].Plan[0].Vars).To(BeNil())
+				Expect(config.Jobs[0].Plan[1].TaskConfig.Run.Path).To(Equal("some-script"))
+				Expect(config.Jobs[0].Plan[1].TaskConfig.Run.Args).To(BeNil())
+				Expect(config.Jobs[0].Plan[1].Across).To(Equal([]atc.AcrossVarConfig{{Var: "password"}}))
+			})
+		})
+
+		Context("when the pipeline has containers", func() {
+			var finishedBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				finishedBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = finishedBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := build.Start("engine", "metadata")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				savedResource, _, err := pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				for handle, buildID := range map[string]int{
+					"running-build-handle":  build.ID(),
+					"finished-build-handle": finishedBuild.ID(),
+				} {
+					_, err = sqlDB.CreateContainer(db.Container{
+						ContainerIdentifier: db.ContainerIdentifier{
+							BuildID: buildID,
+							PlanID:  "some-plan-id",
+							Stage:   db.ContainerStageRun,
+						},
+						ContainerMetadata: db.ContainerMetadata{
+							Handle:     handle,
+							PipelineID: pipelineDB.GetPipelineID(),
+							JobName:    "some-job",
+							Type:       db.ContainerTypeTask,
+							TeamID:     savedPipeline.TeamID,
+						},
+					}, 5*time.Minute, 0, []string{})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				_, err = sqlDB.CreateContainer(db.Container{
+					ContainerIdentifier: db.ContainerIdentifier{
+						ResourceID:  savedResource.ID,
+						CheckType:   "some-type",
+						CheckSource: atc.Source{"some": "source"},
+						Stage:       db.ContainerStageRun,
+					},
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "check-handle",
+						PipelineID: pipelineDB.GetPipelineID(),
+						Type:       db.ContainerTypeCheck,
+						TeamID:     savedPipeline.TeamID,
+					},
+				}, 5*time.Minute, 0, []string{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("expires the check containers and the containers of finished builds", func() {
+				_, found, err := sqlDB.GetContainer("check-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				_, found, err = sqlDB.GetContainer("finished-build-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("keeps the containers of builds which are still running", func() {
+				_, found, err := sqlDB.GetContainer("running-build-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		It("keeps the builds and their events", func() {
+			foundBuild, found, err := teamDB.GetBuild(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundBuild.JobName()).To(Equal("some-job"))
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer events.Close()
+
+			Expect(events.Next()).To(Equal(envelope(event.StartTask{})))
+		})
+
+		Context("when the config is saved again", func() {
+			JustBeforeEach(func() {
+				_, _, version, err := teamDB.GetConfig(atc.PipelineRef{Name: "a-pipeline-name"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, pipelineConfig, "", nil, version, db.PipelineNoChange, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("unarchives the pipeline, leaving it paused", func() {
+				pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "a-pipeline-name"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipeline.Archived).To(BeFalse())
+				Expect(pipeline.Paused).To(BeTrue())
+				Expect(pipeline.Config.Resources[0].Source).To(Equal(atc.Source{"source-config": "some-value"}))
+
+				pipelines, err := teamDB.GetPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipelines).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("LoadVersionsDB with passed constraints on jobs in other pipelines", func() {
+		var (
+			upstreamPipelineDB   db.PipelineDB
+			downstreamPipelineDB db.PipelineDB
+		)
+
+		BeforeEach(func() {
+			sharedSource := atc.Source{"uri": "some-uri"}
+
+			upstreamPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "upstream"}, atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-repo", Type: "git", Source: sharedSource},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "integration",
+						Plan: atc.PlanSequence{{Get: "some-repo"}},
+					},
+				},
+			}, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			downstreamPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "downstream"}, atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "repo", Type: "git", Source: sharedSource},
+					{Name: "unshared-repo", Type: "git", Source: atc.Source{"uri": "other-uri"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "release",
+						Plan: atc.PlanSequence{
+							{Get: "repo", Passed: []string{"upstream/integration"}},
+							{Get: "unshared-repo", Passed: []string{"upstream/integration"}},
+						},
+					},
+				},
+			}, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			upstreamPipelineDB = pipelineDBFactory.Build(upstreamPipeline)
+			downstreamPipelineDB = pipelineDBFactory.Build(downstreamPipeline)
+		})
+
+		It("loads outputs of the other pipeline's job as versions of the shared resources", func() {
+			upstreamJob, err := upstreamPipelineDB.GetJob("integration")
+			Expect(err).NotTo(HaveOccurred())
+
+			repo, _, err := downstreamPipelineDB.GetResource("repo")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, pdb := range []db.PipelineDB{upstreamPipelineDB, downstreamPipelineDB} {
+				for _, resourceName := range []string{"some-repo", "repo", "unshared-repo"} {
+					err := pdb.SaveResourceVersions(atc.ResourceConfig{
+						Name: resourceName,
+						Type: "git",
+					}, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+
+			upstreamVersion, found, err := upstreamPipelineDB.GetVersionedResourceByVersion(atc.Version{"ref": "v1"}, "some-repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			downstreamVersion, found, err := downstreamPipelineDB.GetVersionedResourceByVersion(atc.Version{"ref": "v1"}, "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versions, err := downstreamPipelineDB.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.JobIDs).To(HaveKeyWithValue("upstream/integration", upstreamJob.ID))
+			Expect(versions.BuildOutputs).To(BeEmpty())
+
+			By("including succeeded builds of the other pipeline's job")
+			build, err := upstreamPipelineDB.CreateJobBuild("integration")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = upstreamPipelineDB.SaveOutput(build.ID(), upstreamVersion.VersionedResource, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err = downstreamPipelineDB.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+				ResourceVersion: algorithm.ResourceVersion{
+					VersionID:  downstreamVersion.ID,
+					ResourceID: repo.ID,
+					CheckOrder: downstreamVersion.CheckOrder,
+				},
+				JobID:   upstreamJob.ID,
+				BuildID: build.ID(),
+			}))
+		})
+
+		It("ignores references to pipelines which do not exist", func() {
+			lonelyPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "lonely"}, atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "repo", Type: "git"},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "release",
+						Plan: atc.PlanSequence{
+							{Get: "repo", Passed: []string{"bogus/integration"}},
+						},
+					},
+				},
+			}, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := pipelineDBFactory.Build(lonelyPipeline).LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.JobIDs).NotTo(HaveKey("bogus/integration"))
+		})
+
+		It("ignores archived pipelines", func() {
+			err := upstreamPipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := downstreamPipelineDB.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.JobIDs).NotTo(HaveKey("upstream/integration"))
+		})
+
+		It("only refers to the pipeline without instance vars", func() {
+			upstreamJob, err := upstreamPipelineDB.GetJob("integration")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{
+				Name:         "upstream",
+				InstanceVars: atc.InstanceVars{"branch": "feature"},
+			}, atc.Config{
+				Jobs: atc.JobConfigs{{Name: "integration"}},
+			}, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := downstreamPipelineDB.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.JobIDs).To(HaveKeyWithValue("upstream/integration", upstreamJob.ID))
+
+			By("ignoring instances when there is no such pipeline")
+			err = upstreamPipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err = downstreamPipelineDB.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.JobIDs).NotTo(HaveKey("upstream/integration"))
+		})
+	})
+
 	Describe("pausing and unpausing a pipeline", func() {
 		It("starts out as unpaused", func() {
 			Expect(savedPipeline.Paused).To(BeFalse())
@@ -420,7 +795,7 @@ var _ = Describe("PipelineDB", func() {
 			err := pipelineDB.UpdateName("some-other-weird-name")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName("some-other-weird-name")
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "some-other-weird-name"})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(pipeline.Name).To(Equal("some-other-weird-name"))
@@ -436,7 +811,7 @@ var _ = Describe("PipelineDB", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				team2DB = teamDBFactory.GetTeamDB(team2.Name)
-				_, _, err = team2DB.SaveConfig("a-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+				_, _, err = team2DB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, pipelineConfig, "", nil, 0, db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -444,7 +819,7 @@ var _ = Describe("PipelineDB", func() {
 				err := pipelineDB.UpdateName("some-other-weird-name")
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = team2DB.GetPipelineByName("a-pipeline-name")
+				_, err = team2DB.GetPipelineByRef(atc.PipelineRef{Name: "a-pipeline-name"})
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -509,9 +884,9 @@ var _ = Describe("PipelineDB", func() {
 			})
 
 			By("being able to update the config with a valid config")
-			_, _, err = teamDB.SaveConfig("a-pipeline-name", updatedConfig, configVersion, db.PipelineUnpaused)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, updatedConfig, "", nil, configVersion, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
-			_, _, err = teamDB.SaveConfig("other-pipeline-name", updatedConfig, otherConfigVersion, db.PipelineUnpaused)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "other-pipeline-name"}, updatedConfig, "", nil, otherConfigVersion, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			By("returning the updated config")
@@ -1021,6 +1396,27 @@ var _ = Describe("PipelineDB", func() {
 						InputName: "enabled-input",
 					},
 				))
+
+				By("keeping it and its build outputs as disabled")
+				Expect(versions.DisabledVersions).To(ConsistOf(
+					algorithm.ResourceVersion{
+						VersionID:  disabledVersion.ID,
+						ResourceID: resource.ID,
+						CheckOrder: disabledVersion.CheckOrder,
+					},
+				))
+
+				Expect(versions.DisabledBuildOutputs).To(ConsistOf(
+					algorithm.BuildOutput{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  disabledVersion.ID,
+							ResourceID: resource.ID,
+							CheckOrder: disabledVersion.CheckOrder,
+						},
+						JobID:   aJob.ID,
+						BuildID: build1.ID(),
+					},
+				))
 			})
 		})
 
@@ -1180,6 +1576,39 @@ var _ = Describe("PipelineDB", func() {
 			Expect(savedVR3.Version).To(Equal(db.Version{"version": "1"}))
 		})
 
+		It("can load up the disabled versions of a resource", func() {
+			By("initially having no disabled versions")
+			disabledVersions, err := pipelineDB.GetDisabledVersions(resource.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disabledVersions).To(BeEmpty())
+
+			err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+				Name:   resource.Name,
+				Type:   "some-type",
+				Source: atc.Source{"some": "source"},
+			}, []atc.Version{{"version": "1"}, {"version": "2"}, {"version": "3"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, version := range []atc.Version{{"version": "3"}, {"version": "1"}} {
+				savedVR, found, err := pipelineDB.GetVersionedResourceByVersion(version, resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = pipelineDB.DisableVersionedResource(savedVR.ID)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("returning them in check order")
+			disabledVersions, err = pipelineDB.GetDisabledVersions(resource.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disabledVersions).To(Equal([]db.Version{{"version": "1"}, {"version": "3"}}))
+
+			By("not including disabled versions of other pipelines")
+			disabledVersions, err = otherPipelineDB.GetDisabledVersions(resource.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disabledVersions).To(BeEmpty())
+		})
+
 		It("can load up the latest versioned resource, enabled or not", func() {
 			By("initially having no latest versioned resource")
 			_, found, err := pipelineDB.GetLatestVersionedResource(resource.Name)
@@ -1452,6 +1881,107 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning and unpinning versioned resources", func() {
+			var savedVR1, savedVR2 db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				savedVR1, found, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				savedVR2, found, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "2"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("returns ErrVersionedResourceNotFound if the version is bogus", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", 42, "some-comment", "some-user")
+				Expect(err).To(Equal(db.ErrVersionedResourceNotFound))
+
+				err = pipelineDB.UnpinVersionedResource("some-resource", 42)
+				Expect(err).To(Equal(db.ErrVersionedResourceNotFound))
+			})
+
+			It("returns ErrVersionedResourceNotFound if the version belongs to another resource", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", savedVR1.ID, "some-comment", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.PinVersionedResource("some-other-resource", savedVR2.ID, "some-comment", "some-user")
+				Expect(err).To(Equal(db.ErrVersionedResourceNotFound))
+
+				err = pipelineDB.UnpinVersionedResource("some-other-resource", savedVR1.ID)
+				Expect(err).To(Equal(db.ErrVersionedResourceNotFound))
+
+				savedResource, found, err := pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedResource.PinnedVersionID).To(Equal(savedVR1.ID))
+			})
+
+			It("returns an error when unpinning a version the resource is not pinned to", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", savedVR1.ID, "some-comment", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.UnpinVersionedResource("some-resource", savedVR2.ID)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("pins the resource to the version with a comment and who pinned it, until unpinned", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", savedVR1.ID, "some-comment", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				savedResource, found, err := pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedResource.PinnedVersionID).To(Equal(savedVR1.ID))
+				Expect(savedResource.PinnedVersion).To(Equal(db.Version{"version": "1"}))
+				Expect(savedResource.PinComment).To(Equal("some-comment"))
+				Expect(savedResource.PinnedBy).To(Equal("some-user"))
+
+				versionsDB, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersions).To(Equal(map[int]int{savedResource.ID: savedVR1.ID}))
+
+				By("repinning to another version")
+				err = pipelineDB.PinVersionedResource("some-resource", savedVR2.ID, "some-other-comment", "some-other-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				savedResource, _, err = pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedResource.PinnedVersionID).To(Equal(savedVR2.ID))
+				Expect(savedResource.PinComment).To(Equal("some-other-comment"))
+
+				repinnedVersionsDB, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(repinnedVersionsDB != versionsDB).To(BeTrue(), "Expected VersionsDB to be reloaded")
+				Expect(repinnedVersionsDB.PinnedVersions).To(Equal(map[int]int{savedResource.ID: savedVR2.ID}))
+
+				By("unpinning")
+				err = pipelineDB.UnpinVersionedResource("some-resource", savedVR2.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedResource, _, err = pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedResource.PinnedVersionID).To(BeZero())
+				Expect(savedResource.PinnedVersion).To(BeNil())
+				Expect(savedResource.PinComment).To(BeEmpty())
+				Expect(savedResource.PinnedBy).To(BeEmpty())
+
+				unpinnedVersionsDB, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedVersionsDB != repinnedVersionsDB).To(BeTrue(), "Expected VersionsDB to be reloaded")
+				Expect(unpinnedVersionsDB.PinnedVersions).To(BeEmpty())
+			})
+		})
+
 		Describe("VersionsDB caching", func() {
 			Context("when build outputs are added", func() {
 				var build db.Build
@@ -1598,6 +2128,127 @@ var _ = Describe("PipelineDB", func() {
 					})
 				})
 			})
+
+			Context("when a build whose outputs were already loaded succeeds", func() {
+				It("adds the outputs to the cached VersionsDB", func() {
+					build, err := pipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, err := pipelineDB.SaveOutput(build.ID(), db.VersionedResource{
+						Resource: "some-resource",
+						Type:     "some-type",
+						Version:  db.Version{"version": "1"},
+					}, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.BuildOutputs).To(BeEmpty())
+
+					err = build.Finish(db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					updatedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updatedVersionsDB != versionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
+					Expect(updatedVersionsDB.BuildOutputs).To(HaveLen(1))
+					Expect(updatedVersionsDB.BuildOutputs[0].VersionID).To(Equal(savedVR.ID))
+					Expect(updatedVersionsDB.BuildOutputs[0].BuildID).To(Equal(build.ID()))
+				})
+			})
+
+			Context("when versions are added after the VersionsDB was loaded", func() {
+				It("does not share the updated VersionsDB's slices with the previous one", func() {
+					resourceConfig := atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}
+
+					err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}, {"version": "2"}, {"version": "3"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(3))
+
+					err = pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "4"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					updatedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updatedVersionsDB.ResourceVersions).To(HaveLen(4))
+
+					added := updatedVersionsDB.ResourceVersions[3]
+
+					versionsDB.ResourceVersions = append(versionsDB.ResourceVersions, algorithm.ResourceVersion{VersionID: -1})
+					Expect(updatedVersionsDB.ResourceVersions[3]).To(Equal(added))
+				})
+			})
+
+			Context("when a version is disabled", func() {
+				It("reloads the VersionsDB without the version", func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "1"}, {"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(2))
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = pipelineDB.DisableVersionedResource(savedVR.ID)
+					Expect(err).NotTo(HaveOccurred())
+
+					reloadedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(reloadedVersionsDB.ResourceVersions).To(HaveLen(1))
+					Expect(reloadedVersionsDB.ResourceVersions[0].VersionID).NotTo(Equal(savedVR.ID))
+				})
+			})
+
+			Context("when an older version is saved again", func() {
+				It("reloads the VersionsDB with the new check order", func() {
+					resourceConfig := atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}
+
+					err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}, {"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					reloadedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(reloadedVersionsDB != versionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(savedVR.Version).To(Equal(db.Version{"version": "1"}))
+
+					latest := reloadedVersionsDB.ResourceVersions[0]
+					for _, version := range reloadedVersionsDB.ResourceVersions {
+						if version.CheckOrder > latest.CheckOrder {
+							latest = version
+						}
+					}
+
+					Expect(latest.VersionID).To(Equal(savedVR.ID))
+				})
+			})
 		})
 
 		Describe("saving versioned resources", func() {
@@ -1831,7 +2482,7 @@ var _ = Describe("PipelineDB", func() {
 					pipelineConfig.Resources[2],
 				}
 
-				_, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfigMinusResource, 1, db.PipelineNoChange)
+				_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "a-pipeline-name"}, pipelineConfigMinusResource, "", nil, 1, db.PipelineNoChange, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -2131,6 +2782,49 @@ var _ = Describe("PipelineDB", func() {
 				Expect(build.Status()).To(Equal(db.StatusPending))
 				Expect(build.IsScheduled()).To(BeFalse())
 				Expect(build.TeamName()).To(Equal("some-team"))
+				Expect(build.IsManuallyPinned()).To(BeFalse())
+			})
+		})
+
+		Describe("CreateManualJobBuild", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(
+					atc.ResourceConfig{Name: "some-resource", Type: "some-type"},
+					[]atc.Version{{"ver": "1"}, {"ver": "2"}},
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVersion, found, err := pipelineDB.GetVersionedResourceByVersion(atc.Version{"ver": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = pipelineDB.CreateManualJobBuild("some-job", algorithm.InputMapping{
+					"some-input": algorithm.InputVersion{VersionID: savedVersion.ID, FirstOccurrence: true},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a pending build marked as manually pinned", func() {
+				Expect(build.JobName()).To(Equal("some-job"))
+				Expect(build.Status()).To(Equal(db.StatusPending))
+				Expect(build.IsManuallyPinned()).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsManuallyPinned()).To(BeTrue())
+			})
+
+			It("records the chosen versions as the build's inputs", func() {
+				inputs, _, err := build.GetResources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(HaveLen(1))
+				Expect(inputs[0].Name).To(Equal("some-input"))
+				Expect(inputs[0].Resource).To(Equal("some-resource"))
+				Expect(inputs[0].Version).To(Equal(db.Version{"ver": "1"}))
+				Expect(inputs[0].FirstOccurrence).To(BeTrue())
 			})
 		})
 
@@ -2536,6 +3230,49 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("GetRunningJobBuilds", func() {
+			var startedBuild, scheduledBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				_, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				startedBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = startedBuild.Start("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduledBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduled, err := pipelineDB.UpdateBuildToScheduled(scheduledBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				finishedBuild, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				err = finishedBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				otherJobBuild, err := pipelineDB.CreateJobBuild("other-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = otherJobBuild.Start("", "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the running or scheduled builds of only that job", func() {
+				builds, err := pipelineDB.GetRunningJobBuilds("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				ids := []int{}
+				for _, build := range builds {
+					ids = append(ids, build.ID())
+				}
+				Expect(ids).To(ConsistOf(startedBuild.ID(), scheduledBuild.ID()))
+			})
+		})
+
 		Describe("GetRunningBuildsBySerialGroup", func() {
 			Describe("same job", func() {
 				var startedBuild, scheduledBuild db.Build
@@ -2617,6 +3354,66 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("GetSerialGroupQueues", func() {
+			var (
+				firstBuild  db.Build
+				secondBuild db.Build
+				thirdBuild  db.Build
+			)
+
+			BeforeEach(func() {
+				var err error
+				startedBuild, err := pipelineDB.CreateJobBuild("other-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = startedBuild.Start("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				firstBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				secondBuild, err = pipelineDB.CreateJobBuild("other-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				thirdBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.CreateJobBuild("different-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, jobName := range []string{"some-job", "other-serial-group-job", "different-serial-group-job"} {
+					err = pipelineDB.SaveNextInputMapping(nil, jobName)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				// populate jobs_serial_groups table
+				_, err = pipelineDB.GetSerialGroupQueues("other-serial-group-job", []string{"serial-group", "really-different-group"})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pipelineDB.GetSerialGroupQueues("different-serial-group-job", []string{"different-serial-group"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the running count and pending builds in line for each serial group", func() {
+				queues, err := pipelineDB.GetSerialGroupQueues("other-serial-group-job", []string{"serial-group", "really-different-group"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(queues).To(Equal(map[string]db.SerialGroupQueue{
+					"serial-group": {
+						Running: 1,
+						Pending: []int{firstBuild.ID(), secondBuild.ID(), thirdBuild.ID()},
+					},
+					"really-different-group": {
+						Running: 1,
+						Pending: []int{secondBuild.ID()},
+					},
+				}))
+			})
+
+			It("returns empty queues for serial groups with no builds", func() {
+				queues, err := pipelineDB.GetSerialGroupQueues("some-job", []string{"serial-group", "unused-group"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(queues["unused-group"]).To(Equal(db.SerialGroupQueue{Pending: []int{}}))
+			})
+		})
+
 		Context("when a build is created for a job", func() {
 			var build1DB db.Build
 			var jobConfig atc.JobConfig
@@ -15,6 +15,12 @@ type SavedResource struct {
 	CheckError   error
 	Paused       bool
 	PipelineName string
+
+	PinnedVersionID int
+	PinnedVersion   Version
+	PinComment      string
+	PinnedBy        string
+
 	Resource
 }
 
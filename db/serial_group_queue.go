@@ -0,0 +1,21 @@
+package db
+
+// SerialGroupQueue describes the builds competing for a serial group: how
+// many are currently running, and the IDs of the pending builds waiting to
+// run, in the order they will be started.
+type SerialGroupQueue struct {
+	Running int
+	Pending []int
+}
+
+// Position returns the zero-based position of the build in the queue of
+// pending builds, or -1 if it is not waiting in this serial group.
+func (queue SerialGroupQueue) Position(buildID int) int {
+	for i, id := range queue.Pending {
+		if id == buildID {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -97,6 +97,35 @@ func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	return err
 }
 
+func (db *SQLDB) GetUnreapedSucceededBuildIDs(jobID int) ([]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT id
+		FROM builds
+		WHERE job_id = $1
+		AND status = 'succeeded'
+		AND reap_time IS NULL
+		ORDER BY id DESC
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildIDs := []int{}
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDs = append(buildIDs, id)
+	}
+
+	return buildIDs, nil
+}
+
 func (db *SQLDB) FindLatestSuccessfulBuildsPerJob() (map[int]int, error) {
 	rows, err := db.conn.Query(
 		`SELECT max(id), job_id
@@ -11,7 +11,7 @@ import (
 	"github.com/concourse/atc"
 )
 
-const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version, c.team_id"
+const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version, c.team_id, c.cpu_limit, c.memory_limit"
 
 const containerJoins = `
 		LEFT JOIN pipelines p
@@ -286,6 +286,18 @@ func (db *SQLDB) CreateContainer(
 		imageResourceType.Valid = true
 	}
 
+	var cpuLimit sql.NullInt64
+	if container.CPULimit != 0 {
+		cpuLimit.Int64 = int64(container.CPULimit)
+		cpuLimit.Valid = true
+	}
+
+	var memoryLimit sql.NullInt64
+	if container.MemoryLimit != 0 {
+		memoryLimit.Int64 = int64(container.MemoryLimit)
+		memoryLimit.Valid = true
+	}
+
 	maxLifetimeValue := "NULL"
 	if maxLifetime > 0 {
 		maxLifetimeValue = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(maxLifetime.Seconds()))
@@ -295,8 +307,8 @@ func (db *SQLDB) CreateContainer(
 		INSERT INTO containers (handle, resource_id, step_name, pipeline_id, build_id, type, worker_name,
 			expires_at, ttl, best_if_used_by, check_type, check_source, plan_id, working_directory,
 			env_variables, attempts, stage, image_resource_type, image_resource_source,
-			process_user, resource_type_version, team_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			process_user, resource_type_version, team_id, cpu_limit, memory_limit)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 		RETURNING id`,
 		container.Handle,
 		resourceID,
@@ -319,6 +331,8 @@ func (db *SQLDB) CreateContainer(
 		user,
 		resourceTypeVersion,
 		container.TeamID,
+		cpuLimit,
+		memoryLimit,
 	).Scan(&id)
 	if err != nil {
 		return SavedContainer{}, err
@@ -485,6 +499,8 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		attempts            sql.NullString
 		ttlInSeconds        *float64
 		resourceTypeVersion []byte
+		cpuLimit            sql.NullInt64
+		memoryLimit         sql.NullInt64
 	)
 	container := SavedContainer{}
 
@@ -513,6 +529,8 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		&container.ID,
 		&resourceTypeVersion,
 		&teamID,
+		&cpuLimit,
+		&memoryLimit,
 	)
 
 	if err != nil {
@@ -531,6 +549,14 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		container.TeamID = int(teamID.Int64)
 	}
 
+	if cpuLimit.Valid {
+		container.CPULimit = uint64(cpuLimit.Int64)
+	}
+
+	if memoryLimit.Valid {
+		container.MemoryLimit = uint64(memoryLimit.Int64)
+	}
+
 	container.PlanID = atc.PlanID(planID.String)
 
 	container.Stage = ContainerStage(stage)
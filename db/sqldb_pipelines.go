@@ -6,8 +6,8 @@ type PipelinesDB interface {
 	GetAllPublicPipelines() ([]SavedPipeline, error)
 }
 
-const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, t.name as team_name"
-const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public"
+const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, p.parent_build_id, p.archived, p.instance_vars, t.name as team_name"
+const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public, parent_build_id, archived, instance_vars"
 
 func (db *SQLDB) GetAllPublicPipelines() ([]SavedPipeline, error) {
 	rows, err := db.conn.Query(`
@@ -15,6 +15,7 @@ func (db *SQLDB) GetAllPublicPipelines() ([]SavedPipeline, error) {
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
 		WHERE p.public = true
+		AND p.archived = false
 		ORDER BY team_name, ordering
 	`)
 	if err != nil {
@@ -31,6 +32,7 @@ func (db *SQLDB) GetAllPipelines() ([]SavedPipeline, error) {
 		SELECT ` + pipelineColumns + `
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
+		WHERE p.archived = false
 		ORDER BY ordering
 	`)
 	if err != nil {
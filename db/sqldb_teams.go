@@ -9,7 +9,7 @@ import (
 
 func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth FROM teams
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, container_limits FROM teams
 	`)
 	if err != nil {
 		return nil, err
@@ -73,18 +73,23 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 	if err != nil {
 		return SavedTeam{}, err
 	}
+
+	jsonEncodedContainerLimits, err := json.Marshal(team.ContainerLimits)
+	if err != nil {
+		return SavedTeam{}, err
+	}
 	return scanTeam(db.conn.QueryRow(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth, uaa_auth
+    name, basic_auth, github_auth, uaa_auth, container_limits
 	) VALUES (
-		$1, $2, $3, $4
+		$1, $2, $3, $4, $5
 	)
-	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth
-	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth)))
+	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, container_limits
+	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedContainerLimits)))
 }
 
 func scanTeam(rows scannable) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, containerLimits sql.NullString
 	var savedTeam SavedTeam
 
 	err := rows.Scan(
@@ -94,6 +99,7 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		&basicAuth,
 		&gitHubAuth,
 		&uaaAuth,
+		&containerLimits,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -120,6 +126,13 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		}
 	}
 
+	if containerLimits.Valid {
+		err = json.Unmarshal([]byte(containerLimits.String), &savedTeam.ContainerLimits)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -79,6 +79,22 @@ func (db *SQLDB) InsertVolume(data Volume) error {
 		columns = append(columns, "replicated_from")
 		params = append(params, data.Identifier.Replication.ReplicatedVolumeHandle)
 		values = append(values, fmt.Sprintf("$%d", len(params)))
+	case data.Identifier.TaskCache != nil:
+		columns = append(columns, "task_cache_pipeline_id")
+		params = append(params, data.Identifier.TaskCache.PipelineID)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_job_name")
+		params = append(params, data.Identifier.TaskCache.JobName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_step_name")
+		params = append(params, data.Identifier.TaskCache.StepName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_path")
+		params = append(params, data.Identifier.TaskCache.Path)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
 	}
 
 	_, err = tx.Exec(
@@ -112,6 +128,38 @@ func (db *SQLDB) ReapVolume(handle string) error {
 	return err
 }
 
+// SetTaskCacheVolume makes the volume the newest cache identified by id, in a
+// single update, so that the cache is never seen partially written. The cache
+// lives as long as its job, so the volume's TTL is cleared.
+func (db *SQLDB) SetTaskCacheVolume(handle string, id TaskCacheIdentifier) error {
+	result, err := db.conn.Exec(`
+		UPDATE volumes
+		SET output_name = NULL,
+			task_cache_pipeline_id = $2,
+			task_cache_job_name = $3,
+			task_cache_step_name = $4,
+			task_cache_path = $5,
+			expires_at = NULL,
+			ttl = 0
+		WHERE handle = $1
+		AND worker_name = $6
+	`, handle, id.PipelineID, id.JobName, id.StepName, id.Path, id.WorkerName)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
 func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 	err := db.expireVolumes()
 	if err != nil {
@@ -134,7 +182,11 @@ func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
 		FROM volumes v
 		` + volumeJoins)
 	if err != nil {
@@ -179,6 +231,12 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 		}
 	case id.Replication != nil:
 		addParam("replicated_from", id.Replication.ReplicatedVolumeHandle)
+	case id.TaskCache != nil:
+		addParam("task_cache_pipeline_id", id.TaskCache.PipelineID)
+		addParam("task_cache_job_name", id.TaskCache.JobName)
+		addParam("task_cache_step_name", id.TaskCache.StepName)
+		addParam("task_cache_path", id.TaskCache.Path)
+		addParam("worker_name", id.TaskCache.WorkerName)
 	}
 
 	statement := `
@@ -197,7 +255,11 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
 		FROM volumes v` + volumeJoins
 
 	statement += "WHERE " + strings.Join(conditions, " AND ")
@@ -240,7 +302,11 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
 		FROM volumes v ` + volumeJoins + `
 			INNER JOIN image_resource_versions i
 				ON i.version = v.resource_version
@@ -333,6 +399,10 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			path                 sql.NullString
 			hostPathVersion      sql.NullString
 			teamID               sql.NullInt64
+			taskCachePipelineID  sql.NullInt64
+			taskCacheJobName     sql.NullString
+			taskCacheStepName    sql.NullString
+			taskCachePath        sql.NullString
 		)
 
 		err := rows.Scan(
@@ -351,6 +421,10 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			&volume.SizeInBytes,
 			&volume.ContainerTTL,
 			&teamID,
+			&taskCachePipelineID,
+			&taskCacheJobName,
+			&taskCacheStepName,
+			&taskCachePath,
 		)
 		if err != nil {
 			return []SavedVolume{}, err
@@ -388,6 +462,14 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			volume.Volume.Identifier.Replication = &ReplicationIdentifier{
 				ReplicatedVolumeHandle: replicationName.String,
 			}
+		case taskCachePath.Valid:
+			volume.Volume.Identifier.TaskCache = &TaskCacheIdentifier{
+				WorkerName: volume.WorkerName,
+				PipelineID: int(taskCachePipelineID.Int64),
+				JobName:    taskCacheJobName.String,
+				StepName:   taskCacheStepName.String,
+				Path:       taskCachePath.String,
+			}
 		case path.Valid:
 			volume.Volume.Identifier.Import = &ImportIdentifier{
 				Path:       path.String,
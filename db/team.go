@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 
+	"github.com/concourse/atc"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -13,6 +14,8 @@ type Team struct {
 	BasicAuth  *BasicAuth  `json:"basic_auth"`
 	GitHubAuth *GitHubAuth `json:"github_auth"`
 	UAAAuth    *UAAAuth    `json:"uaa_auth"`
+
+	ContainerLimits *atc.ContainerLimits `json:"container_limits"`
 }
 
 type BasicAuth struct {
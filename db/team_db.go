@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
 )
 
 //go:generate counterfeiter . TeamDB
@@ -13,7 +14,8 @@ import (
 type TeamDB interface {
 	HasTeamName() bool
 	GetPipelines() ([]SavedPipeline, error)
-	GetPipelineByName(pipelineName string) (SavedPipeline, error)
+	GetArchivedPipelines() ([]SavedPipeline, error)
+	GetPipelineByRef(pipelineRef atc.PipelineRef) (SavedPipeline, error)
 	GetAllPipelines() ([]SavedPipeline, error)
 
 	OrderPipelines([]string) error
@@ -22,9 +24,16 @@ type TeamDB interface {
 	UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error)
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
+	UpdateContainerLimits(containerLimits *atc.ContainerLimits) (SavedTeam, error)
 
-	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
-	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	GetConfig(pipelineRef atc.PipelineRef) (atc.Config, atc.RawConfig, ConfigVersion, error)
+	GetConfigTemplate(pipelineRef atc.PipelineRef) (atc.RawConfig, template.Variables, ConfigVersion, error)
+	SaveConfig(atc.PipelineRef, atc.Config, atc.RawConfig, template.Variables, ConfigVersion, PipelinePausedState, string) (SavedPipeline, bool, error)
+	SaveConfigFromBuild(atc.PipelineRef, atc.Config, atc.RawConfig, template.Variables, ConfigVersion, string, int) (SavedPipeline, bool, error)
+	ImportPipelines(pipelines []atc.ExportedPipeline, savedBy string, pinnedBy string) error
+
+	GetConfigVersions(pipelineRef atc.PipelineRef) ([]PipelineConfigVersion, error)
+	GetConfigVersion(pipelineRef atc.PipelineRef, version int) (PipelineConfigVersion, bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	GetBuilds(page Page, publicOnly bool) ([]Build, Pagination, error)
@@ -48,16 +57,22 @@ func (db *teamDB) HasTeamName() bool {
 	return db.teamName != ""
 }
 
-func (db *teamDB) GetPipelineByName(pipelineName string) (SavedPipeline, error) {
+func (db *teamDB) GetPipelineByRef(pipelineRef atc.PipelineRef) (SavedPipeline, error) {
+	instanceVars, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
 	row := db.conn.QueryRow(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
 		WHERE p.name = $1
+		AND p.instance_vars IS NOT DISTINCT FROM $2
 		AND p.team_id = (
-			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($3)
 		)
-	`, pipelineName, db.teamName)
+	`, pipelineRef.Name, instanceVars, db.teamName)
 
 	return scanPipeline(row)
 }
@@ -70,7 +85,28 @@ func (db *teamDB) GetPipelines() ([]SavedPipeline, error) {
 		WHERE team_id = (
 			SELECT id FROM teams WHERE LOWER(name) = LOWER($1)
 		)
-		ORDER BY ordering
+		AND archived = false
+		ORDER BY ordering, p.id
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanPipelines(rows)
+}
+
+func (db *teamDB) GetArchivedPipelines() ([]SavedPipeline, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+pipelineColumns+`
+		FROM pipelines p
+		INNER JOIN teams t ON t.id = p.team_id
+		WHERE team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($1)
+		)
+		AND archived = true
+		ORDER BY ordering, p.id
 	`, db.teamName)
 	if err != nil {
 		return nil, err
@@ -87,7 +123,8 @@ func (db *teamDB) GetAllPipelines() ([]SavedPipeline, error) {
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
 		WHERE team_id = (SELECT id FROM teams WHERE LOWER(name) = LOWER($1))
-		ORDER BY ordering
+		AND archived = false
+		ORDER BY ordering, p.id
 	`, db.teamName)
 	if err != nil {
 		return nil, err
@@ -106,6 +143,7 @@ func (db *teamDB) GetAllPipelines() ([]SavedPipeline, error) {
 		INNER JOIN teams t ON t.id = p.team_id
 		WHERE team_id != (SELECT id FROM teams WHERE LOWER(name) = LOWER($1))
 		AND public = true
+		AND archived = false
 		ORDER BY team_name, ordering
 	`, db.teamName)
 	if err != nil {
@@ -130,15 +168,23 @@ func (db *teamDB) OrderPipelines(pipelineNames []string) error {
 
 	defer tx.Rollback()
 
-	var pipelineCount int
-
 	var teamID int
 	err = tx.QueryRow(`SELECT id FROM teams WHERE LOWER(name) = LOWER($1)`, db.teamName).Scan(&teamID)
 	if err != nil {
 		return err
 	}
 
-	err = tx.QueryRow(`
+	err = db.orderPipelines(tx, teamID, pipelineNames)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *teamDB) orderPipelines(tx Tx, teamID int, pipelineNames []string) error {
+	var pipelineCount int
+	err := tx.QueryRow(`
 		SELECT COUNT(1)
 		FROM pipelines
 		WHERE team_id = $1
@@ -171,21 +217,26 @@ func (db *teamDB) OrderPipelines(pipelineNames []string) error {
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error) {
+func (db *teamDB) GetConfig(pipelineRef atc.PipelineRef) (atc.Config, atc.RawConfig, ConfigVersion, error) {
+	instanceVars, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return atc.Config{}, atc.RawConfig(""), 0, err
+	}
+
 	var configBlob []byte
 	var version int
-	err := db.conn.QueryRow(`
+	err = db.conn.QueryRow(`
 		SELECT config, version
 		FROM pipelines
-		WHERE name = $1 AND team_id = (
+		WHERE name = $1 AND instance_vars IS NOT DISTINCT FROM $2 AND team_id = (
 			SELECT id
 			FROM teams
-			WHERE LOWER(name) = LOWER($2)
+			WHERE LOWER(name) = LOWER($3)
 		)
-	`, pipelineName, db.teamName).Scan(&configBlob, &version)
+	`, pipelineRef.Name, instanceVars, db.teamName).Scan(&configBlob, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, atc.RawConfig(""), 0, nil
@@ -202,17 +253,59 @@ func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, Con
 	return config, atc.RawConfig(string(configBlob)), ConfigVersion(version), nil
 }
 
+// GetConfigTemplate returns the pipeline's config as it was submitted, before
+// interpolation, along with the vars it was interpolated with. Pipelines saved
+// without a template return their interpolated config.
+func (db *teamDB) GetConfigTemplate(pipelineRef atc.PipelineRef) (atc.RawConfig, template.Variables, ConfigVersion, error) {
+	instanceVars, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return atc.RawConfig(""), nil, 0, err
+	}
+
+	var configBlob []byte
+	var templateBlob sql.NullString
+	var varsBlob sql.NullString
+	var version int
+	err = db.conn.QueryRow(`
+		SELECT config, template, vars, version
+		FROM pipelines
+		WHERE name = $1 AND instance_vars IS NOT DISTINCT FROM $2 AND team_id = (
+			SELECT id
+			FROM teams
+			WHERE LOWER(name) = LOWER($3)
+		)
+	`, pipelineRef.Name, instanceVars, db.teamName).Scan(&configBlob, &templateBlob, &varsBlob, &version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.RawConfig(""), nil, 0, nil
+		}
+		return atc.RawConfig(""), nil, 0, err
+	}
+
+	if !templateBlob.Valid {
+		return atc.RawConfig(string(configBlob)), nil, ConfigVersion(version), nil
+	}
+
+	var vars template.Variables
+	if varsBlob.Valid {
+		err = json.Unmarshal([]byte(varsBlob.String), &vars)
+		if err != nil {
+			return atc.RawConfig(""), nil, 0, err
+		}
+	}
+
+	return atc.RawConfig(templateBlob.String), vars, ConfigVersion(version), nil
+}
+
 func (db *teamDB) SaveConfig(
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
 	config atc.Config,
+	configTemplate atc.RawConfig,
+	vars template.Variables,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+	savedBy string,
 ) (SavedPipeline, bool, error) {
-	payload, err := json.Marshal(config)
-	if err != nil {
-		return SavedPipeline{}, false, err
-	}
-
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return SavedPipeline{}, false, err
@@ -226,6 +319,55 @@ func (db *teamDB) SaveConfig(
 		return SavedPipeline{}, false, err
 	}
 
+	savedPipeline, created, err := db.saveConfig(tx, teamID, pipelineRef, config, configTemplate, vars, from, pausedState, savedBy)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	return savedPipeline, created, tx.Commit()
+}
+
+func (db *teamDB) saveConfig(
+	tx Tx,
+	teamID int,
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	configTemplate atc.RawConfig,
+	vars template.Variables,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+	savedBy string,
+) (SavedPipeline, bool, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	var templatePayload sql.NullString
+	if configTemplate != "" {
+		templatePayload = sql.NullString{String: string(configTemplate), Valid: true}
+	}
+
+	var varsPayload sql.NullString
+	if vars != nil {
+		varsBlob, err := json.Marshal(vars)
+		if err != nil {
+			return SavedPipeline{}, false, err
+		}
+
+		varsPayload = sql.NullString{String: string(varsBlob), Valid: true}
+	}
+
+	var savedByPayload sql.NullString
+	if savedBy != "" {
+		savedByPayload = sql.NullString{String: savedBy, Valid: true}
+	}
+
+	instanceVarsBlob, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	var created bool
 	var savedPipeline SavedPipeline
 
@@ -234,8 +376,9 @@ func (db *teamDB) SaveConfig(
 		SELECT COUNT(1)
 		FROM pipelines
 		WHERE name = $1
-	  AND team_id = $2
-	`, pipelineName, teamID).Scan(&existingConfig)
+		AND instance_vars IS NOT DISTINCT FROM $2
+	  AND team_id = $3
+	`, pipelineRef.Name, instanceVarsBlob, teamID).Scan(&existingConfig)
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
@@ -246,20 +389,26 @@ func (db *teamDB) SaveConfig(
 		}
 
 		savedPipeline, err = scanPipeline(tx.QueryRow(`
-		INSERT INTO pipelines (name, config, version, ordering, paused, team_id)
+		INSERT INTO pipelines (name, config, template, vars, version, ordering, paused, team_id, instance_vars)
 		VALUES (
 			$1,
 			$2,
-			nextval('config_version_seq'),
-			(SELECT COUNT(1) + 1 FROM pipelines),
 			$3,
-			$4
+			$4,
+			nextval('config_version_seq'),
+			COALESCE(
+				(SELECT MIN(ordering) FROM pipelines WHERE name = $1 AND team_id = $6),
+				(SELECT COUNT(1) + 1 FROM pipelines)
+			),
+			$5,
+			$6,
+			$7
 		)
 		RETURNING `+unqualifiedPipelineColumns+`,
 		(
-			SELECT t.name as team_name FROM teams t WHERE t.id = $4
+			SELECT t.name as team_name FROM teams t WHERE t.id = $6
 		)
-		`, pipelineName, payload, pausedState.Bool(), teamID))
+		`, pipelineRef.Name, payload, templatePayload, varsPayload, pausedState.Bool(), teamID, instanceVarsBlob))
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -291,27 +440,29 @@ func (db *teamDB) SaveConfig(
 		if pausedState == PipelineNoChange {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq')
-			WHERE name = $2
-			AND version = $3
-			AND team_id = $4
+			SET config = $1, template = $2, vars = $3, version = nextval('config_version_seq'), archived = false
+			WHERE name = $4
+			AND instance_vars IS NOT DISTINCT FROM $7
+			AND version = $5
+			AND team_id = $6
 			RETURNING `+unqualifiedPipelineColumns+`,
 			(
-				SELECT t.name as team_name FROM teams t WHERE t.id = $4
+				SELECT t.name as team_name FROM teams t WHERE t.id = $6
 			)
-			`, payload, pipelineName, from, teamID))
+			`, payload, templatePayload, varsPayload, pipelineRef.Name, from, teamID, instanceVarsBlob))
 		} else {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq'), paused = $2
-			WHERE name = $3
-			AND version = $4
-			AND team_id = $5
+			SET config = $1, template = $2, vars = $3, version = nextval('config_version_seq'), paused = $4, archived = false
+			WHERE name = $5
+			AND instance_vars IS NOT DISTINCT FROM $8
+			AND version = $6
+			AND team_id = $7
 			RETURNING `+unqualifiedPipelineColumns+`,
 			(
-				SELECT t.name as team_name FROM teams t WHERE t.id = $4
+				SELECT t.name as team_name FROM teams t WHERE t.id = $7
 			)
-			`, payload, pausedState.Bool(), pipelineName, from, teamID))
+			`, payload, templatePayload, varsPayload, pausedState.Bool(), pipelineRef.Name, from, teamID, instanceVarsBlob))
 		}
 
 		if err != nil && err != sql.ErrNoRows {
@@ -335,6 +486,21 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, template, vars, saved_by)
+		VALUES (
+			$1,
+			(SELECT COALESCE(MAX(version), 0) + 1 FROM pipeline_config_versions WHERE pipeline_id = $1),
+			$2,
+			$3,
+			$4,
+			$5
+		)
+	`, savedPipeline.ID, payload, templatePayload, varsPayload, savedByPayload)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	for _, resource := range config.Resources {
 		err = db.registerResource(tx, resource.Name, savedPipeline.ID)
 		if err != nil {
@@ -363,9 +529,293 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
+	return savedPipeline, created, nil
+}
+
+// ImportPipelines recreates the exported pipelines, along with their pinned
+// and disabled versions, and orders them as they were exported. Either all of
+// the pipelines are imported or none are; if one of them already exists,
+// ErrConfigComparisonFailed is returned.
+func (db *teamDB) ImportPipelines(pipelines []atc.ExportedPipeline, savedBy string, pinnedBy string) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var teamID int
+	err = tx.QueryRow(`SELECT id FROM teams WHERE LOWER(name) = LOWER($1)`, db.teamName).Scan(&teamID)
+	if err != nil {
+		return err
+	}
+
+	pipelineNames := []string{}
+	orderedNames := map[string]bool{}
+
+	for _, pipeline := range pipelines {
+		pausedState := PipelineUnpaused
+		if pipeline.Paused {
+			pausedState = PipelinePaused
+		}
+
+		savedPipeline, _, err := db.saveConfig(tx, teamID, pipeline.Ref(), pipeline.Config, pipeline.Template, pipeline.Vars, 0, pausedState, savedBy)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE pipelines
+			SET public = $1
+			WHERE id = $2
+		`, pipeline.Public, savedPipeline.ID)
+		if err != nil {
+			return err
+		}
+
+		err = db.importResources(tx, savedPipeline, pipeline.Config, pipeline.Resources, pinnedBy)
+		if err != nil {
+			return err
+		}
+
+		if !orderedNames[pipeline.Name] {
+			orderedNames[pipeline.Name] = true
+			pipelineNames = append(pipelineNames, pipeline.Name)
+		}
+	}
+
+	err = db.orderPipelines(tx, teamID, pipelineNames)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *teamDB) importResources(tx Tx, savedPipeline SavedPipeline, config atc.Config, resources []atc.ExportedResource, pinnedBy string) error {
+	pdb := &pipelineDB{
+		conn:          db.conn,
+		SavedPipeline: savedPipeline,
+	}
+
+	for _, resource := range resources {
+		resourceConfig, found := config.Resources.Lookup(resource.Name)
+		if !found {
+			return ResourceNotFoundError{Name: resource.Name}
+		}
+
+		savedResource, found, err := pdb.getResource(tx, resource.Name)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return ResourceNotFoundError{Name: resource.Name}
+		}
+
+		if resource.PinnedVersion != nil {
+			versionID, err := db.importVersion(tx, pdb, savedResource, resourceConfig, resource.PinnedVersion)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(`
+				UPDATE resources
+				SET pinned_version_id = $1, pin_comment = $2, pinned_by = $3
+				WHERE id = $4
+			`, versionID, resource.PinComment, pinnedBy, savedResource.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, version := range resource.DisabledVersions {
+			versionID, err := db.importVersion(tx, pdb, savedResource, resourceConfig, version)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(`
+				UPDATE versioned_resources
+				SET enabled = false, modified_time = now()
+				WHERE id = $1
+			`, versionID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (db *teamDB) importVersion(tx Tx, pdb *pipelineDB, savedResource SavedResource, resourceConfig atc.ResourceConfig, version atc.Version) (int, error) {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return 0, err
+	}
+
+	savedVersion, _, err := pdb.saveVersionedResource(tx, savedResource, VersionedResource{
+		Resource: resourceConfig.Name,
+		Type:     resourceConfig.Type,
+		Version:  Version(version),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, resourceConfig.Type, string(versionJSON))
+	if err != nil {
+		return 0, err
+	}
+
+	return savedVersion.ID, nil
+}
+
+// SaveConfigFromBuild saves the config the same way as SaveConfig, leaving
+// the pipeline's paused state unchanged, and records the build whose
+// set_pipeline step configured it in the same transaction.
+func (db *teamDB) SaveConfigFromBuild(
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	configTemplate atc.RawConfig,
+	vars template.Variables,
+	from ConfigVersion,
+	savedBy string,
+	buildID int,
+) (SavedPipeline, bool, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	defer tx.Rollback()
+
+	var teamID int
+	err = tx.QueryRow(`SELECT id FROM teams WHERE LOWER(name) = LOWER($1)`, db.teamName).Scan(&teamID)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	savedPipeline, created, err := db.saveConfig(tx, teamID, pipelineRef, config, configTemplate, vars, from, PipelineNoChange, savedBy)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE pipelines
+		SET parent_build_id = $1
+		WHERE id = $2
+	`, buildID, savedPipeline.ID)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	savedPipeline.ParentBuildID = buildID
+
 	return savedPipeline, created, tx.Commit()
 }
 
+const pipelineConfigVersionColumns = "v.version, v.config, v.template, v.vars, v.saved_by, v.saved_at"
+
+func (db *teamDB) GetConfigVersions(pipelineRef atc.PipelineRef) ([]PipelineConfigVersion, error) {
+	instanceVars, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT `+pipelineConfigVersionColumns+`
+		FROM pipeline_config_versions v
+		INNER JOIN pipelines p ON p.id = v.pipeline_id
+		WHERE p.name = $1
+		AND p.instance_vars IS NOT DISTINCT FROM $2
+		AND p.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($3)
+		)
+		ORDER BY v.version DESC
+	`, pipelineRef.Name, instanceVars, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	configVersions := []PipelineConfigVersion{}
+
+	for rows.Next() {
+		configVersion, err := scanPipelineConfigVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		configVersions = append(configVersions, configVersion)
+	}
+
+	return configVersions, nil
+}
+
+func (db *teamDB) GetConfigVersion(pipelineRef atc.PipelineRef, version int) (PipelineConfigVersion, bool, error) {
+	instanceVars, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	configVersion, err := scanPipelineConfigVersion(db.conn.QueryRow(`
+		SELECT `+pipelineConfigVersionColumns+`
+		FROM pipeline_config_versions v
+		INNER JOIN pipelines p ON p.id = v.pipeline_id
+		WHERE p.name = $1
+		AND p.instance_vars IS NOT DISTINCT FROM $2
+		AND p.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($3)
+		)
+		AND v.version = $4
+	`, pipelineRef.Name, instanceVars, db.teamName, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return configVersion, true, nil
+}
+
+func scanPipelineConfigVersion(rows scannable) (PipelineConfigVersion, error) {
+	var configVersion PipelineConfigVersion
+	var configBlob []byte
+	var templateBlob sql.NullString
+	var varsBlob sql.NullString
+	var savedBy sql.NullString
+
+	err := rows.Scan(&configVersion.Version, &configBlob, &templateBlob, &varsBlob, &savedBy, &configVersion.SavedAt)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	err = json.Unmarshal(configBlob, &configVersion.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	if templateBlob.Valid {
+		configVersion.Template = atc.RawConfig(templateBlob.String)
+	}
+
+	if varsBlob.Valid {
+		err = json.Unmarshal([]byte(varsBlob.String), &configVersion.Vars)
+		if err != nil {
+			return PipelineConfigVersion{}, err
+		}
+	}
+
+	configVersion.SavedBy = savedBy.String
+
+	return configVersion, nil
+}
+
 func (db *teamDB) registerJob(tx Tx, name string, pipelineID int) error {
 	_, err := tx.Exec(`
 		INSERT INTO jobs (name, pipeline_id)
@@ -423,7 +873,7 @@ func (db *teamDB) registerResourceType(tx Tx, resourceType atc.ResourceType, pip
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, container_limits
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -441,7 +891,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 }
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, containerLimits sql.NullString
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -457,6 +907,7 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&basicAuth,
 		&gitHubAuth,
 		&uaaAuth,
+		&containerLimits,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -487,6 +938,13 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	if containerLimits.Valid {
+		err = json.Unmarshal([]byte(containerLimits.String), &savedTeam.ContainerLimits)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -500,7 +958,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, container_limits
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -522,7 +980,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, container_limits
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -538,12 +996,28 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, container_limits
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateContainerLimits(containerLimits *atc.ContainerLimits) (SavedTeam, error) {
+	jsonEncodedContainerLimits, err := json.Marshal(containerLimits)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET container_limits = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, container_limits
+	`
+	params := []interface{}{string(jsonEncodedContainerLimits), db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
@@ -742,9 +1216,12 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 	var paused bool
 	var public bool
 	var teamID int
+	var parentBuildID sql.NullInt64
+	var archived bool
+	var instanceVarsBlob sql.NullString
 	var teamName string
 
-	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &teamName)
+	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &parentBuildID, &archived, &instanceVarsBlob, &teamName)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
@@ -755,16 +1232,29 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 		return SavedPipeline{}, err
 	}
 
+	var instanceVars atc.InstanceVars
+	if instanceVarsBlob.Valid {
+		err = json.Unmarshal([]byte(instanceVarsBlob.String), &instanceVars)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+	}
+
 	return SavedPipeline{
 		ID:       id,
 		Paused:   paused,
 		Public:   public,
 		TeamID:   teamID,
 		TeamName: teamName,
+
+		ParentBuildID: int(parentBuildID.Int64),
+		Archived:      archived,
+
 		Pipeline: Pipeline{
-			Name:    name,
-			Config:  config,
-			Version: ConfigVersion(version),
+			Name:         name,
+			InstanceVars: instanceVars,
+			Config:       config,
+			Version:      ConfigVersion(version),
 		},
 	}, nil
 }
@@ -784,6 +1274,22 @@ func scanPipelines(rows *sql.Rows) ([]SavedPipeline, error) {
 	return pipelines, nil
 }
 
+// instanceVarsPayload encodes the instance vars for the pipelines table.
+// Pipelines without instance vars store NULL, so that they are identified by
+// their name alone.
+func instanceVarsPayload(instanceVars atc.InstanceVars) (sql.NullString, error) {
+	if len(instanceVars) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	payload, err := json.Marshal(instanceVars)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(payload), Valid: true}, nil
+}
+
 type PipelinePausedState string
 
 const (
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 )
 
 var _ = Describe("Updating pipeline config for specific team", func() {
@@ -159,52 +161,52 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("returns true for created", func() {
-			_, created, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, created, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(created).To(BeTrue())
 		})
 
 		It("caches the team id", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName(pipelineName)
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(pipeline.TeamID).To(Equal(team.ID))
 		})
 
 		It("can be saved as paused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName(pipelineName)
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(pipeline.Paused).To(BeTrue())
 		})
 
 		It("can be saved as unpaused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName(pipelineName)
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(pipeline.Paused).To(BeFalse())
 		})
 
 		It("defaults to paused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName(pipelineName)
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(pipeline.Paused).To(BeTrue())
 		})
 
 		It("creates all of the resources from the pipeline in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(savedPipeline)
@@ -214,7 +216,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the resource types from the pipeline in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(savedPipeline)
@@ -225,7 +227,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the jobs from the pipeline in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(savedPipeline)
@@ -235,7 +237,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the serial groups from the jobs in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			serialGroups := []SerialGroup{}
@@ -275,90 +277,90 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("it returns created as false", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, configVersion, err := teamDB.GetConfig(pipelineName)
+			_, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, created, err := teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+			_, created, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, configVersion, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(created).To(BeFalse())
 		})
 
 		It("updating from paused to unpaused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName(pipelineName)
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(pipeline.Paused).To(BeTrue())
 
-			_, _, configVersion, err := teamDB.GetConfig(pipelineName)
+			_, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineUnpaused)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, configVersion, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err = teamDB.GetPipelineByName(pipelineName)
+			pipeline, err = teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(pipeline.Paused).To(BeFalse())
 		})
 
 		It("updating from unpaused to paused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err := teamDB.GetPipelineByName(pipelineName)
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(pipeline.Paused).To(BeFalse())
 
-			_, _, configVersion, err := teamDB.GetConfig(pipelineName)
+			_, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelinePaused)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, configVersion, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pipeline, err = teamDB.GetPipelineByName(pipelineName)
+			pipeline, err = teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(pipeline.Paused).To(BeTrue())
 		})
 
 		Context("updating with no change", func() {
 			It("maintains paused if the pipeline is paused", func() {
-				_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+				_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelinePaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
-				pipeline, err := teamDB.GetPipelineByName(pipelineName)
+				pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pipeline.Paused).To(BeTrue())
 
-				_, _, configVersion, err := teamDB.GetConfig(pipelineName)
+				_, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 
-				_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+				_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, configVersion, db.PipelineNoChange, "")
 				Expect(err).NotTo(HaveOccurred())
 
-				pipeline, err = teamDB.GetPipelineByName(pipelineName)
+				pipeline, err = teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pipeline.Paused).To(BeTrue())
 			})
 
 			It("maintains unpaused if the pipeline is unpaused", func() {
-				_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+				_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
-				pipeline, err := teamDB.GetPipelineByName(pipelineName)
+				pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pipeline.Paused).To(BeFalse())
 
-				_, _, configVersion, err := teamDB.GetConfig(pipelineName)
+				_, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 
-				_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+				_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, configVersion, db.PipelineNoChange, "")
 				Expect(err).NotTo(HaveOccurred())
 
-				pipeline, err = teamDB.GetPipelineByName(pipelineName)
+				pipeline, err = teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pipeline.Paused).To(BeFalse())
 			})
@@ -369,18 +371,18 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		pipelineName := "a-pipeline-name"
 		otherPipelineName := "an-other-pipeline-name"
 
-		_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+		_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
-		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: otherPipelineName}, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		pipeline, err := teamDB.GetPipelineByName(pipelineName)
+		pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: pipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(pipeline.Name).To(Equal(pipelineName))
 		Expect(pipeline.Config).To(Equal(config))
 		Expect(pipeline.ID).NotTo(Equal(0))
 
-		otherPipeline, err := teamDB.GetPipelineByName(otherPipelineName)
+		otherPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: otherPipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(otherPipeline.Name).To(Equal(otherPipelineName))
 		Expect(otherPipeline.Config).To(Equal(otherConfig))
@@ -388,22 +390,22 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 	})
 
 	It("can order pipelines", func() {
-		_, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{}, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-1", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-1"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-2", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-2"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-3", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-3"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-4", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-4"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-5", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-5"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		err = teamDB.OrderPipelines([]string{
@@ -416,7 +418,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-6", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-6"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelines, err := teamDB.GetPipelines()
@@ -497,17 +499,115 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		}))
 	})
 
+	Context("when the same pipeline is saved with different instance vars", func() {
+		var (
+			featureRef atc.PipelineRef
+			masterRef  atc.PipelineRef
+
+			featurePipeline db.SavedPipeline
+			masterPipeline  db.SavedPipeline
+		)
+
+		BeforeEach(func() {
+			featureRef = atc.PipelineRef{
+				Name:         "a-pipeline-name",
+				InstanceVars: atc.InstanceVars{"branch": "feature"},
+			}
+
+			masterRef = atc.PipelineRef{
+				Name:         "a-pipeline-name",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			}
+
+			var err error
+			featurePipeline, _, err = teamDB.SaveConfig(featureRef, config, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "an-other-pipeline-name"}, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			masterPipeline, _, err = teamDB.SaveConfig(masterRef, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves each instance as its own pipeline", func() {
+			Expect(featurePipeline.ID).NotTo(Equal(masterPipeline.ID))
+			Expect(featurePipeline.Ref()).To(Equal(featureRef))
+			Expect(masterPipeline.Ref()).To(Equal(masterRef))
+
+			pipeline, err := teamDB.GetPipelineByRef(masterRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.ID).To(Equal(masterPipeline.ID))
+			Expect(pipeline.Config).To(Equal(otherConfig))
+
+			featureConfig, _, _, err := teamDB.GetConfig(featureRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(featureConfig).To(Equal(config))
+		})
+
+		It("does not find the instances by name alone", func() {
+			_, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "a-pipeline-name"})
+			Expect(err).To(Equal(sql.ErrNoRows))
+		})
+
+		It("updates only the given instance", func() {
+			_, _, configVersion, err := teamDB.GetConfig(featureRef)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, created, err := teamDB.SaveConfig(featureRef, otherConfig, "", nil, configVersion, db.PipelineNoChange, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			featureConfig, _, _, err := teamDB.GetConfig(featureRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(featureConfig).To(Equal(otherConfig))
+
+			configVersions, err := teamDB.GetConfigVersions(masterRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configVersions).To(HaveLen(1))
+		})
+
+		It("lists the instances next to each other", func() {
+			pipelines, err := teamDB.GetPipelines()
+			Expect(err).NotTo(HaveOccurred())
+
+			refs := []atc.PipelineRef{}
+			for _, pipeline := range pipelines {
+				refs = append(refs, pipeline.Ref())
+			}
+
+			Expect(refs).To(Equal([]atc.PipelineRef{
+				featureRef,
+				masterRef,
+				{Name: "an-other-pipeline-name"},
+			}))
+		})
+
+		It("pauses each instance independently", func() {
+			err := pipelineDBFactory.Build(featurePipeline).Pause()
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, err := teamDB.GetPipelineByRef(featureRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.Paused).To(BeTrue())
+
+			pipeline, err = teamDB.GetPipelineByRef(masterRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.Paused).To(BeFalse())
+		})
+	})
+
 	It("can get a list of all active pipelines ordered by 'ordering'", func() {
 		pipelineName := "a-pipeline-name"
 		otherPipelineName := "an-other-pipeline-name"
 
-		_, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{}, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: otherPipelineName}, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		err = teamDB.OrderPipelines([]string{
@@ -553,8 +653,165 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		}))
 	})
 
+	Describe("config templates", func() {
+		It("stores the uninterpolated config and vars alongside the config", func() {
+			configTemplate := atc.RawConfig(`{"resources":[{"name":"((name))"}]}`)
+			vars := template.Variables{"name": "some-resource"}
+
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, configTemplate, vars, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			returnedConfig, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedConfig).To(Equal(config))
+
+			returnedTemplate, returnedVars, templateVersion, err := teamDB.GetConfigTemplate(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedTemplate).To(Equal(configTemplate))
+			Expect(returnedVars).To(Equal(vars))
+			Expect(templateVersion).To(Equal(configVersion))
+		})
+
+		It("returns the config as its own template when saved without one", func() {
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			configJSON, err := json.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			returnedTemplate, returnedVars, _, err := teamDB.GetConfigTemplate(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(returnedTemplate)).To(MatchJSON(configJSON))
+			Expect(returnedVars).To(BeNil())
+		})
+	})
+
+	Describe("SaveConfigFromBuild", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the config and records the build that configured the pipeline", func() {
+			savedPipeline, created, err := teamDB.SaveConfigFromBuild(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, "build 1", build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(savedPipeline.ParentBuildID).To(Equal(build.ID()))
+
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.Config).To(Equal(config))
+			Expect(pipeline.ParentBuildID).To(Equal(build.ID()))
+
+			versions, err := teamDB.GetConfigVersions(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+			Expect(versions[0].SavedBy).To(Equal("build 1"))
+		})
+
+		It("leaves the paused state of an existing pipeline alone", func() {
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, version, err := teamDB.GetConfig(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, created, err := teamDB.SaveConfigFromBuild(atc.PipelineRef{Name: "my-pipeline"}, otherConfig, "", nil, version, "build 1", build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.Paused).To(BeFalse())
+			Expect(pipeline.ParentBuildID).To(Equal(build.ID()))
+		})
+
+		It("does not record the build when the config version conflicts", func() {
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfigFromBuild(atc.PipelineRef{Name: "my-pipeline"}, otherConfig, "", nil, 0, "build 1", build.ID())
+			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+			pipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.ParentBuildID).To(BeZero())
+		})
+	})
+
+	Describe("config history", func() {
+		It("records each saved config as a new version", func() {
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "some-team")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, otherConfig, "", template.Variables{"some": "var"}, configVersion, db.PipelineNoChange, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := teamDB.GetConfigVersions(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+
+			Expect(versions[0].Version).To(Equal(2))
+			Expect(versions[0].Config).To(Equal(otherConfig))
+			Expect(versions[0].Vars).To(Equal(template.Variables{"some": "var"}))
+			Expect(versions[0].SavedBy).To(BeEmpty())
+
+			Expect(versions[1].Version).To(Equal(1))
+			Expect(versions[1].Config).To(Equal(config))
+			Expect(versions[1].SavedBy).To(Equal("some-team"))
+			Expect(versions[1].SavedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("can look up a single version", func() {
+			configTemplate := atc.RawConfig(`{"resources":[{"name":"((name))"}]}`)
+
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, configTemplate, template.Variables{"name": "some-resource"}, 0, db.PipelineUnpaused, "some-team")
+			Expect(err).NotTo(HaveOccurred())
+
+			version, found, err := teamDB.GetConfigVersion(atc.PipelineRef{Name: "my-pipeline"}, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Version).To(Equal(1))
+			Expect(version.Config).To(Equal(config))
+			Expect(version.Template).To(Equal(configTemplate))
+			Expect(version.Vars).To(Equal(template.Variables{"name": "some-resource"}))
+		})
+
+		It("returns false when the version does not exist", func() {
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := teamDB.GetConfigVersion(atc.PipelineRef{Name: "my-pipeline"}, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = teamDB.GetConfigVersion(atc.PipelineRef{Name: "some-other-pipeline"}, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not return versions of other teams' pipelines", func() {
+			_, err := database.CreateTeam(db.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamDB := teamDBFactory.GetTeamDB("other-team")
+			_, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := teamDB.GetConfigVersions(atc.PipelineRef{Name: "my-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(BeEmpty())
+		})
+	})
+
 	It("can lookup configs by build id", func() {
-		savedPipeline, _, err := teamDB.SaveConfig("my-pipeline", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "my-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
 
 		myPipelineDB := pipelineDBFactory.Build(savedPipeline)
 
@@ -570,22 +827,22 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		otherPipelineName := "an-other-pipeline-name"
 
 		By("initially being empty")
-		initialConfig, _, _, err := teamDB.GetConfig(pipelineName)
+		initialConfig, _, _, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(initialConfig).To(BeZero())
-		initialOtherConfig, _, _, err := teamDB.GetConfig(otherPipelineName)
+		initialOtherConfig, _, _, err := teamDB.GetConfig(atc.PipelineRef{Name: otherPipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(initialOtherConfig).To(BeZero())
 
 		By("being able to save the config")
-		_, _, err = teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: otherPipelineName}, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		By("returning the saved config to later gets")
-		returnedConfig, returnedRawConfig, configVersion, err := teamDB.GetConfig(pipelineName)
+		returnedConfig, returnedRawConfig, configVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(returnedConfig).To(Equal(config))
 		jsonBytes, err := json.Marshal(config)
@@ -593,7 +850,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Expect(returnedRawConfig).To(MatchJSON(jsonBytes))
 		Expect(configVersion).NotTo(Equal(db.ConfigVersion(0)))
 
-		otherReturnedConfig, otherReturnedRawConfig, otherConfigVersion, err := teamDB.GetConfig(otherPipelineName)
+		otherReturnedConfig, otherReturnedRawConfig, otherConfigVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: otherPipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(otherReturnedConfig).To(Equal(otherConfig))
 		jsonBytes, err = json.Marshal(otherConfig)
@@ -634,26 +891,26 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		By("not allowing non-sequential updates")
-		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion-1, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, updatedConfig, "", nil, configVersion-1, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion+10, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, updatedConfig, "", nil, configVersion+10, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion-1, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, "", nil, otherConfigVersion-1, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion+10, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, "", nil, otherConfigVersion+10, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
 		By("being able to update the config with a valid con")
-		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: pipelineName}, updatedConfig, "", nil, configVersion, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
-		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, "", nil, otherConfigVersion, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		By("returning the updated config")
-		returnedConfig, returnedRawConfig, newConfigVersion, err := teamDB.GetConfig(pipelineName)
+		returnedConfig, returnedRawConfig, newConfigVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(returnedConfig).To(Equal(updatedConfig))
 		rawConfigJSONBytes, err := json.Marshal(updatedConfig)
@@ -661,7 +918,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Expect(returnedRawConfig).To(MatchJSON(rawConfigJSONBytes))
 		Expect(newConfigVersion).NotTo(Equal(configVersion))
 
-		otherReturnedConfig, otherReturnedRawConfig, newOtherConfigVersion, err := teamDB.GetConfig(otherPipelineName)
+		otherReturnedConfig, otherReturnedRawConfig, newOtherConfigVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: otherPipelineName})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(otherReturnedConfig).To(Equal(updatedConfig))
 		Expect(returnedRawConfig).To(MatchJSON(rawConfigJSONBytes))
@@ -669,7 +926,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 
 		By("being able to retrieve invalid config")
 		invalidPipelineName := "invalid-config"
-		_, _, err = teamDB.SaveConfig(invalidPipelineName, config, 1, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: invalidPipelineName}, config, "", nil, 1, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		dbConn.Exec(`
@@ -678,7 +935,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		WHERE name = 'invalid-config'
 		`)
 
-		_, _, invalidConfigVersion, err := teamDB.GetConfig(invalidPipelineName)
+		_, _, invalidConfigVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: invalidPipelineName})
 		Expect(err).To(BeAssignableToTypeOf(atc.MalformedConfigError{}))
 		Expect(err.Error()).To(ContainSubstring("malformed config:"))
 		Expect(invalidConfigVersion).NotTo(Equal(db.ConfigVersion(1)))
@@ -696,51 +953,51 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("can allow pipelines with the same name across teams", func() {
-			_, _, err := teamDB.SaveConfig("steve", config, 0, db.PipelineUnpaused)
+			_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, config, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			By("allowing you to save a pipeline with the same name in another team")
-			_, _, err = otherTeamDB.SaveConfig("steve", otherConfig, 0, db.PipelineUnpaused)
+			_, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, otherConfig, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			By("getting the config for the correct team's pipeline")
-			actualConfig, _, teamPipelineVersion, err := teamDB.GetConfig("steve")
+			actualConfig, _, teamPipelineVersion, err := teamDB.GetConfig(atc.PipelineRef{Name: "steve"})
 			Expect(actualConfig).To(Equal(config))
 
-			actualOtherConfig, _, otherTeamPipelineVersion, err := otherTeamDB.GetConfig("steve")
+			actualOtherConfig, _, otherTeamPipelineVersion, err := otherTeamDB.GetConfig(atc.PipelineRef{Name: "steve"})
 			Expect(actualOtherConfig).To(Equal(otherConfig))
 
 			By("updating the pipeline config for the correct team's pipeline")
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, teamPipelineVersion, db.PipelineNoChange)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, otherConfig, "", nil, teamPipelineVersion, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = otherTeamDB.SaveConfig("steve", config, otherTeamPipelineVersion, db.PipelineNoChange)
+			_, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, config, "", nil, otherTeamPipelineVersion, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			actualOtherConfig, _, teamPipelineVersion, err = teamDB.GetConfig("steve")
+			actualOtherConfig, _, teamPipelineVersion, err = teamDB.GetConfig(atc.PipelineRef{Name: "steve"})
 			Expect(actualOtherConfig).To(Equal(otherConfig))
 
-			actualConfig, _, otherTeamPipelineVersion, err = otherTeamDB.GetConfig("steve")
+			actualConfig, _, otherTeamPipelineVersion, err = otherTeamDB.GetConfig(atc.PipelineRef{Name: "steve"})
 			Expect(actualConfig).To(Equal(config))
 
 			By("pausing the correct team's pipeline")
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, teamPipelineVersion, db.PipelinePaused)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, otherConfig, "", nil, teamPipelineVersion, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			pausedPipeline, err := teamDB.GetPipelineByName("steve")
+			pausedPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "steve"})
 			Expect(err).NotTo(HaveOccurred())
 
-			unpausedPipeline, err := otherTeamDB.GetPipelineByName("steve")
+			unpausedPipeline, err := otherTeamDB.GetPipelineByRef(atc.PipelineRef{Name: "steve"})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(pausedPipeline.Paused).To(BeTrue())
 			Expect(unpausedPipeline.Paused).To(BeFalse())
 
 			By("cannot cross update configs")
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, otherTeamPipelineVersion, db.PipelineNoChange)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, otherConfig, "", nil, otherTeamPipelineVersion, db.PipelineNoChange, "")
 			Expect(err).To(HaveOccurred())
 
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, otherTeamPipelineVersion, db.PipelinePaused)
+			_, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "steve"}, otherConfig, "", nil, otherTeamPipelineVersion, db.PipelinePaused, "")
 			Expect(err).To(HaveOccurred())
 		})
 	})
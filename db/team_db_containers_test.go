@@ -85,10 +85,10 @@ var _ = Describe("TeamDbContainers", func() {
 			},
 		}
 
-		savedPipeline, _, err = teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		savedOtherPipeline, _, err = teamDB.SaveConfig("some-other-pipeline", config, 0, db.PipelineUnpaused)
+		savedOtherPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "some-other-pipeline"}, config, "", nil, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 	"github.com/lib/pq"
 
 	. "github.com/onsi/ginkgo"
@@ -65,19 +66,19 @@ var _ = Describe("TeamDB", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
-	Describe("GetPipelineByName", func() {
+	Describe("GetPipelineByRef", func() {
 		var savedPipeline db.SavedPipeline
 		BeforeEach(func() {
 			var err error
-			savedPipeline, _, err = teamDB.SaveConfig("pipeline-name", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = otherTeamDB.SaveConfig("pipeline-name", atc.Config{}, 0, db.PipelineUnpaused)
+			_, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
 		It("returns the pipeline with the case insensitive name that belongs to the team", func() {
-			actualPipeline, err := teamDB.GetPipelineByName("pipeline-name")
+			actualPipeline, err := teamDB.GetPipelineByRef(atc.PipelineRef{Name: "pipeline-name"})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(actualPipeline).To(Equal(savedPipeline))
 		})
@@ -89,16 +90,16 @@ var _ = Describe("TeamDB", func() {
 
 		BeforeEach(func() {
 			var err error
-			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline1, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline2, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline, _, err := otherTeamDB.SaveConfig("other-team-pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline, _, err := otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-team-pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			_, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-team-pipeline-name-b"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(otherSavedPublicPipeline)
@@ -123,22 +124,22 @@ var _ = Describe("TeamDB", func() {
 		var otherSavedPublicPipeline3 db.SavedPipeline
 		BeforeEach(func() {
 			var err error
-			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline1, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline2, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			savedPipeline3, _, err = teamDB.SaveConfig("pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline3, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-c"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline1, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline1, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-team-pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline2, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline2, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-team-pipeline-name-b"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline3, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline3, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-team-pipeline-name-c"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB1 := pipelineDBFactory.Build(savedPipeline1)
@@ -202,14 +203,14 @@ var _ = Describe("TeamDB", func() {
 
 		BeforeEach(func() {
 			var err error
-			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline1, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
-			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline2, _, err = teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherTeamSavedPipeline1, _, err = otherTeamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherTeamSavedPipeline1, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
-			otherTeamSavedPipeline2, _, err = otherTeamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			otherTeamSavedPipeline2, _, err = otherTeamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -234,6 +235,90 @@ var _ = Describe("TeamDB", func() {
 		})
 	})
 
+	Describe("ImportPipelines", func() {
+		var pipelines []atc.ExportedPipeline
+
+		BeforeEach(func() {
+			pipelines = []atc.ExportedPipeline{
+				{
+					Name:   "pipeline-name-b",
+					Public: true,
+					Config: atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "some-resource", Type: "some-type"},
+						},
+					},
+					Template: "resources: [{name: some-resource, type: ((type))}]",
+					Vars:     template.Variables{"type": "some-type"},
+					Resources: []atc.ExportedResource{
+						{
+							Name:             "some-resource",
+							PinnedVersion:    atc.Version{"version": "1"},
+							PinComment:       "some-comment",
+							DisabledVersions: []atc.Version{{"version": "2"}},
+						},
+					},
+				},
+				{
+					Name:   "pipeline-name-a",
+					Paused: true,
+				},
+			}
+		})
+
+		It("imports the pipelines in order, with their pinned and disabled versions", func() {
+			err := teamDB.ImportPipelines(pipelines, "some-team", "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			importedPipelines, err := teamDB.GetPipelines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(importedPipelines).To(HaveLen(2))
+
+			Expect(importedPipelines[0].Name).To(Equal("pipeline-name-b"))
+			Expect(importedPipelines[0].Public).To(BeTrue())
+			Expect(importedPipelines[0].Paused).To(BeFalse())
+
+			Expect(importedPipelines[1].Name).To(Equal("pipeline-name-a"))
+			Expect(importedPipelines[1].Public).To(BeFalse())
+			Expect(importedPipelines[1].Paused).To(BeTrue())
+
+			configTemplate, vars, _, err := teamDB.GetConfigTemplate(atc.PipelineRef{Name: "pipeline-name-b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configTemplate).To(Equal(pipelines[0].Template))
+			Expect(vars).To(Equal(pipelines[0].Vars))
+
+			pipelineDB := pipelineDBFactory.Build(importedPipelines[0])
+
+			savedResource, found, err := pipelineDB.GetResource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedResource.PinnedVersion).To(Equal(db.Version{"version": "1"}))
+			Expect(savedResource.PinComment).To(Equal("some-comment"))
+			Expect(savedResource.PinnedBy).To(Equal("some-user"))
+
+			disabledVersions, err := pipelineDB.GetDisabledVersions("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disabledVersions).To(Equal([]db.Version{{"version": "2"}}))
+		})
+
+		Context("when one of the pipelines already exists", func() {
+			BeforeEach(func() {
+				_, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, "", nil, 0, db.PipelineUnpaused, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("imports none of them", func() {
+				err := teamDB.ImportPipelines(pipelines, "some-team", "some-user")
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+				savedPipelines, err := teamDB.GetPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedPipelines).To(HaveLen(1))
+				Expect(savedPipelines[0].Name).To(Equal("pipeline-name-a"))
+			})
+		})
+	})
+
 	Describe("Updating Auth", func() {
 		var basicAuth *db.BasicAuth
 		var gitHubAuth *db.GitHubAuth
@@ -376,6 +461,30 @@ var _ = Describe("TeamDB", func() {
 		})
 	})
 
+	Describe("UpdateContainerLimits", func() {
+		It("saves the container limits to the existing team", func() {
+			limits := &atc.ContainerLimits{CPU: 512, Memory: 1024}
+
+			savedTeam, err := teamDB.UpdateContainerLimits(limits)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedTeam.ContainerLimits).To(Equal(limits))
+
+			actualTeam, found, err := teamDB.GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(actualTeam.ContainerLimits).To(Equal(limits))
+		})
+
+		It("clears the container limits when given none", func() {
+			_, err := teamDB.UpdateContainerLimits(&atc.ContainerLimits{CPU: 512})
+			Expect(err).NotTo(HaveOccurred())
+
+			savedTeam, err := teamDB.UpdateContainerLimits(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedTeam.ContainerLimits).To(BeNil())
+		})
+	})
+
 	Describe("GetTeam", func() {
 		It("returns the saved team", func() {
 			actualTeam, found, err := teamDB.GetTeam()
@@ -544,7 +653,7 @@ var _ = Describe("TeamDB", func() {
 						},
 					},
 				}
-				pipeline, _, err := teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+				pipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "some-pipeline"}, config, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pipelineDB = pipelineDBFactory.Build(pipeline)
@@ -676,7 +785,7 @@ var _ = Describe("TeamDB", func() {
 					pipelineDB.Reveal()
 
 					config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-					privatePipeline, _, err := teamDB.SaveConfig("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+					privatePipeline, _, err := teamDB.SaveConfig(atc.PipelineRef{Name: "private-pipeline"}, config, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 					privatePipelineDB := pipelineDBFactory.Build(privatePipeline)
 
@@ -690,7 +799,7 @@ var _ = Describe("TeamDB", func() {
 
 					otherTeamDB := teamDBFactory.GetTeamDB("other")
 
-					otherTeamPublicPipeline, _, err := otherTeamDB.SaveConfig("other-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+					otherTeamPublicPipeline, _, err := otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-pipeline"}, config, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 					otherPipelineDB := pipelineDBFactory.Build(otherTeamPublicPipeline)
 					otherPipelineDB.Reveal()
@@ -743,7 +852,7 @@ var _ = Describe("TeamDB", func() {
 
 			It("returns it if it's public", func() {
 				config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-				otherTeamPublicPipeline, _, err := otherTeamDB.SaveConfig("other-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+				otherTeamPublicPipeline, _, err := otherTeamDB.SaveConfig(atc.PipelineRef{Name: "other-pipeline"}, config, "", nil, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 				otherPipelineDB := pipelineDBFactory.Build(otherTeamPublicPipeline)
 				otherPipelineDB.Reveal()
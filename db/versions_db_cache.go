@@ -0,0 +1,592 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/concourse/atc/db/algorithm"
+)
+
+// rows inserted by transactions which were still in flight during a refresh
+// may have ids below ones which were already loaded, so ids are only
+// considered settled once they've been loaded for this long
+const versionsDBSettleTime = time.Minute
+
+// entries which haven't been loaded for this long are dropped, so that the
+// cache doesn't hold on to pipelines which were deleted without it knowing,
+// e.g. by another ATC or along with their team
+const versionsDBCacheIdleTime = 10 * time.Minute
+
+var versionsDBCacheHits int64
+var versionsDBCacheMisses int64
+
+// VersionsDBCacheCounts returns the number of versions DB loads which were
+// served from the cache and the number which required a full reload since the
+// last call.
+func VersionsDBCacheCounts() (int, int) {
+	hits := atomic.SwapInt64(&versionsDBCacheHits, 0)
+	misses := atomic.SwapInt64(&versionsDBCacheMisses, 0)
+	return int(hits), int(misses)
+}
+
+func versionsDBChannel(pipelineID int) string {
+	return fmt.Sprintf("versions_db_%d", pipelineID)
+}
+
+// versionsDBCache holds the versions DB of each pipeline in memory so that
+// only the build inputs, build outputs, and versions added since the last
+// load have to be queried. Changes to existing rows are announced on the
+// pipeline's versions DB channel, which forces a full reload.
+type versionsDBCache struct {
+	bus *notificationsBus
+
+	entries   map[int]*versionsDBCacheEntry
+	entriesL  sync.Mutex
+	lastSweep time.Time
+}
+
+func newVersionsDBCache(bus *notificationsBus) *versionsDBCache {
+	return &versionsDBCache{
+		bus:     bus,
+		entries: map[int]*versionsDBCacheEntry{},
+	}
+}
+
+type versionsDBCacheEntry struct {
+	sync.Mutex
+
+	notify      chan bool
+	invalidated bool
+
+	// guarded by the cache's entriesL
+	lastUsed time.Time
+
+	versionsDB *algorithm.VersionsDB
+	configKey  string
+
+	// only used for pipelines with cross-pipeline passed constraints, which
+	// are always fully reloaded when anything has been modified
+	latestModifiedTime time.Time
+
+	versions idWindow
+	inputs   idWindow
+	outputs  idWindow
+
+	// outputs of builds which have not finished yet, keyed by build ID
+	pendingOutputs         map[int][]algorithm.BuildOutput
+	pendingDisabledOutputs map[int][]algorithm.BuildOutput
+}
+
+func (cache *versionsDBCache) entry(pipelineID int) (*versionsDBCacheEntry, error) {
+	now := time.Now()
+
+	cache.entriesL.Lock()
+	idle := cache.sweep(now)
+	entry, err := cache.lookup(pipelineID, now)
+	cache.entriesL.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idlePipelineID, idleEntry := range idle {
+		err := cache.bus.Unlisten(versionsDBChannel(idlePipelineID), idleEntry.notify)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// lookup returns the pipeline's entry, creating it if needed. It must be
+// called with entriesL held.
+func (cache *versionsDBCache) lookup(pipelineID int, now time.Time) (*versionsDBCacheEntry, error) {
+	entry, found := cache.entries[pipelineID]
+	if found {
+		entry.lastUsed = now
+		return entry, nil
+	}
+
+	notify, err := cache.bus.Listen(versionsDBChannel(pipelineID))
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &versionsDBCacheEntry{notify: notify, lastUsed: now}
+	cache.entries[pipelineID] = entry
+
+	return entry, nil
+}
+
+// sweep drops the entries which have not been used for
+// versionsDBCacheIdleTime, returning them so that they can be unlistened. It
+// must be called with entriesL held.
+func (cache *versionsDBCache) sweep(now time.Time) map[int]*versionsDBCacheEntry {
+	if now.Sub(cache.lastSweep) < versionsDBCacheIdleTime {
+		return nil
+	}
+
+	cache.lastSweep = now
+
+	idle := map[int]*versionsDBCacheEntry{}
+	for pipelineID, entry := range cache.entries {
+		if now.Sub(entry.lastUsed) >= versionsDBCacheIdleTime {
+			idle[pipelineID] = entry
+			delete(cache.entries, pipelineID)
+		}
+	}
+
+	return idle
+}
+
+func (cache *versionsDBCache) invalidate(pipelineID int) error {
+	cache.entriesL.Lock()
+	entry, found := cache.entries[pipelineID]
+	cache.entriesL.Unlock()
+
+	if found {
+		entry.Lock()
+		entry.invalidated = true
+		entry.Unlock()
+	}
+
+	return cache.bus.Notify(versionsDBChannel(pipelineID))
+}
+
+func (cache *versionsDBCache) remove(pipelineID int) error {
+	cache.entriesL.Lock()
+	entry, found := cache.entries[pipelineID]
+	delete(cache.entries, pipelineID)
+	cache.entriesL.Unlock()
+
+	if !found {
+		return nil
+	}
+
+	return cache.bus.Unlisten(versionsDBChannel(pipelineID), entry.notify)
+}
+
+// needsReload reports whether the cached versions DB cannot be updated
+// incrementally, either because it has been invalidated or because the
+// connection to the bus was lost and invalidations may have been missed.
+func (entry *versionsDBCacheEntry) needsReload(configKey string) bool {
+	select {
+	case <-entry.notify:
+		entry.invalidated = true
+	default:
+	}
+
+	return entry.invalidated || entry.versionsDB == nil || entry.configKey != configKey
+}
+
+func (entry *versionsDBCacheEntry) reset() {
+	entry.invalidated = false
+	entry.versionsDB = nil
+	entry.configKey = ""
+	entry.latestModifiedTime = time.Time{}
+	entry.versions = idWindow{}
+	entry.inputs = idWindow{}
+	entry.outputs = idWindow{}
+	entry.pendingOutputs = map[int][]algorithm.BuildOutput{}
+	entry.pendingDisabledOutputs = map[int][]algorithm.BuildOutput{}
+}
+
+// idWindow tracks which rows of a table have been loaded, by id.
+type idWindow struct {
+	settled int
+	seen    map[int]bool
+	marks   []idMark
+}
+
+type idMark struct {
+	at time.Time
+	id int
+}
+
+// add records that the row has been loaded, returning false if it already
+// had been.
+func (window *idWindow) add(id int) bool {
+	if id <= window.settled || window.seen[id] {
+		return false
+	}
+
+	if window.seen == nil {
+		window.seen = map[int]bool{}
+	}
+
+	window.seen[id] = true
+
+	return true
+}
+
+func (window *idWindow) max() int {
+	max := window.settled
+	for id := range window.seen {
+		if id > max {
+			max = id
+		}
+	}
+
+	return max
+}
+
+// settle advances the settled id to the highest id loaded at least
+// versionsDBSettleTime ago.
+func (window *idWindow) settle(now time.Time) {
+	window.marks = append(window.marks, idMark{at: now, id: window.max()})
+
+	for len(window.marks) > 0 && now.Sub(window.marks[0].at) >= versionsDBSettleTime {
+		window.settled = window.marks[0].id
+		window.marks = window.marks[1:]
+	}
+
+	for id := range window.seen {
+		if id <= window.settled {
+			delete(window.seen, id)
+		}
+	}
+}
+
+// settleAll treats every row loaded so far as settled; used after a full
+// reload to avoid tracking every row of the pipeline.
+func (window *idWindow) settleAll() {
+	window.settled = window.max()
+	window.seen = nil
+	window.marks = nil
+}
+
+// versionsDBChanges are the rows loaded by a refresh of a pipeline's versions
+// DB.
+type versionsDBChanges struct {
+	// number of rows loaded, including those which were not added to the
+	// versions DB, such as outputs of builds which haven't finished yet
+	loaded int
+
+	resourceVersions     []algorithm.ResourceVersion
+	buildOutputs         []algorithm.BuildOutput
+	buildInputs          []algorithm.BuildInput
+	jobIDs               map[string]int
+	resourceIDs          map[string]int
+	pinnedVersions       map[int]int
+	disabledVersions     []algorithm.ResourceVersion
+	disabledBuildOutputs []algorithm.BuildOutput
+}
+
+func (pdb *pipelineDB) loadVersionsDBIncrementally(entry *versionsDBCacheEntry) (*algorithm.VersionsDB, error) {
+	changes, err := pdb.loadVersionsDBChanges(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := entry.versionsDB
+
+	if changes.loaded == 0 &&
+		reflect.DeepEqual(changes.jobIDs, cached.JobIDs) &&
+		reflect.DeepEqual(changes.resourceIDs, cached.ResourceIDs) &&
+		reflect.DeepEqual(changes.pinnedVersions, cached.PinnedVersions) {
+		return cached, nil
+	}
+
+	// cap the cached slices so that appending copies them rather than sharing
+	// their backing arrays with versions DBs which callers may still hold
+	versionsDB := &algorithm.VersionsDB{
+		ResourceVersions: append(cached.ResourceVersions[:len(cached.ResourceVersions):len(cached.ResourceVersions)], changes.resourceVersions...),
+		BuildOutputs:     append(cached.BuildOutputs[:len(cached.BuildOutputs):len(cached.BuildOutputs)], changes.buildOutputs...),
+		BuildInputs:      append(cached.BuildInputs[:len(cached.BuildInputs):len(cached.BuildInputs)], changes.buildInputs...),
+		JobIDs:           changes.jobIDs,
+		ResourceIDs:      changes.resourceIDs,
+		PinnedVersions:   changes.pinnedVersions,
+		CachedAt:         time.Now(),
+
+		DisabledVersions:     append(cached.DisabledVersions[:len(cached.DisabledVersions):len(cached.DisabledVersions)], changes.disabledVersions...),
+		DisabledBuildOutputs: append(cached.DisabledBuildOutputs[:len(cached.DisabledBuildOutputs):len(cached.DisabledBuildOutputs)], changes.disabledBuildOutputs...),
+	}
+
+	entry.versionsDB = versionsDB
+
+	return versionsDB, nil
+}
+
+func (pdb *pipelineDB) loadFullVersionsDB(entry *versionsDBCacheEntry, configKey string) (*algorithm.VersionsDB, error) {
+	entry.reset()
+
+	changes, err := pdb.loadVersionsDBChanges(entry)
+	if err != nil {
+		entry.reset()
+		return nil, err
+	}
+
+	entry.versions.settleAll()
+	entry.inputs.settleAll()
+	entry.outputs.settleAll()
+
+	versionsDB := &algorithm.VersionsDB{
+		ResourceVersions: changes.resourceVersions,
+		BuildOutputs:     changes.buildOutputs,
+		BuildInputs:      changes.buildInputs,
+		JobIDs:           changes.jobIDs,
+		ResourceIDs:      changes.resourceIDs,
+		PinnedVersions:   changes.pinnedVersions,
+		CachedAt:         time.Now(),
+
+		DisabledVersions:     changes.disabledVersions,
+		DisabledBuildOutputs: changes.disabledBuildOutputs,
+	}
+
+	entry.versionsDB = versionsDB
+	entry.configKey = configKey
+
+	return versionsDB, nil
+}
+
+// loadVersionsDBChanges loads the rows which have not been loaded into the
+// entry yet, along with the pipeline's jobs, resources, and pinned versions,
+// which are cheap enough to always reload.
+func (pdb *pipelineDB) loadVersionsDBChanges(entry *versionsDBCacheEntry) (versionsDBChanges, error) {
+	changes := versionsDBChanges{
+		resourceVersions:     []algorithm.ResourceVersion{},
+		buildOutputs:         []algorithm.BuildOutput{},
+		buildInputs:          []algorithm.BuildInput{},
+		disabledVersions:     []algorithm.ResourceVersion{},
+		disabledBuildOutputs: []algorithm.BuildOutput{},
+	}
+
+	now := time.Now()
+
+	err := pdb.loadNewBuildOutputs(entry, &changes)
+	if err != nil {
+		return versionsDBChanges{}, err
+	}
+
+	err = pdb.loadFinishedPendingOutputs(entry, &changes)
+	if err != nil {
+		return versionsDBChanges{}, err
+	}
+
+	err = pdb.loadNewBuildInputs(entry, &changes)
+	if err != nil {
+		return versionsDBChanges{}, err
+	}
+
+	err = pdb.loadNewResourceVersions(entry, &changes)
+	if err != nil {
+		return versionsDBChanges{}, err
+	}
+
+	changes.jobIDs, err = pdb.loadJobIDs()
+	if err != nil {
+		return versionsDBChanges{}, err
+	}
+
+	changes.resourceIDs, changes.pinnedVersions, err = pdb.loadResourceIDsAndPinnedVersions()
+	if err != nil {
+		return versionsDBChanges{}, err
+	}
+
+	entry.versions.settle(now)
+	entry.inputs.settle(now)
+	entry.outputs.settle(now)
+
+	return changes, nil
+}
+
+func (pdb *pipelineDB) loadNewBuildOutputs(entry *versionsDBCacheEntry, changes *versionsDBChanges) error {
+	rows, err := pdb.conn.Query(`
+    SELECT o.id, v.id, v.check_order, v.enabled, r.id, o.build_id, b.status, j.id
+    FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
+    WHERE v.id = o.versioned_resource_id
+    AND b.id = o.build_id
+    AND j.id = b.job_id
+    AND r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND o.id > $2
+  `, pdb.ID, entry.outputs.settled)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var enabled bool
+		var status string
+		var output algorithm.BuildOutput
+		err := rows.Scan(&id, &output.VersionID, &output.CheckOrder, &enabled, &output.ResourceID, &output.BuildID, &status, &output.JobID)
+		if err != nil {
+			return err
+		}
+
+		if !entry.outputs.add(id) {
+			continue
+		}
+
+		changes.loaded++
+
+		if !enabled {
+			switch Status(status) {
+			case StatusSucceeded:
+				changes.disabledBuildOutputs = append(changes.disabledBuildOutputs, output)
+			case StatusPending, StatusStarted:
+				entry.pendingDisabledOutputs[output.BuildID] = append(entry.pendingDisabledOutputs[output.BuildID], output)
+			}
+
+			continue
+		}
+
+		switch Status(status) {
+		case StatusSucceeded:
+			changes.buildOutputs = append(changes.buildOutputs, output)
+		case StatusPending, StatusStarted:
+			entry.pendingOutputs[output.BuildID] = append(entry.pendingOutputs[output.BuildID], output)
+		}
+	}
+
+	return nil
+}
+
+// loadFinishedPendingOutputs adds the outputs of builds which have succeeded
+// since their outputs were loaded, and forgets those of builds which finished
+// any other way.
+func (pdb *pipelineDB) loadFinishedPendingOutputs(entry *versionsDBCacheEntry, changes *versionsDBChanges) error {
+	pendingBuildIDs := map[int]bool{}
+	for buildID := range entry.pendingOutputs {
+		pendingBuildIDs[buildID] = true
+	}
+
+	for buildID := range entry.pendingDisabledOutputs {
+		pendingBuildIDs[buildID] = true
+	}
+
+	if len(pendingBuildIDs) == 0 {
+		return nil
+	}
+
+	args := []interface{}{}
+	refs := []string{}
+	for buildID := range pendingBuildIDs {
+		args = append(args, buildID)
+		refs = append(refs, fmt.Sprintf("$%d", len(args)))
+	}
+
+	rows, err := pdb.conn.Query(`
+		SELECT id, status
+		FROM builds
+		WHERE id IN (`+strings.Join(refs, ",")+`)
+	`, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var status string
+		err := rows.Scan(&buildID, &status)
+		if err != nil {
+			return err
+		}
+
+		switch Status(status) {
+		case StatusPending, StatusStarted:
+			continue
+		case StatusSucceeded:
+			changes.buildOutputs = append(changes.buildOutputs, entry.pendingOutputs[buildID]...)
+			changes.disabledBuildOutputs = append(changes.disabledBuildOutputs, entry.pendingDisabledOutputs[buildID]...)
+		}
+
+		changes.loaded++
+
+		delete(entry.pendingOutputs, buildID)
+		delete(entry.pendingDisabledOutputs, buildID)
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) loadNewBuildInputs(entry *versionsDBCacheEntry, changes *versionsDBChanges) error {
+	rows, err := pdb.conn.Query(`
+    SELECT i.id, v.id, v.check_order, v.enabled, r.id, i.build_id, i.name, j.id
+    FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
+    WHERE v.id = i.versioned_resource_id
+    AND b.id = i.build_id
+    AND j.id = b.job_id
+    AND r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND i.id > $2
+  `, pdb.ID, entry.inputs.settled)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var enabled bool
+		var input algorithm.BuildInput
+		err := rows.Scan(&id, &input.VersionID, &input.CheckOrder, &enabled, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID)
+		if err != nil {
+			return err
+		}
+
+		if !entry.inputs.add(id) {
+			continue
+		}
+
+		changes.loaded++
+
+		if !enabled {
+			continue
+		}
+
+		changes.buildInputs = append(changes.buildInputs, input)
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) loadNewResourceVersions(entry *versionsDBCacheEntry, changes *versionsDBChanges) error {
+	rows, err := pdb.conn.Query(`
+    SELECT v.id, v.check_order, v.enabled, r.id
+    FROM versioned_resources v, resources r
+    WHERE r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND v.id > $2
+  `, pdb.ID, entry.versions.settled)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var enabled bool
+		var version algorithm.ResourceVersion
+		err := rows.Scan(&version.VersionID, &version.CheckOrder, &enabled, &version.ResourceID)
+		if err != nil {
+			return err
+		}
+
+		if !entry.versions.add(version.VersionID) {
+			continue
+		}
+
+		changes.loaded++
+
+		if !enabled {
+			changes.disabledVersions = append(changes.disabledVersions, version)
+			continue
+		}
+
+		changes.resourceVersions = append(changes.resourceVersions, version)
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ type VolumeIdentifier struct {
 	Output        *OutputIdentifier
 	Import        *ImportIdentifier
 	Replication   *ReplicationIdentifier
+	TaskCache     *TaskCacheIdentifier
 }
 
 func (i VolumeIdentifier) Type() string {
@@ -40,6 +41,8 @@ func (i VolumeIdentifier) Type() string {
 		return "import"
 	case i.Replication != nil:
 		return "replication"
+	case i.TaskCache != nil:
+		return "task-cache"
 	default:
 		return ""
 	}
@@ -57,6 +60,8 @@ func (i VolumeIdentifier) String() string {
 		return i.Import.String()
 	case i.Replication != nil:
 		return i.Replication.String()
+	case i.TaskCache != nil:
+		return i.TaskCache.String()
 	default:
 		return ""
 	}
@@ -112,6 +117,20 @@ func (i ImportIdentifier) String() string {
 	return fmt.Sprintf("%s@%s", i.Path, *i.Version)
 }
 
+// TaskCacheIdentifier identifies the volume backing one of a task's caches.
+// Caches are local to a worker, so the worker is part of their identity.
+type TaskCacheIdentifier struct {
+	WorkerName string
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+func (i TaskCacheIdentifier) String() string {
+	return fmt.Sprintf("%s/%s:%s", i.JobName, i.StepName, i.Path)
+}
+
 type SavedVolume struct {
 	Volume
 
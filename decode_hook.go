@@ -44,6 +44,18 @@ var VersionConfigDecodeHook = func(
 				Pinned: version,
 			}, nil
 		}
+
+		if versionConfig, ok := data.(map[string]interface{}); ok {
+			for key, val := range versionConfig {
+				if sVal, ok := val.(string); ok {
+					version[key] = strings.TrimSpace(sVal)
+				}
+			}
+
+			return VersionConfig{
+				Pinned: version,
+			}, nil
+		}
 	}
 
 	return data, nil
@@ -22,6 +22,23 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("in-parallel")
+
+	step := exec.InParallel{
+		Limit:    plan.InParallel.Limit,
+		FailFast: plan.InParallel.FailFast,
+	}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -69,6 +86,22 @@ func (build *execBuild) buildOnFailureStep(logger lager.Logger, plan atc.Plan) e
 	return exec.OnFailure(step, next)
 }
 
+func (build *execBuild) buildOnAbortStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	plan.OnAbort.Step.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, plan.OnAbort.Step)
+	plan.OnAbort.Next.Attempts = plan.Attempts
+	next := build.buildStepFactory(logger, plan.OnAbort.Next)
+	return exec.OnAbort(step, next)
+}
+
+func (build *execBuild) buildOnErrorStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	plan.OnError.Step.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, plan.OnError.Step)
+	plan.OnError.Next.Attempts = plan.Attempts
+	next := build.buildStepFactory(logger, plan.OnError.Next)
+	return exec.OnError(step, next)
+}
+
 func (build *execBuild) buildEnsureStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	plan.Ensure.Step.Attempts = plan.Attempts
 	step := build.buildStepFactory(logger, plan.Ensure.Step)
@@ -81,7 +114,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 	logger = logger.Session("task")
 
 	var configSource exec.TaskConfigSource
-	if plan.Task.ConfigPath != "" && (plan.Task.Config != nil || plan.Task.Params != nil) {
+	if plan.Task.ConfigPath != "" && (plan.Task.Config != nil || plan.Task.Params != nil || plan.Task.ContainerLimits != nil) {
 		configSource = exec.MergedConfigSource{
 			A: exec.FileConfigSource{plan.Task.ConfigPath},
 			B: exec.StaticConfigSource{*plan.Task},
@@ -94,6 +127,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		return exec.Identity{}
 	}
 
+	configSource = build.withTeamContainerLimits(logger, configSource)
 	configSource = exec.ValidatingConfigSource{configSource}
 
 	workerID, workerMetadata := build.stepIdentifier(
@@ -116,6 +150,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		exec.Privileged(plan.Task.Privileged),
 		plan.Task.Tags,
 		build.teamID,
+		build.variables,
 		configSource,
 		plan.Task.ResourceTypes,
 		plan.Task.InputMapping,
@@ -127,6 +162,44 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 	)
 }
 
+func (build *execBuild) withTeamContainerLimits(logger lager.Logger, configSource exec.TaskConfigSource) exec.TaskConfigSource {
+	team, found, err := build.teamDB.GetTeam()
+	if err != nil {
+		logger.Error("failed-to-get-team", err)
+		return configSource
+	}
+
+	if !found || team.ContainerLimits == nil {
+		return configSource
+	}
+
+	return exec.DefaultLimitsConfigSource{
+		ConfigSource: configSource,
+		Limits:       *team.ContainerLimits,
+	}
+}
+
+func (build *execBuild) buildLoadVarStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	return exec.LoadVar(*plan.LoadVar, build.variables)
+}
+
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return exec.SetPipeline(
+		*plan.SetPipeline,
+		build.delegate.SetPipelineDelegate(logger, *plan.SetPipeline, event.OriginID(plan.ID)),
+		pipelineSaver{
+			logger:   logger,
+			teamDB:   build.teamDB,
+			validate: build.validate,
+			buildID:  build.buildID,
+		},
+	)
+}
+
 func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("get", lager.Data{
 		"name": plan.Get.Name,
@@ -155,6 +228,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		},
 		plan.Get.Tags,
 		build.teamID,
+		build.variables,
 		plan.Get.Params,
 		plan.Get.Version,
 		plan.Get.ResourceTypes,
@@ -190,6 +264,7 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		},
 		plan.Put.Tags,
 		build.teamID,
+		build.variables,
 		plan.Put.Params,
 		plan.Put.ResourceTypes,
 		build.containerSuccessTTL,
@@ -226,6 +301,7 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		},
 		getPlan.Tags,
 		build.teamID,
+		build.variables,
 		getPlan.Params,
 		getPlan.ResourceTypes,
 		build.containerSuccessTTL,
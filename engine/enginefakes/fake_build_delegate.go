@@ -42,6 +42,16 @@ type FakeBuildDelegate struct {
 	outputDelegateReturns struct {
 		result1 exec.PutDelegate
 	}
+	SetPipelineDelegateStub        func(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
 	FinishStub        func(lager.Logger, error, exec.Success, bool)
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -159,6 +169,41 @@ func (fake *FakeBuildDelegate) OutputDelegateReturns(result1 exec.PutDelegate) {
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) SetPipelineDelegate(arg1 lager.Logger, arg2 atc.SetPipelinePlan, arg3 event.OriginID) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1, arg2, arg3})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.setPipelineDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateArgsForCall(i int) (lager.Logger, atc.SetPipelinePlan, event.OriginID) {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return fake.setPipelineDelegateArgsForCall[i].arg1, fake.setPipelineDelegateArgsForCall[i].arg2, fake.setPipelineDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Finish(arg1 lager.Logger, arg2 error, arg3 exec.Success, arg4 bool) {
 	fake.finishMutex.Lock()
 	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
@@ -195,6 +240,8 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.executionDelegateMutex.RUnlock()
 	fake.outputDelegateMutex.RLock()
 	defer fake.outputDelegateMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	return fake.invocations
@@ -10,6 +10,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -28,6 +30,8 @@ type execEngine struct {
 	factory         exec.Factory
 	delegateFactory BuildDelegateFactory
 	teamDBFactory   db.TeamDBFactory
+	secrets         creds.Secrets
+	validate        config.Validator
 	externalURL     string
 }
 
@@ -35,12 +39,16 @@ func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
+	secrets creds.Secrets,
+	validate config.Validator,
 	externalURL string,
 ) Engine {
 	return &execEngine{
 		factory:         factory,
 		delegateFactory: delegateFactory,
 		teamDBFactory:   teamDBFactory,
+		secrets:         secrets,
+		validate:        validate,
 		externalURL:     externalURL,
 	}
 }
@@ -54,7 +62,10 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		buildID:      build.ID(),
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
+		teamDB:       engine.teamDBFactory.GetTeamDB(build.TeamName()),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    creds.NewVariables(engine.secrets, build.TeamName(), build.PipelineName()),
+		validate:     engine.validate,
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -77,7 +88,7 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		return nil, err
 	}
 
-	err = atc.NewPlanTraversal(engine.convertPipelineNameToID(build.TeamName())).Traverse(&metadata.Plan)
+	err = atc.NewPlanTraversal(engine.convertPipelineNameToID(build)).Traverse(&metadata.Plan)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +97,10 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		buildID:      build.ID(),
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
+		teamDB:       engine.teamDBFactory.GetTeamDB(build.TeamName()),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    creds.NewVariables(engine.secrets, build.TeamName(), build.PipelineName()),
+		validate:     engine.validate,
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -99,8 +113,11 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 	}, nil
 }
 
-func (engine *execEngine) convertPipelineNameToID(teamName string) func(plan *atc.Plan) error {
-	teamDB := engine.teamDBFactory.GetTeamDB(teamName)
+// convertPipelineNameToID resolves the pipeline names in plans saved before
+// plans referred to pipelines by ID. A name matching the build's own pipeline
+// refers to that pipeline, including its instance vars.
+func (engine *execEngine) convertPipelineNameToID(build db.Build) func(plan *atc.Plan) error {
+	teamDB := engine.teamDBFactory.GetTeamDB(build.TeamName())
 	return func(plan *atc.Plan) error {
 		var pipelineName *string
 		var pipelineID *int
@@ -130,8 +147,17 @@ func (engine *execEngine) convertPipelineNameToID(teamName string) func(plan *at
 				)
 			}
 
-			savedPipeline, err := teamDB.GetPipelineByName(*pipelineName)
+			pipelineRef := atc.PipelineRef{Name: *pipelineName}
+			if !build.IsOneOff() && *pipelineName == build.PipelineName() {
+				buildPipeline, err := build.GetPipeline()
+				if err != nil {
+					return err
+				}
 
+				pipelineRef = buildPipeline.Ref()
+			}
+
+			savedPipeline, err := teamDB.GetPipelineByRef(pipelineRef)
 			if err != nil {
 				return err
 			}
@@ -159,6 +185,9 @@ type execBuild struct {
 	stepMetadata StepMetadata
 	teamName     string
 	teamID       int
+	teamDB       db.TeamDB
+	variables    creds.Variables
+	validate     config.Validator
 
 	factory  exec.Factory
 	delegate BuildDelegate
@@ -233,6 +262,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -253,6 +286,14 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildOnFailureStep(logger, plan)
 	}
 
+	if plan.OnAbort != nil {
+		return build.buildOnAbortStep(logger, plan)
+	}
+
+	if plan.OnError != nil {
+		return build.buildOnErrorStep(logger, plan)
+	}
+
 	if plan.Ensure != nil {
 		return build.buildEnsureStep(logger, plan)
 	}
@@ -261,6 +302,14 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildTaskStep(logger, plan)
 	}
 
+	if plan.LoadVar != nil {
+		return build.buildLoadVarStep(logger, plan)
+	}
+
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
 	if plan.Get != nil {
 		return build.buildGetStep(logger, plan)
 	}
@@ -301,6 +350,7 @@ func (build *execBuild) stepIdentifier(
 			StepName:   stepName,
 			Type:       stepType,
 			PipelineID: pipelineID,
+			JobName:    build.stepMetadata.JobName,
 			TeamID:     build.teamID,
 			Attempts:   attempts,
 		}
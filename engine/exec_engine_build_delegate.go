@@ -25,6 +25,7 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	SetPipelineDelegate(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -91,6 +92,16 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) SetPipelineDelegate(logger lager.Logger, plan atc.SetPipelinePlan, id event.OriginID) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -459,6 +470,36 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type setPipelineDelegate struct {
+	logger lager.Logger
+
+	plan atc.SetPipelinePlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (setPipeline *setPipelineDelegate) Failed(err error) {
+	setPipeline.delegate.saveErr(setPipeline.logger, err, event.Origin{
+		ID: setPipeline.id,
+	})
+	setPipeline.logger.Info("errored", lager.Data{"error": err.Error()})
+}
+
+func (setPipeline *setPipelineDelegate) Stdout() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStdout,
+		ID:     setPipeline.id,
+	})
+}
+
+func (setPipeline *setPipelineDelegate) Stderr() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStderr,
+		ID:     setPipeline.id,
+	})
+}
+
 type dbEventWriter struct {
 	buildID    int
 	pipelineID int
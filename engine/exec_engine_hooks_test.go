@@ -1,8 +1,12 @@
 package engine_test
 
 import (
+	"errors"
+	"os"
+
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -36,10 +40,13 @@ var _ = Describe("Exec Engine With Hooks", func() {
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+		fakeTeamDBFactory.GetTeamDBReturns(new(dbfakes.FakeTeamDB))
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			nil,
+			config.ValidateConfig,
 			"http://example.com",
 		)
 
@@ -183,12 +190,13 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the step correctly", func() {
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(sourceName).To(Equal(exec.SourceName("some-input")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-input",
 						Type:       db.ContainerTypeGet,
 					}))
@@ -204,11 +212,12 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the completion hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-completion-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-completion-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -225,11 +234,12 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the failure hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-failure-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-failure-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -246,11 +256,12 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the success hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-success-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-success-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -267,11 +278,12 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the next step correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
+					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-next-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
+						JobName:    "some-job",
 						StepName:   "some-next-task",
 						Type:       db.ContainerTypeTask,
 					}))
@@ -453,6 +465,96 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			})
 		})
 
+		Context("when the step errors", func() {
+			var planFactory atc.PlanFactory
+
+			BeforeEach(func() {
+				planFactory = atc.NewPlanFactory(123)
+				inputStep.RunReturns(errors.New("disaster"))
+			})
+
+			It("runs the error hook but not the failure hook", func() {
+				plan := planFactory.NewPlan(atc.OnErrorPlan{
+					Step: planFactory.NewPlan(atc.OnFailurePlan{
+						Step: planFactory.NewPlan(atc.GetPlan{
+							Name: "some-input",
+						}),
+						Next: planFactory.NewPlan(atc.PutPlan{
+							Name: "some-failure-output",
+						}),
+					}),
+					Next: planFactory.NewPlan(atc.TaskPlan{
+						Name:   "some-cleanup",
+						Config: &atc.TaskConfig{},
+					}),
+				})
+
+				build, err := execEngine.CreateBuild(logger, build, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+
+				Expect(inputStep.RunCallCount()).To(Equal(1))
+				Expect(outputStep.RunCallCount()).To(Equal(0))
+				Expect(taskStep.RunCallCount()).To(Equal(1))
+
+				_, cbErr, successful, aborted := fakeDelegate.FinishArgsForCall(0)
+				Expect(cbErr).To(MatchError("disaster"))
+				Expect(successful).To(Equal(exec.Success(false)))
+				Expect(aborted).To(BeFalse())
+			})
+		})
+
+		Context("when the build is aborted", func() {
+			var planFactory atc.PlanFactory
+
+			BeforeEach(func() {
+				planFactory = atc.NewPlanFactory(123)
+
+				inputStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					close(ready)
+					<-signals
+					return exec.ErrInterrupted
+				}
+			})
+
+			It("runs the abort hook", func() {
+				plan := planFactory.NewPlan(atc.OnAbortPlan{
+					Step: planFactory.NewPlan(atc.GetPlan{
+						Name: "some-input",
+					}),
+					Next: planFactory.NewPlan(atc.TaskPlan{
+						Name:   "some-cleanup",
+						Config: &atc.TaskConfig{},
+					}),
+				})
+
+				build, err := execEngine.CreateBuild(logger, build, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				resumed := make(chan struct{})
+				go func() {
+					defer close(resumed)
+					build.Resume(logger)
+				}()
+
+				Eventually(inputStep.RunCallCount).Should(Equal(1))
+
+				err = build.Abort(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(resumed).Should(BeClosed())
+
+				Expect(taskStep.RunCallCount()).To(Equal(1))
+				Expect(taskStep.ReleaseCallCount()).To(Equal(1))
+
+				_, cbErr, successful, aborted := fakeDelegate.FinishArgsForCall(0)
+				Expect(cbErr).To(Equal(exec.ErrInterrupted))
+				Expect(successful).To(Equal(exec.Success(false)))
+				Expect(aborted).To(BeTrue())
+			})
+		})
+
 		Context("when a step in the aggregate fails the step fails", func() {
 			var planFactory atc.PlanFactory
 
@@ -4,12 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -17,6 +22,7 @@ import (
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/template"
 	"github.com/concourse/atc/worker"
 
 	. "github.com/onsi/ginkgo"
@@ -27,6 +33,7 @@ var _ = Describe("ExecEngine", func() {
 	var (
 		fakeFactory         *execfakes.FakeFactory
 		fakeTeamDB          *dbfakes.FakeTeamDB
+		fakeSecrets         *credsfakes.FakeSecrets
 		fakeDelegateFactory *enginefakes.FakeBuildDelegateFactory
 		logger              *lagertest.TestLogger
 
@@ -42,10 +49,13 @@ var _ = Describe("ExecEngine", func() {
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
 		fakeTeamDB = new(dbfakes.FakeTeamDB)
 		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+		fakeSecrets = new(credsfakes.FakeSecrets)
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeSecrets,
+			config.ValidateConfig,
 			"http://example.com",
 		)
 	})
@@ -203,7 +213,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -216,7 +226,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -235,7 +245,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -248,7 +258,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.DependentGetArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.DependentGetArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -263,7 +273,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -271,6 +281,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -286,7 +297,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"some": "params"}))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ = fakeFactory.PutArgsForCall(1)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -294,6 +305,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put-2",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -318,7 +330,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -326,6 +338,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -346,7 +359,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"another": "params"}))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ = fakeFactory.DependentGetArgsForCall(1)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ = fakeFactory.DependentGetArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -354,6 +367,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get-2",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -443,7 +457,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -451,6 +465,7 @@ var _ = Describe("ExecEngine", func() {
 					Type:         db.ContainerTypeGet,
 					StepName:     "some-get",
 					PipelineID:   57,
+					JobName:      "some-job",
 					Attempts:     []int{1},
 					TeamID:       teamID,
 				}))
@@ -471,7 +486,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(1)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -479,6 +494,7 @@ var _ = Describe("ExecEngine", func() {
 					Type:         db.ContainerTypeGet,
 					StepName:     "some-get",
 					PipelineID:   57,
+					JobName:      "some-job",
 					Attempts:     []int{3},
 					TeamID:       teamID,
 				}))
@@ -503,7 +519,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -511,6 +527,7 @@ var _ = Describe("ExecEngine", func() {
 					Type:         db.ContainerTypeTask,
 					StepName:     "some-task",
 					PipelineID:   57,
+					JobName:      "some-job",
 					Attempts:     []int{2, 1},
 					TeamID:       teamID,
 				}))
@@ -525,7 +542,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, _, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -533,6 +550,7 @@ var _ = Describe("ExecEngine", func() {
 					Type:         db.ContainerTypeTask,
 					StepName:     "some-task",
 					PipelineID:   57,
+					JobName:      "some-job",
 					Attempts:     []int{2, 2},
 					TeamID:       teamID,
 				}))
@@ -593,13 +611,13 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
 			})
 		})
@@ -635,7 +653,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -654,7 +672,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -668,7 +686,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, version, _, _, _ := fakeFactory.GetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, variables, params, version, _, _, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -676,6 +694,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-input",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(sourceName).To(Equal(exec.SourceName("some-input")))
@@ -686,6 +705,7 @@ var _ = Describe("ExecEngine", func() {
 
 					Expect(tags).To(ConsistOf("some", "get", "tags"))
 					Expect(actualTeamID).To(Equal(teamID))
+					Expect(variables).To(Equal(creds.NewVariables(fakeSecrets, "some-team", "some-pipeline")))
 					Expect(resourceConfig.Name).To(Equal("some-input-resource"))
 					Expect(resourceConfig.Type).To(Equal("get"))
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
@@ -750,7 +770,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -769,7 +789,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -782,7 +802,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, _, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(logger).NotTo(BeNil())
 						Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 						Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -790,6 +810,7 @@ var _ = Describe("ExecEngine", func() {
 							Type:         db.ContainerTypeTask,
 							StepName:     "some-task",
 							PipelineID:   57,
+							JobName:      "some-job",
 							TeamID:       teamID,
 						}))
 						Expect(workerID).To(Equal(worker.Identifier{
@@ -824,7 +845,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 						})
 					})
@@ -844,7 +865,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -869,7 +890,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -877,6 +898,54 @@ var _ = Describe("ExecEngine", func() {
 						})
 					})
 
+					Context("when the plan contains container limits and config path", func() {
+						BeforeEach(func() {
+							taskPlan.ContainerLimits = &atc.ContainerLimits{CPU: 512}
+						})
+
+						It("creates the task with a MergedConfigSource wrapped in a ValidatingConfigSource", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							vcs, ok := configSource.(exec.ValidatingConfigSource)
+							Expect(ok).To(BeTrue())
+							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
+							Expect(ok).To(BeTrue())
+						})
+					})
+
+					Context("when the team has default container limits", func() {
+						BeforeEach(func() {
+							fakeTeamDB.GetTeamReturns(db.SavedTeam{
+								Team: db.Team{
+									Name:            "some-team",
+									ContainerLimits: &atc.ContainerLimits{CPU: 512, Memory: 1024},
+								},
+							}, true, nil)
+						})
+
+						It("applies them to the task's config", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							vcs, ok := configSource.(exec.ValidatingConfigSource)
+							Expect(ok).To(BeTrue())
+							dlcs, ok := vcs.ConfigSource.(exec.DefaultLimitsConfigSource)
+							Expect(ok).To(BeTrue())
+							Expect(dlcs.Limits).To(Equal(atc.ContainerLimits{CPU: 512, Memory: 1024}))
+						})
+					})
+
 					It("releases the tasks correctly", func() {
 						taskStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
 							defer GinkgoRecover()
@@ -893,6 +962,129 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that contains a load_var step", func() {
+				BeforeEach(func() {
+					plan = planFactory.NewPlan(atc.DoPlan{
+						planFactory.NewPlan(atc.GetPlan{
+							Name:     "version",
+							Resource: "version",
+							Type:     "semver",
+						}),
+						planFactory.NewPlan(atc.LoadVarPlan{
+							Name: "version",
+							File: "version/number",
+						}),
+						planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-input/build.yml",
+						}),
+					})
+
+					inputStepFactory.UsingStub = func(prev exec.Step, repo *exec.SourceRepository) exec.Step {
+						fakeSource := new(execfakes.FakeArtifactSource)
+						fakeSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader("1.2.3\n")), nil)
+						repo.RegisterSource("version", fakeSource)
+						return inputStep
+					}
+				})
+
+				It("makes the loaded var available to later steps", func() {
+					var evaluated atc.Params
+					taskStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+						_, _, _, _, _, _, _, _, variables, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+
+						var err error
+						evaluated, err = variables.EvaluateParams(atc.Params{"version": "((.:version))"})
+						return err
+					}
+
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(taskStep.RunCallCount()).To(Equal(1))
+					Expect(evaluated).To(Equal(atc.Params{"version": "1.2.3"}))
+				})
+			})
+
+			Context("that contains a set_pipeline step", func() {
+				var fakeSetPipelineDelegate *execfakes.FakeSetPipelineDelegate
+
+				BeforeEach(func() {
+					plan = planFactory.NewPlan(atc.DoPlan{
+						planFactory.NewPlan(atc.GetPlan{
+							Name:     "ci",
+							Resource: "ci",
+							Type:     "git",
+						}),
+						planFactory.NewPlan(atc.SetPipelinePlan{
+							Name: "some-child-pipeline",
+							File: "ci/pipeline.yml",
+							Vars: map[string]interface{}{"job-name": "some-child-job"},
+						}),
+					})
+
+					fakeSetPipelineDelegate = new(execfakes.FakeSetPipelineDelegate)
+					fakeSetPipelineDelegate.StdoutReturns(ioutil.Discard)
+					fakeSetPipelineDelegate.StderrReturns(ioutil.Discard)
+					fakeDelegate.SetPipelineDelegateReturns(fakeSetPipelineDelegate)
+
+					fakeTeamDB.GetConfigReturns(atc.Config{}, "", 3, nil)
+
+					inputStepFactory.UsingStub = func(prev exec.Step, repo *exec.SourceRepository) exec.Step {
+						fakeSource := new(execfakes.FakeArtifactSource)
+						fakeSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader("jobs:\n- name: ((job-name))\n")), nil)
+						repo.RegisterSource("ci", fakeSource)
+						return inputStep
+					}
+				})
+
+				JustBeforeEach(func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+				})
+
+				It("saves the pipeline in the build's team on behalf of the build, recording the build as its parent", func() {
+					Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(fakeTeamDB.SaveConfigFromBuildCallCount()).To(Equal(1))
+
+					name, config, _, vars, version, savedBy, buildID := fakeTeamDB.SaveConfigFromBuildArgsForCall(0)
+					Expect(name).To(Equal(atc.PipelineRef{Name: "some-child-pipeline"}))
+					Expect(config.Jobs).To(Equal(atc.JobConfigs{{Name: "some-child-job"}}))
+					Expect(vars).To(Equal(template.Variables{"job-name": "some-child-job"}))
+					Expect(version).To(Equal(db.ConfigVersion(3)))
+					Expect(savedBy).To(Equal("build 42"))
+					Expect(buildID).To(Equal(42))
+				})
+
+				It("finishes successfully", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, succeeded, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(succeeded).To(Equal(exec.Success(true)))
+					Expect(aborted).To(BeFalse())
+				})
+
+				Context("when the config is invalid", func() {
+					BeforeEach(func() {
+						inputStepFactory.UsingStub = func(prev exec.Step, repo *exec.SourceRepository) exec.Step {
+							fakeSource := new(execfakes.FakeArtifactSource)
+							fakeSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader("jobs:\n- name: a\n- name: a\n")), nil)
+							repo.RegisterSource("ci", fakeSource)
+							return inputStep
+						}
+					})
+
+					It("does not save the pipeline", func() {
+						Expect(fakeTeamDB.SaveConfigFromBuildCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					plan             atc.Plan
@@ -934,7 +1126,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -942,6 +1134,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -970,7 +1163,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -978,6 +1171,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get",
 						PipelineID:   57,
+						JobName:      "some-job",
 						TeamID:       teamID,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -1119,7 +1313,7 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(engine.StepMetadata{
 					BuildID:      42,
@@ -1133,6 +1327,7 @@ var _ = Describe("ExecEngine", func() {
 					Type:         db.ContainerTypeGet,
 					StepName:     "some-get",
 					PipelineID:   57,
+					JobName:      "some-job",
 					Attempts:     []int{1},
 					TeamID:       teamID,
 				}))
@@ -1164,7 +1359,7 @@ var _ = Describe("ExecEngine", func() {
 					foundBuild.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -1182,7 +1377,7 @@ var _ = Describe("ExecEngine", func() {
 					foundBuild.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -1212,14 +1407,14 @@ var _ = Describe("ExecEngine", func() {
 						}
 					}`,
 				)
-				fakeTeamDB.GetPipelineByNameStub = func(pipelineName string) (db.SavedPipeline, error) {
-					switch pipelineName {
+				fakeTeamDB.GetPipelineByRefStub = func(pipelineRef atc.PipelineRef) (db.SavedPipeline, error) {
+					switch pipelineRef.Name {
 					case "some-pipeline-1":
 						return db.SavedPipeline{ID: 1}, nil
 					case "some-pipeline-2":
 						return db.SavedPipeline{ID: 2}, nil
 					default:
-						errMessage := fmt.Sprintf("unknown pipeline name `%s`", pipelineName)
+						errMessage := fmt.Sprintf("unknown pipeline name `%s`", pipelineRef.Name)
 						Fail(errMessage)
 						return db.SavedPipeline{}, errors.New(errMessage)
 					}
@@ -1267,6 +1462,53 @@ var _ = Describe("ExecEngine", func() {
 				}))
 			})
 
+			Context("when a plan refers to the build's own pipeline", func() {
+				BeforeEach(func() {
+					dbBuild.EngineMetadataReturns(`{
+						"Plan": {
+							"id": "1",
+							"get": {"pipeline": "some-pipeline"}
+						}
+					}`,
+					)
+
+					dbBuild.GetPipelineReturns(db.SavedPipeline{
+						ID: 3,
+						Pipeline: db.Pipeline{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "feature"},
+						},
+					}, nil)
+
+					fakeTeamDB.GetPipelineByRefReturns(db.SavedPipeline{ID: 3}, nil)
+				})
+
+				It("resolves it by the pipeline's full ref, including its instance vars", func() {
+					_, err := execEngine.LookupBuild(logger, dbBuild)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTeamDB.GetPipelineByRefCallCount()).To(Equal(1))
+					Expect(fakeTeamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature"},
+					}))
+				})
+
+				Context("when getting the build's pipeline fails", func() {
+					disaster := errors.New("oh dear")
+
+					BeforeEach(func() {
+						dbBuild.GetPipelineReturns(db.SavedPipeline{}, disaster)
+					})
+
+					It("returns an error", func() {
+						foundBuild, err := execEngine.LookupBuild(logger, dbBuild)
+						Expect(err).To(Equal(disaster))
+						Expect(foundBuild).To(BeNil())
+					})
+				})
+			})
+
 			Context("when pipeline can not be found", func() {
 				var disaster error
 				BeforeEach(func() {
@@ -1278,7 +1520,7 @@ var _ = Describe("ExecEngine", func() {
 					}`,
 					)
 					disaster = errors.New("oh dear")
-					fakeTeamDB.GetPipelineByNameReturns(db.SavedPipeline{}, disaster)
+					fakeTeamDB.GetPipelineByRefReturns(db.SavedPipeline{}, disaster)
 				})
 
 				It("returns an error", func() {
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -37,10 +38,13 @@ var _ = Describe("Exec Engine with Try", func() {
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+		fakeTeamDBFactory.GetTeamDBReturns(new(dbfakes.FakeTeamDB))
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			nil,
+			config.ValidateConfig,
 			"http://example.com",
 		)
 
@@ -121,7 +125,7 @@ var _ = Describe("Exec Engine with Try", func() {
 
 			It("constructs the step correctly", func() {
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(sourceName).To(Equal(exec.SourceName("some-input")))
@@ -129,6 +133,7 @@ var _ = Describe("Exec Engine with Try", func() {
 					Type:       db.ContainerTypeGet,
 					StepName:   "some-input",
 					PipelineID: 42,
+					JobName:    "some-job",
 				}))
 				Expect(workerID).To(Equal(worker.Identifier{
 					BuildID: 84,
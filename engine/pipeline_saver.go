@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/configsaver"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
+)
+
+// pipelineSaver saves pipelines configured by set_pipeline steps the same way
+// as the API does, and records the build as the pipeline's parent.
+type pipelineSaver struct {
+	logger   lager.Logger
+	teamDB   db.TeamDB
+	validate config.Validator
+	buildID  int
+}
+
+func (saver pipelineSaver) ConfigVersion(pipelineName string) (db.ConfigVersion, error) {
+	_, _, version, err := saver.teamDB.GetConfig(atc.PipelineRef{Name: pipelineName})
+	return version, err
+}
+
+func (saver pipelineSaver) SavePipeline(pipelineName string, configStructure interface{}, vars template.Variables, from db.ConfigVersion) ([]config.Warning, []string, error) {
+	var varsStructure interface{}
+	if len(vars) > 0 {
+		varsStructure = map[string]interface{}(vars)
+	}
+
+	pipelineConfig, configTemplate, decodedVars, err := config.DecodeConfig(configStructure, varsStructure, nil)
+	if err != nil {
+		return nil, []string{err.Error()}, nil
+	}
+
+	pipelineRef := atc.PipelineRef{Name: pipelineName}
+
+	warnings, errorMessages, _, _, err := configsaver.SavePipeline(
+		saver.logger,
+		saver.validate,
+		pipelineConfig,
+		func() (db.SavedPipeline, bool, error) {
+			return saver.teamDB.SaveConfigFromBuild(
+				pipelineRef,
+				pipelineConfig,
+				configTemplate,
+				decodedVars,
+				from,
+				fmt.Sprintf("build %d", saver.buildID),
+				saver.buildID,
+			)
+		},
+	)
+	return warnings, errorMessages, err
+}
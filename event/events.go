@@ -43,8 +43,9 @@ type TaskRunConfig struct {
 }
 
 type TaskInputConfig struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Optional bool   `json:"optional,omitempty"`
 }
 
 func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
@@ -52,8 +53,9 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 
 	for _, input := range config.Inputs {
 		inputConfigs = append(inputConfigs, TaskInputConfig{
-			Name: input.Name,
-			Path: input.Path,
+			Name:     input.Name,
+			Path:     input.Path,
+			Optional: input.Optional,
 		})
 	}
 
@@ -35,6 +35,12 @@ func (configSource StaticConfigSource) FetchConfig(*SourceRepository) (atc.TaskC
 		taskConfig = *configSource.Plan.Config
 	}
 
+	if configSource.Plan.ContainerLimits != nil {
+		taskConfig = taskConfig.Merge(atc.TaskConfig{
+			Limits: configSource.Plan.ContainerLimits,
+		})
+	}
+
 	if configSource.Plan.Params == nil {
 		return taskConfig, nil
 	}
@@ -213,6 +219,35 @@ func (configSource ValidatingConfigSource) Warnings() []string {
 	return configSource.ConfigSource.Warnings()
 }
 
+// DefaultLimitsConfigSource delegates to another ConfigSource, and applies
+// default container limits to its task config.
+type DefaultLimitsConfigSource struct {
+	ConfigSource TaskConfigSource
+	Limits       atc.ContainerLimits
+}
+
+// FetchConfig fetches the config using the underlying ConfigSource, and fills
+// in any container limits it does not set with the defaults.
+func (configSource DefaultLimitsConfigSource) FetchConfig(source *SourceRepository) (atc.TaskConfig, error) {
+	config, err := configSource.ConfigSource.FetchConfig(source)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	limits := configSource.Limits
+	if config.Limits != nil {
+		limits = limits.Merge(*config.Limits)
+	}
+
+	config.Limits = &limits
+
+	return config, nil
+}
+
+func (configSource DefaultLimitsConfigSource) Warnings() []string {
+	return configSource.ConfigSource.Warnings()
+}
+
 // UnknownArtifactSourceError is returned when the SourceName specified by the
 // path does not exist in the SourceRepository.
 type UnknownArtifactSourceError struct {
@@ -188,6 +188,19 @@ var _ = Describe("ConfigSource", func() {
 			})
 		})
 
+		Context("when the task plan specifies container limits", func() {
+			BeforeEach(func() {
+				taskConfig.Limits = &atc.ContainerLimits{CPU: 512, Memory: 1024}
+				taskPlan.ContainerLimits = &atc.ContainerLimits{Memory: 2048}
+			})
+
+			It("overrides the limits set in the task config", func() {
+				fetchedConfig, err := configSource.FetchConfig(repo)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fetchedConfig.Limits).To(Equal(&atc.ContainerLimits{CPU: 512, Memory: 2048}))
+			})
+		})
+
 		Context("when the plan has no task config", func() {
 			BeforeEach(func() {
 				taskPlan.Config = nil
@@ -535,4 +548,67 @@ run: {path: a/file}
 			})
 		})
 	})
+
+	Describe("DefaultLimitsConfigSource", func() {
+		var (
+			fakeConfigSource *execfakes.FakeTaskConfigSource
+
+			configSource TaskConfigSource
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			fakeConfigSource = new(execfakes.FakeTaskConfigSource)
+
+			configSource = DefaultLimitsConfigSource{
+				ConfigSource: fakeConfigSource,
+				Limits:       atc.ContainerLimits{CPU: 512, Memory: 1024},
+			}
+		})
+
+		JustBeforeEach(func() {
+			fetchedConfig, fetchErr = configSource.FetchConfig(repo)
+		})
+
+		Context("when the config does not specify limits", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{Image: "some-image"}, nil)
+			})
+
+			It("uses the defaults", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig).To(Equal(atc.TaskConfig{
+					Image:  "some-image",
+					Limits: &atc.ContainerLimits{CPU: 512, Memory: 1024},
+				}))
+			})
+		})
+
+		Context("when the config specifies some limits", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{
+					Limits: &atc.ContainerLimits{Memory: 2048},
+				}, nil)
+			})
+
+			It("fills in the rest from the defaults", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig.Limits).To(Equal(&atc.ContainerLimits{CPU: 512, Memory: 2048}))
+			})
+		})
+
+		Context("when fetching the config fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(fetchErr).To(Equal(disaster))
+			})
+		})
+	})
 })
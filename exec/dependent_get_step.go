@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 )
 
@@ -20,6 +21,7 @@ type DependentGetStep struct {
 	session             resource.Session
 	tags                atc.Tags
 	teamID              int
+	variables           creds.Variables
 	delegate            ResourceDelegate
 	resourceFetcher     resource.Fetcher
 	resourceTypes       atc.ResourceTypes
@@ -36,6 +38,7 @@ func newDependentGetStep(
 	session resource.Session,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate ResourceDelegate,
 	resourceFetcher resource.Fetcher,
 	resourceTypes atc.ResourceTypes,
@@ -51,6 +54,7 @@ func newDependentGetStep(
 		session:             session,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		resourceFetcher:     resourceFetcher,
 		resourceTypes:       resourceTypes,
@@ -81,6 +85,7 @@ func (step DependentGetStep) Using(prev Step, repo *SourceRepository) Step {
 		step.session,
 		step.tags,
 		step.teamID,
+		step.variables,
 		step.delegate,
 		step.resourceFetcher,
 		step.resourceTypes,
@@ -13,6 +13,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -34,6 +36,7 @@ var _ = Describe("DependentGet", func() {
 		fakeFetchSource     *rfakes.FakeFetchSource
 		fakeCache           *rfakes.FakeCache
 		getDelegate         *execfakes.FakeGetDelegate
+		fakeSecrets         *credsfakes.FakeSecrets
 		resourceConfig      atc.ResourceConfig
 		params              atc.Params
 		version             atc.Version
@@ -83,6 +86,8 @@ var _ = Describe("DependentGet", func() {
 		successTTL = 3 * time.Second
 		failureTTL = 10 * time.Second
 
+		fakeSecrets = new(credsfakes.FakeSecrets)
+
 		getDelegate = new(execfakes.FakeGetDelegate)
 		getDelegate.StdoutReturns(stdoutBuf)
 		getDelegate.StderrReturns(stderrBuf)
@@ -140,6 +145,7 @@ var _ = Describe("DependentGet", func() {
 			resourceConfig,
 			tags,
 			teamID,
+			creds.NewVariables(fakeSecrets, "some-team", "some-pipeline"),
 			params,
 			resourceTypes,
 			successTTL,
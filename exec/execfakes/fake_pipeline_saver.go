@@ -0,0 +1,134 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/template"
+)
+
+type FakePipelineSaver struct {
+	ConfigVersionStub        func(pipelineName string) (db.ConfigVersion, error)
+	configVersionMutex       sync.RWMutex
+	configVersionArgsForCall []struct {
+		pipelineName string
+	}
+	configVersionReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	SavePipelineStub        func(pipelineName string, configStructure interface{}, vars template.Variables, from db.ConfigVersion) ([]config.Warning, []string, error)
+	savePipelineMutex       sync.RWMutex
+	savePipelineArgsForCall []struct {
+		pipelineName    string
+		configStructure interface{}
+		vars            template.Variables
+		from            db.ConfigVersion
+	}
+	savePipelineReturns struct {
+		result1 []config.Warning
+		result2 []string
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePipelineSaver) ConfigVersion(pipelineName string) (db.ConfigVersion, error) {
+	fake.configVersionMutex.Lock()
+	fake.configVersionArgsForCall = append(fake.configVersionArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("ConfigVersion", []interface{}{pipelineName})
+	fake.configVersionMutex.Unlock()
+	if fake.ConfigVersionStub != nil {
+		return fake.ConfigVersionStub(pipelineName)
+	} else {
+		return fake.configVersionReturns.result1, fake.configVersionReturns.result2
+	}
+}
+
+func (fake *FakePipelineSaver) ConfigVersionCallCount() int {
+	fake.configVersionMutex.RLock()
+	defer fake.configVersionMutex.RUnlock()
+	return len(fake.configVersionArgsForCall)
+}
+
+func (fake *FakePipelineSaver) ConfigVersionArgsForCall(i int) string {
+	fake.configVersionMutex.RLock()
+	defer fake.configVersionMutex.RUnlock()
+	return fake.configVersionArgsForCall[i].pipelineName
+}
+
+func (fake *FakePipelineSaver) ConfigVersionReturns(result1 db.ConfigVersion, result2 error) {
+	fake.ConfigVersionStub = nil
+	fake.configVersionReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineSaver) SavePipeline(pipelineName string, configStructure interface{}, vars template.Variables, from db.ConfigVersion) ([]config.Warning, []string, error) {
+	fake.savePipelineMutex.Lock()
+	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
+		pipelineName    string
+		configStructure interface{}
+		vars            template.Variables
+		from            db.ConfigVersion
+	}{pipelineName, configStructure, vars, from})
+	fake.recordInvocation("SavePipeline", []interface{}{pipelineName, configStructure, vars, from})
+	fake.savePipelineMutex.Unlock()
+	if fake.SavePipelineStub != nil {
+		return fake.SavePipelineStub(pipelineName, configStructure, vars, from)
+	} else {
+		return fake.savePipelineReturns.result1, fake.savePipelineReturns.result2, fake.savePipelineReturns.result3
+	}
+}
+
+func (fake *FakePipelineSaver) SavePipelineCallCount() int {
+	fake.savePipelineMutex.RLock()
+	defer fake.savePipelineMutex.RUnlock()
+	return len(fake.savePipelineArgsForCall)
+}
+
+func (fake *FakePipelineSaver) SavePipelineArgsForCall(i int) (string, interface{}, template.Variables, db.ConfigVersion) {
+	fake.savePipelineMutex.RLock()
+	defer fake.savePipelineMutex.RUnlock()
+	return fake.savePipelineArgsForCall[i].pipelineName, fake.savePipelineArgsForCall[i].configStructure, fake.savePipelineArgsForCall[i].vars, fake.savePipelineArgsForCall[i].from
+}
+
+func (fake *FakePipelineSaver) SavePipelineReturns(result1 []config.Warning, result2 []string, result3 error) {
+	fake.SavePipelineStub = nil
+	fake.savePipelineReturns = struct {
+		result1 []config.Warning
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineSaver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.configVersionMutex.RLock()
+	defer fake.configVersionMutex.RUnlock()
+	fake.savePipelineMutex.RLock()
+	defer fake.savePipelineMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakePipelineSaver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.PipelineSaver = new(FakePipelineSaver)
@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/worker"
 )
 
@@ -25,6 +26,7 @@ type Factory interface {
 		atc.ResourceConfig,
 		atc.Tags,
 		int,
+		creds.Variables,
 		atc.Params,
 		atc.Version,
 		atc.ResourceTypes,
@@ -42,6 +44,7 @@ type Factory interface {
 		atc.ResourceConfig,
 		atc.Tags,
 		int,
+		creds.Variables,
 		atc.Params,
 		atc.ResourceTypes,
 		time.Duration,
@@ -60,6 +63,7 @@ type Factory interface {
 		atc.ResourceConfig,
 		atc.Tags,
 		int,
+		creds.Variables,
 		atc.Params,
 		atc.ResourceTypes,
 		time.Duration,
@@ -76,6 +80,7 @@ type Factory interface {
 		Privileged,
 		atc.Tags,
 		int,
+		creds.Variables,
 		TaskConfigSource,
 		atc.ResourceTypes,
 		map[string]string,
@@ -140,6 +145,17 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . SetPipelineDelegate
+
+// SetPipelineDelegate is used to record events related to a SetPipelineStep's
+// runtime behavior.
+type SetPipelineDelegate interface {
+	Failed(error)
+
+	Stdout() io.Writer
+	Stderr() io.Writer
+}
+
 // Privileged is used to indicate whether the given step should run with
 // special privileges (i.e. as an administrator user).
 type Privileged bool
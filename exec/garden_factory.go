@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -48,6 +49,7 @@ func (factory *gardenFactory) DependentGet(
 	resourceConfig atc.ResourceConfig,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	params atc.Params,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
@@ -66,6 +68,7 @@ func (factory *gardenFactory) DependentGet(
 		},
 		tags,
 		teamID,
+		variables,
 		delegate,
 		factory.resourceFetcher,
 		resourceTypes,
@@ -84,6 +87,7 @@ func (factory *gardenFactory) Get(
 	resourceConfig atc.ResourceConfig,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	params atc.Params,
 	version atc.Version,
 	resourceTypes atc.ResourceTypes,
@@ -111,6 +115,7 @@ func (factory *gardenFactory) Get(
 		},
 		tags,
 		teamID,
+		variables,
 		delegate,
 		factory.resourceFetcher,
 		resourceTypes,
@@ -129,6 +134,7 @@ func (factory *gardenFactory) Put(
 	resourceConfig atc.ResourceConfig,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	params atc.Params,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
@@ -147,6 +153,7 @@ func (factory *gardenFactory) Put(
 		},
 		tags,
 		teamID,
+		variables,
 		delegate,
 		factory.tracker,
 		resourceTypes,
@@ -164,6 +171,7 @@ func (factory *gardenFactory) Task(
 	privileged Privileged,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	configSource TaskConfigSource,
 	resourceTypes atc.ResourceTypes,
 	inputMapping map[string]string,
@@ -181,6 +189,7 @@ func (factory *gardenFactory) Task(
 		workerMetadata,
 		tags,
 		teamID,
+		variables,
 		delegate,
 		privileged,
 		configSource,
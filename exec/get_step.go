@@ -11,6 +11,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -29,6 +30,7 @@ type GetStep struct {
 	session         resource.Session
 	tags            atc.Tags
 	teamID          int
+	variables       creds.Variables
 	delegate        GetDelegate
 	resourceFetcher resource.Fetcher
 	resourceTypes   atc.ResourceTypes
@@ -54,6 +56,7 @@ func newGetStep(
 	session resource.Session,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate GetDelegate,
 	resourceFetcher resource.Fetcher,
 	resourceTypes atc.ResourceTypes,
@@ -71,6 +74,7 @@ func newGetStep(
 		session:             session,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		resourceFetcher:     resourceFetcher,
 		resourceTypes:       resourceTypes,
@@ -119,21 +123,36 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	runSession := step.session
 	runSession.ID.Stage = db.ContainerStageRun
 
+	source, err := step.variables.EvaluateSource(step.resourceConfig.Source)
+	if err != nil {
+		return err
+	}
+
+	params, err := step.variables.EvaluateParams(step.params)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := step.variables.EvaluateResourceTypes(step.resourceTypes)
+	if err != nil {
+		return err
+	}
+
 	resourceDefinition := &getStepResource{
-		source:       step.resourceConfig.Source,
+		source:       source,
 		resourceType: resource.ResourceType(step.resourceConfig.Type),
 		delegate:     step.delegate,
-		params:       step.params,
+		variables:    step.variables,
+		params:       params,
 		version:      step.version,
 	}
 
-	var err error
 	step.fetchSource, err = step.resourceFetcher.Fetch(
 		step.logger,
 		runSession,
 		step.tags,
 		step.teamID,
-		step.resourceTypes,
+		resourceTypes,
 		step.cacheIdentifier,
 		step.stepMetadata,
 		step.delegate,
@@ -245,6 +264,7 @@ func (step *GetStep) registerAndReportResource() {
 
 type getStepResource struct {
 	delegate     GetDelegate
+	variables    creds.Variables
 	resourceType resource.ResourceType
 	source       atc.Source
 	params       atc.Params
@@ -253,8 +273,8 @@ type getStepResource struct {
 
 func (d *getStepResource) IOConfig() resource.IOConfig {
 	return resource.IOConfig{
-		Stdout: d.delegate.Stdout(),
-		Stderr: d.delegate.Stderr(),
+		Stdout: d.variables.Redact(d.delegate.Stdout()),
+		Stderr: d.variables.Redact(d.delegate.Stderr()),
 	}
 }
 
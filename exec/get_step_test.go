@@ -13,6 +13,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -36,6 +38,7 @@ var _ = Describe("Get", func() {
 		fakeWorker          *wfakes.FakeWorker
 		fakeVersionedSource *rfakes.FakeVersionedSource
 		fakeFetchSource     *rfakes.FakeFetchSource
+		fakeSecrets         *credsfakes.FakeSecrets
 
 		factory Factory
 
@@ -84,6 +87,8 @@ var _ = Describe("Get", func() {
 
 		getDelegate = new(execfakes.FakeGetDelegate)
 
+		fakeSecrets = new(credsfakes.FakeSecrets)
+
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
 		getDelegate.StdoutReturns(stdoutBuf)
@@ -134,6 +139,7 @@ var _ = Describe("Get", func() {
 			resourceConfig,
 			tags,
 			teamID,
+			creds.NewVariables(fakeSecrets, "some-team", "some-pipeline"),
 			params,
 			version,
 			resourceTypes,
@@ -395,6 +401,61 @@ var _ = Describe("Get", func() {
 		})
 	})
 
+	Context("when the source and params reference secrets", func() {
+		BeforeEach(func() {
+			resourceConfig.Source = atc.Source{"some": "((source-secret))"}
+			params = atc.Params{"some-param": "((params-secret))"}
+			resourceTypes = atc.ResourceTypes{
+				{
+					Name:   "custom-resource",
+					Type:   "custom-type",
+					Source: atc.Source{"some-custom": "((type-secret))"},
+				},
+			}
+
+			fakeSecrets.GetStub = func(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+				return "resolved-" + secretName, true, nil
+			}
+		})
+
+		It("fetches the resource with the secrets resolved", func() {
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+			_, _, _, _, actualResourceTypes, cacheID, _, _, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+			Expect(resourceOptions.Source()).To(Equal(atc.Source{"some": "resolved-source-secret"}))
+			Expect(resourceOptions.Params()).To(Equal(atc.Params{"some-param": "resolved-params-secret"}))
+			Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
+				{
+					Name:   "custom-resource",
+					Type:   "custom-type",
+					Source: atc.Source{"some-custom": "resolved-type-secret"},
+				},
+			}))
+
+			Expect(cacheID).To(Equal(resource.ResourceCacheIdentifier{
+				Type:    "some-resource-type",
+				Source:  atc.Source{"some": "((source-secret))"},
+				Params:  atc.Params{"some-param": "((params-secret))"},
+				Version: version,
+			}))
+		})
+
+		Context("when a secret is not defined", func() {
+			BeforeEach(func() {
+				fakeSecrets.GetStub = nil
+				fakeSecrets.GetReturns(nil, false, nil)
+			})
+
+			It("invokes the delegate's Failed callback without fetching", func() {
+				Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedSecretError{Name: "source-secret"})))
+
+				Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+
+				Expect(getDelegate.FailedCallCount()).To(Equal(1))
+				Expect(getDelegate.FailedArgsForCall(0)).To(Equal(creds.UndefinedSecretError{Name: "source-secret"}))
+			})
+		})
+	})
+
 	Context("when the tracker fails to initialize the resource", func() {
 		disaster := errors.New("nope")
 
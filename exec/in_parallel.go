@@ -0,0 +1,194 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// InParallel constructs a Step that will run each step in parallel, running
+// at most Limit of them at once. A Limit of zero runs every step at once.
+//
+// If FailFast is true, the remaining steps are interrupted (or never started)
+// as soon as one step fails or errors.
+type InParallel struct {
+	Steps    []StepFactory
+	Limit    int
+	FailFast bool
+}
+
+// Using delegates to each StepFactory and returns an *InParallelStep.
+func (p InParallel) Using(prev Step, repo *SourceRepository) Step {
+	steps := make([]Step, len(p.Steps))
+	for i, step := range p.Steps {
+		steps[i] = step.Using(prev, repo)
+	}
+
+	return &InParallelStep{
+		steps:    steps,
+		started:  make([]bool, len(steps)),
+		limit:    p.Limit,
+		failFast: p.FailFast,
+	}
+}
+
+// InParallelStep is a step of steps to run in parallel.
+type InParallelStep struct {
+	steps    []Step
+	started  []bool
+	limit    int
+	failFast bool
+}
+
+type inParallelResult struct {
+	index int
+	err   error
+}
+
+// Run executes the steps in parallel, starting the next step whenever a
+// running one exits so that no more than the limit are running at once. It
+// will indicate that it's ready immediately, and propagate any signal received
+// to all running steps, starting no further steps.
+//
+// Unless the step is set to fail fast, it will wait for all steps to exit,
+// even if one step fails or errors. After all steps finish, their errors (if
+// any) will be aggregated and returned as a single error.
+func (step *InParallelStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	limit := step.limit
+	if limit == 0 || limit > len(step.steps) {
+		limit = len(step.steps)
+	}
+
+	results := make(chan inParallelResult, len(step.steps))
+	running := map[int]ifrit.Process{}
+	next := 0
+
+	interrupted := false
+	failed := false
+
+	startSteps := func() {
+		for next < len(step.steps) && len(running) < limit {
+			index := next
+			next++
+
+			process := ifrit.Background(step.steps[index])
+			step.started[index] = true
+			running[index] = process
+
+			go func() {
+				results <- inParallelResult{index: index, err: <-process.Wait()}
+			}()
+		}
+	}
+
+	startSteps()
+
+	close(ready)
+
+	var errorMessages []string
+
+	for len(running) > 0 {
+		select {
+		case sig := <-signals:
+			interrupted = true
+
+			for _, process := range running {
+				process.Signal(sig)
+			}
+
+		case result := <-results:
+			delete(running, result.index)
+
+			if result.err != nil {
+				if !(failed && step.failFast && result.err == ErrInterrupted) {
+					errorMessages = append(errorMessages, result.err.Error())
+				}
+
+				failed = true
+			} else {
+				var succeeded Success
+				if step.steps[result.index].Result(&succeeded) && !bool(succeeded) {
+					failed = true
+				}
+			}
+
+			if interrupted {
+				continue
+			}
+
+			if failed && step.failFast {
+				next = len(step.steps)
+
+				for _, process := range running {
+					process.Signal(os.Interrupt)
+				}
+
+				continue
+			}
+
+			startSteps()
+		}
+	}
+
+	if interrupted {
+		return ErrInterrupted
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// Release iterates over the steps and Releases them individually.
+func (step *InParallelStep) Release() {
+	for _, src := range step.steps {
+		src.Release()
+	}
+}
+
+// Result indicates Success as true if all of the steps ran and indicate
+// Success as true, or if there were no steps at all. If a step was never
+// started because another failed first, it indicates Success as false. If
+// none of the steps can indicate Success, it will return false and not
+// indicate success itself.
+//
+// All other result types are ignored, and Result will return false.
+func (step *InParallelStep) Result(x interface{}) bool {
+	if success, ok := x.(*Success); ok {
+		if len(step.steps) == 0 {
+			*success = Success(true)
+			return true
+		}
+
+		succeeded := true
+		anyIndicated := false
+		for i, src := range step.steps {
+			if !step.started[i] {
+				succeeded = false
+				continue
+			}
+
+			var s Success
+			if !src.Result(&s) {
+				continue
+			}
+
+			anyIndicated = true
+			succeeded = succeeded && bool(s)
+		}
+
+		if !anyIndicated {
+			return false
+		}
+
+		*success = Success(succeeded)
+
+		return true
+	}
+
+	return false
+}
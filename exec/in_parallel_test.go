@@ -0,0 +1,330 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		inParallel InParallel
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		inParallel = InParallel{
+			Steps: []StepFactory{
+				fakeStepA,
+				fakeStepB,
+				fakeStepC,
+			},
+		}
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = inParallel.Using(inStep, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source for all steps", func() {
+		for _, fakeStep := range []*execfakes.FakeStepFactory{fakeStepA, fakeStepB, fakeStepC} {
+			Expect(fakeStep.UsingCallCount()).To(Equal(1))
+			step, repo := fakeStep.UsingArgsForCall(0)
+			Expect(step).To(Equal(inStep))
+			Expect(repo).To(Equal(repo))
+		}
+	})
+
+	It("exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	Describe("executing each step", func() {
+		Context("when there is no limit", func() {
+			BeforeEach(func() {
+				wg := new(sync.WaitGroup)
+				wg.Add(3)
+
+				run := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					wg.Done()
+					wg.Wait()
+					close(ready)
+					return nil
+				}
+
+				outStepA.RunStub = run
+				outStepB.RunStub = run
+				outStepC.RunStub = run
+			})
+
+			It("happens concurrently", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepA.RunCallCount()).To(Equal(1))
+				Expect(outStepB.RunCallCount()).To(Equal(1))
+				Expect(outStepC.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when there is a limit", func() {
+			var finishA chan struct{}
+			var finishB chan struct{}
+
+			BeforeEach(func() {
+				inParallel.Limit = 2
+
+				finishA = make(chan struct{})
+				finishB = make(chan struct{})
+
+				outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					<-finishA
+					return nil
+				}
+
+				outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					<-finishB
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				close(finishB)
+			})
+
+			It("only runs that many steps at once", func() {
+				Eventually(outStepA.RunCallCount).Should(Equal(1))
+				Eventually(outStepB.RunCallCount).Should(Equal(1))
+				Consistently(outStepC.RunCallCount).Should(BeZero())
+
+				close(finishA)
+
+				Eventually(outStepC.RunCallCount).Should(Equal(1))
+			})
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+		var actuallyExit chan struct{}
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+
+			receivedSignals = make(chan os.Signal, 2)
+			actuallyExit = make(chan struct{}, 1)
+
+			run := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				<-actuallyExit
+				return ErrInterrupted
+			}
+
+			outStepA.RunStub = run
+			outStepB.RunStub = run
+		})
+
+		It("returns ErrInterrupted without starting any more steps", func() {
+			process.Signal(os.Interrupt)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Consistently(process.Wait()).ShouldNot(Receive())
+			close(actuallyExit)
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+			Expect(outStepC.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when steps fail", func() {
+		disasterA := errors.New("nope A")
+		disasterB := errors.New("nope B")
+
+		BeforeEach(func() {
+			outStepA.RunReturns(disasterA)
+			outStepB.RunReturns(disasterB)
+		})
+
+		It("exits with an error including the original message", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+
+			Expect(err.Error()).To(ContainSubstring("nope A"))
+			Expect(err.Error()).To(ContainSubstring("nope B"))
+		})
+
+		It("still runs the remaining steps", func() {
+			Eventually(process.Wait()).Should(Receive())
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when failing fast", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+			inParallel.FailFast = true
+
+			receivedSignals = make(chan os.Signal, 1)
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+
+			outStepA.ResultReturns(false)
+		})
+
+		Context("when a step fails", func() {
+			BeforeEach(func() {
+				outStepB.ResultStub = successResult(false)
+			})
+
+			It("interrupts the running steps and starts no more", func() {
+				Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("does not succeed", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var result Success
+				Expect(step.Result(&result)).To(BeTrue())
+				Expect(result).To(Equal(Success(false)))
+			})
+		})
+
+		Context("when a step errors", func() {
+			BeforeEach(func() {
+				outStepB.RunReturns(errors.New("nope B"))
+			})
+
+			It("interrupts the running steps and returns only the original error", func() {
+				Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err.Error()).To(ContainSubstring("nope B"))
+				Expect(err.Error()).NotTo(ContainSubstring(ErrInterrupted.Error()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("releasing", func() {
+		It("releases all steps", func() {
+			step.Release()
+
+			Expect(outStepA.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepB.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepC.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("getting a result", func() {
+		JustBeforeEach(func() {
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		Context("when the result type is bad", func() {
+			It("returns false", func() {
+				result := "this-is-bad"
+				Expect(step.Result(&result)).To(BeFalse())
+			})
+		})
+
+		Context("when getting a Success result", func() {
+			var result Success
+
+			BeforeEach(func() {
+				result = false
+			})
+
+			Context("and all steps are successful", func() {
+				BeforeEach(func() {
+					outStepA.ResultStub = successResult(true)
+					outStepB.ResultStub = successResult(true)
+					outStepC.ResultStub = successResult(true)
+				})
+
+				It("yields true", func() {
+					Expect(step.Result(&result)).To(BeTrue())
+					Expect(result).To(Equal(Success(true)))
+				})
+			})
+
+			Context("and some steps are not successful", func() {
+				BeforeEach(func() {
+					outStepA.ResultStub = successResult(true)
+					outStepB.ResultStub = successResult(false)
+					outStepC.ResultStub = successResult(true)
+				})
+
+				It("yields false", func() {
+					Expect(step.Result(&result)).To(BeTrue())
+					Expect(result).To(Equal(Success(false)))
+				})
+			})
+
+			Context("when no steps indicate success", func() {
+				It("returns false", func() {
+					Expect(step.Result(&result)).To(BeFalse())
+					Expect(result).To(Equal(Success(false)))
+				})
+			})
+
+			Context("when there are no steps", func() {
+				BeforeEach(func() {
+					inParallel = InParallel{}
+				})
+
+				It("returns true", func() {
+					Expect(step.Result(&result)).To(BeTrue())
+					Expect(result).To(Equal(Success(true)))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,160 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+// UnknownVarFormatError is returned when a LoadVarStep is configured with a
+// format it does not know how to parse.
+type UnknownVarFormatError struct {
+	Format string
+}
+
+// Error returns a human-friendly error message.
+func (err UnknownVarFormatError) Error() string {
+	return fmt.Sprintf("unknown var format: %s", err.Format)
+}
+
+// LoadVarStep reads a file from the SourceRepository and sets its contents as
+// a local var, available to later steps as ((.:name)).
+type LoadVarStep struct {
+	plan      atc.LoadVarPlan
+	variables creds.Variables
+	repo      *SourceRepository
+
+	succeeded bool
+}
+
+// LoadVar constructs a LoadVarStep factory.
+func LoadVar(plan atc.LoadVarPlan, variables creds.Variables) LoadVarStep {
+	return LoadVarStep{
+		plan:      plan,
+		variables: variables,
+	}
+}
+
+// Using constructs a *LoadVarStep.
+func (step LoadVarStep) Using(prev Step, repo *SourceRepository) Step {
+	step.repo = repo
+	return &step
+}
+
+// Run streams the file out of the SourceRepository, parses it, and sets the
+// local var.
+//
+// The file must be given in the format SOURCE_NAME/FILE/PATH, as with
+// FileConfigSource, and is parsed according to the plan's format:
+//
+//	raw:  the contents as-is
+//	trim: the contents with leading and trailing whitespace removed
+//	json: the contents decoded as JSON
+//	yaml: the contents decoded as YAML
+//
+// If no format is given, it is inferred from the file's extension, falling
+// back to trim.
+func (step *LoadVarStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	payload, err := step.repo.readFile(step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	value, err := parseVar(step.format(), payload)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", step.plan.File, err)
+	}
+
+	step.variables.SetLocal(step.plan.Name, value, step.plan.Sensitive)
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Release is a no-op.
+func (step *LoadVarStep) Release() {}
+
+// Result indicates Success as true if the var was loaded.
+//
+// All other types are ignored.
+func (step *LoadVarStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+	}
+
+	return false
+}
+
+func (step *LoadVarStep) format() string {
+	if step.plan.Format != "" {
+		return step.plan.Format
+	}
+
+	switch filepath.Ext(step.plan.File) {
+	case ".json":
+		return "json"
+	case ".yml", ".yaml":
+		return "yaml"
+	default:
+		return "trim"
+	}
+}
+
+func parseVar(format string, payload []byte) (interface{}, error) {
+	switch format {
+	case "raw":
+		return string(payload), nil
+
+	case "trim":
+		return strings.TrimSpace(string(payload)), nil
+
+	case "json":
+		var value interface{}
+		err := json.Unmarshal(payload, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+
+	case "yaml":
+		var raw interface{}
+		err := yaml.Unmarshal(payload, &raw)
+		if err != nil {
+			return nil, err
+		}
+
+		// convert YAML's map[interface{}]interface{} so that the value can be
+		// marshalled as JSON
+		var sanitized map[string]interface{}
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:     &sanitized,
+			DecodeHook: atc.SanitizeDecodeHook,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = decoder.Decode(map[interface{}]interface{}{"value": raw})
+		if err != nil {
+			return nil, err
+		}
+
+		return sanitized["value"], nil
+
+	default:
+		return nil, UnknownVarFormatError{format}
+	}
+}
@@ -0,0 +1,208 @@
+package exec_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("LoadVarStep", func() {
+	var (
+		fakeSource *execfakes.FakeArtifactSource
+		repo       *SourceRepository
+		variables  creds.Variables
+
+		plan atc.LoadVarPlan
+		step Step
+
+		fileContent string
+		streamErr   error
+		runErr      error
+	)
+
+	BeforeEach(func() {
+		fakeSource = new(execfakes.FakeArtifactSource)
+		repo = NewSourceRepository()
+		repo.RegisterSource("some-source", fakeSource)
+
+		variables = creds.NewVariables(nil, "some-team", "some-pipeline")
+
+		plan = atc.LoadVarPlan{
+			Name: "some-var",
+			File: "some-source/some-file",
+		}
+
+		fileContent = "  some-value\n"
+		streamErr = nil
+	})
+
+	JustBeforeEach(func() {
+		fakeSource.StreamFileStub = func(path string) (io.ReadCloser, error) {
+			if streamErr != nil {
+				return nil, streamErr
+			}
+
+			return ioutil.NopCloser(strings.NewReader(fileContent)), nil
+		}
+
+		step = LoadVar(plan, variables).Using(nil, repo)
+		runErr = <-ifrit.Invoke(step).Wait()
+	})
+
+	loadedVar := func() interface{} {
+		params, err := variables.EvaluateParams(atc.Params{"var": "((.:some-var))"})
+		Expect(err).NotTo(HaveOccurred())
+		return params["var"]
+	}
+
+	It("streams the file out of the source", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeSource.StreamFileCallCount()).To(Equal(1))
+		Expect(fakeSource.StreamFileArgsForCall(0)).To(Equal("some-file"))
+	})
+
+	It("trims the file's contents by default", func() {
+		Expect(loadedVar()).To(Equal("some-value"))
+	})
+
+	It("succeeds", func() {
+		var success Success
+		Expect(step.Result(&success)).To(BeTrue())
+		Expect(success).To(Equal(Success(true)))
+	})
+
+	Context("when the format is raw", func() {
+		BeforeEach(func() {
+			plan.Format = "raw"
+		})
+
+		It("loads the contents as-is", func() {
+			Expect(loadedVar()).To(Equal("  some-value\n"))
+		})
+	})
+
+	Context("when the format is json", func() {
+		BeforeEach(func() {
+			plan.Format = "json"
+			fileContent = `{"version": "1.2.3", "build": 42}`
+		})
+
+		It("decodes the contents", func() {
+			Expect(loadedVar()).To(Equal(map[string]interface{}{
+				"version": "1.2.3",
+				"build":   float64(42),
+			}))
+		})
+
+		Context("when the contents are invalid", func() {
+			BeforeEach(func() {
+				fileContent = "{"
+			})
+
+			It("returns an error", func() {
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(ContainSubstring("failed to load some-source/some-file"))
+			})
+
+			It("does not succeed", func() {
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+		})
+	})
+
+	Context("when the format is yaml", func() {
+		BeforeEach(func() {
+			plan.Format = "yaml"
+			fileContent = "version: 1.2.3\ntags: [a, b]\nnested: {key: value}\n"
+		})
+
+		It("decodes the contents, with maps that can be marshalled as JSON", func() {
+			Expect(loadedVar()).To(Equal(map[string]interface{}{
+				"version": "1.2.3",
+				"tags":    []interface{}{"a", "b"},
+				"nested":  map[string]interface{}{"key": "value"},
+			}))
+		})
+	})
+
+	Context("when the format is not given and the file has a .json extension", func() {
+		BeforeEach(func() {
+			plan.File = "some-source/some-file.json"
+			fileContent = `["a", "b"]`
+		})
+
+		It("decodes the contents as JSON", func() {
+			Expect(loadedVar()).To(Equal([]interface{}{"a", "b"}))
+		})
+	})
+
+	Context("when the format is unknown", func() {
+		BeforeEach(func() {
+			plan.Format = "toml"
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(ContainSubstring(UnknownVarFormatError{"toml"}.Error()))
+		})
+	})
+
+	Context("when the var is sensitive", func() {
+		BeforeEach(func() {
+			plan.Sensitive = true
+		})
+
+		It("is redacted from output", func() {
+			buf := new(bytes.Buffer)
+			_, err := variables.Redact(buf).Write([]byte("value: some-value"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("value: ((redacted))"))
+		})
+	})
+
+	Context("when the file has no source name", func() {
+		BeforeEach(func() {
+			plan.File = "some-file"
+		})
+
+		It("returns an UnspecifiedArtifactSourceError", func() {
+			Expect(runErr).To(Equal(UnspecifiedArtifactSourceError{"some-file"}))
+		})
+	})
+
+	Context("when the source does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-source/some-file"
+		})
+
+		It("returns an UnknownArtifactSourceError", func() {
+			Expect(runErr).To(Equal(UnknownArtifactSourceError{"bogus-source"}))
+		})
+	})
+
+	Context("when streaming the file fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			streamErr = disaster
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package exec
+
+import "os"
+
+// OnAbortStep will run one step, and then a second step if the first step is
+// interrupted because the build was aborted.
+type OnAbortStep struct {
+	stepFactory  StepFactory
+	abortFactory StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	step  Step
+	abort Step
+}
+
+// OnAbort constructs an OnAbortStep factory.
+func OnAbort(firstStep StepFactory, secondStep StepFactory) OnAbortStep {
+	return OnAbortStep{
+		stepFactory:  firstStep,
+		abortFactory: secondStep,
+	}
+}
+
+// Using constructs an *OnAbortStep.
+func (o OnAbortStep) Using(prev Step, repo *SourceRepository) Step {
+	o.repo = repo
+	o.prev = prev
+
+	o.step = o.stepFactory.Using(o.prev, o.repo)
+	return &o
+}
+
+// Run will call Run on the first step and wait for it to complete.
+// OnAbortStep is ready as soon as the first step is ready.
+//
+// If the first step returns ErrInterrupted, the second step is executed and
+// ErrInterrupted is returned, unless the second step errors, in which case its
+// error is returned. Any other error from the first step is returned as-is.
+func (o *OnAbortStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	stepRunErr := o.step.Run(signals, ready)
+	if stepRunErr != ErrInterrupted {
+		return stepRunErr
+	}
+
+	o.abort = o.abortFactory.Using(o.step, o.repo)
+
+	err := o.abort.Run(signals, make(chan struct{}))
+	if err != nil {
+		return err
+	}
+
+	return ErrInterrupted
+}
+
+// Result indicates Success as false if the first step was interrupted, and
+// otherwise delegates to the first step.
+//
+// Any other type is ignored.
+func (o *OnAbortStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		if o.abort != nil {
+			*v = false
+			return true
+		}
+
+		return o.step.Result(v)
+
+	default:
+		return false
+	}
+}
+
+// Release releases both steps.
+func (o *OnAbortStep) Release() {
+	if o.step != nil {
+		o.step.Release()
+	}
+
+	if o.abort != nil {
+		o.abort.Release()
+	}
+}
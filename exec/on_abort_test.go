@@ -0,0 +1,159 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+)
+
+var _ = Describe("On Abort Step", func() {
+	var (
+		stepFactory  *execfakes.FakeStepFactory
+		abortFactory *execfakes.FakeStepFactory
+
+		step *execfakes.FakeStep
+		hook *execfakes.FakeStep
+
+		previousStep *execfakes.FakeStep
+
+		repo *exec.SourceRepository
+
+		onAbortFactory exec.StepFactory
+		onAbortStep    exec.Step
+	)
+
+	BeforeEach(func() {
+		stepFactory = &execfakes.FakeStepFactory{}
+		abortFactory = &execfakes.FakeStepFactory{}
+
+		step = &execfakes.FakeStep{}
+		hook = &execfakes.FakeStep{}
+
+		previousStep = &execfakes.FakeStep{}
+
+		stepFactory.UsingReturns(step)
+		abortFactory.UsingReturns(hook)
+
+		repo = exec.NewSourceRepository()
+
+		onAbortFactory = exec.OnAbort(stepFactory, abortFactory)
+		onAbortStep = onAbortFactory.Using(previousStep, repo)
+	})
+
+	It("runs the abort hook if the step is interrupted", func() {
+		step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+
+			<-signals
+			return exec.ErrInterrupted
+		}
+
+		process := ifrit.Background(onAbortStep)
+
+		process.Signal(os.Kill)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(hook.RunCallCount).Should(Equal(1))
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+	})
+
+	It("provides the step as the previous step to the hook", func() {
+		step.RunReturns(exec.ErrInterrupted)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(abortFactory.UsingCallCount).Should(Equal(1))
+
+		argsPrev, argsRepo := abortFactory.UsingArgsForCall(0)
+		Expect(argsPrev).To(Equal(step))
+		Expect(argsRepo).To(Equal(repo))
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+	})
+
+	It("returns the hook's error if the hook errors", func() {
+		step.RunReturns(exec.ErrInterrupted)
+		hook.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(process.Wait()).Should(Receive(errorMatching("disaster")))
+	})
+
+	It("does not run the abort hook if the step errors", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(errorMatching("disaster")))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the abort hook if the step fails", func() {
+		step.ResultStub = successResult(false)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	Describe("Result", func() {
+		var signals chan os.Signal
+		var ready chan struct{}
+
+		BeforeEach(func() {
+			signals = make(chan os.Signal, 1)
+			ready = make(chan struct{}, 1)
+		})
+
+		Context("when the step is interrupted and the hook succeeds", func() {
+			BeforeEach(func() {
+				step.RunReturns(exec.ErrInterrupted)
+				hook.ResultStub = successResult(true)
+			})
+
+			It("does not indicate success", func() {
+				var succeeded exec.Success
+				onAbortStep.Run(signals, ready)
+				Expect(onAbortStep.Result(&succeeded)).To(BeTrue())
+				Expect(bool(succeeded)).To(BeFalse())
+			})
+		})
+
+		Context("when the step is not interrupted", func() {
+			BeforeEach(func() {
+				step.ResultStub = successResult(true)
+			})
+
+			It("indicates the step's result", func() {
+				var succeeded exec.Success
+				onAbortStep.Run(signals, ready)
+				Expect(onAbortStep.Result(&succeeded)).To(BeTrue())
+				Expect(bool(succeeded)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Release", func() {
+		It("releases both steps once the hook has run", func() {
+			step.RunReturns(exec.ErrInterrupted)
+
+			onAbortStep.Run(make(chan os.Signal, 1), make(chan struct{}, 1))
+			onAbortStep.Release()
+
+			Expect(step.ReleaseCallCount()).To(Equal(1))
+			Expect(hook.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+)
+
+var _ = Describe("On Error Step", func() {
+	var (
+		stepFactory  *execfakes.FakeStepFactory
+		errorFactory *execfakes.FakeStepFactory
+
+		step *execfakes.FakeStep
+		hook *execfakes.FakeStep
+
+		previousStep *execfakes.FakeStep
+
+		repo *exec.SourceRepository
+
+		onErrorFactory exec.StepFactory
+		onErrorStep    exec.Step
+	)
+
+	BeforeEach(func() {
+		stepFactory = &execfakes.FakeStepFactory{}
+		errorFactory = &execfakes.FakeStepFactory{}
+
+		step = &execfakes.FakeStep{}
+		hook = &execfakes.FakeStep{}
+
+		previousStep = &execfakes.FakeStep{}
+
+		stepFactory.UsingReturns(step)
+		errorFactory.UsingReturns(hook)
+
+		repo = exec.NewSourceRepository()
+
+		onErrorFactory = exec.OnError(stepFactory, errorFactory)
+		onErrorStep = onErrorFactory.Using(previousStep, repo)
+	})
+
+	It("runs the error hook if the step errors, and returns the step's error", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(hook.RunCallCount).Should(Equal(1))
+
+		Eventually(process.Wait()).Should(Receive(errorMatching("disaster")))
+	})
+
+	It("provides the step as the previous step to the hook", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(errorFactory.UsingCallCount).Should(Equal(1))
+
+		argsPrev, argsRepo := errorFactory.UsingArgsForCall(0)
+		Expect(argsPrev).To(Equal(step))
+		Expect(argsRepo).To(Equal(repo))
+
+		Eventually(process.Wait()).Should(Receive(errorMatching("disaster")))
+	})
+
+	It("returns both errors if the hook also errors", func() {
+		step.RunReturns(errors.New("disaster"))
+		hook.RunReturns(errors.New("hook disaster"))
+
+		process := ifrit.Background(onErrorStep)
+
+		var err error
+		Eventually(process.Wait()).Should(Receive(&err))
+		Expect(err.Error()).To(ContainSubstring("disaster"))
+		Expect(err.Error()).To(ContainSubstring("hook disaster"))
+	})
+
+	It("does not run the error hook if the step fails", func() {
+		step.ResultStub = successResult(false)
+
+		process := ifrit.Background(onErrorStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the error hook if the step is interrupted", func() {
+		step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+
+			<-signals
+			return exec.ErrInterrupted
+		}
+
+		process := ifrit.Background(onErrorStep)
+
+		process.Signal(os.Kill)
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	Describe("Result", func() {
+		var signals chan os.Signal
+		var ready chan struct{}
+
+		BeforeEach(func() {
+			signals = make(chan os.Signal, 1)
+			ready = make(chan struct{}, 1)
+		})
+
+		Context("when the step errors and the hook succeeds", func() {
+			BeforeEach(func() {
+				step.RunReturns(errors.New("disaster"))
+				hook.ResultStub = successResult(true)
+			})
+
+			It("does not indicate success", func() {
+				var succeeded exec.Success
+				onErrorStep.Run(signals, ready)
+				Expect(onErrorStep.Result(&succeeded)).To(BeTrue())
+				Expect(bool(succeeded)).To(BeFalse())
+			})
+		})
+
+		Context("when the step succeeds", func() {
+			BeforeEach(func() {
+				step.ResultStub = successResult(true)
+			})
+
+			It("indicates the step's result", func() {
+				var succeeded exec.Success
+				onErrorStep.Run(signals, ready)
+				Expect(onErrorStep.Result(&succeeded)).To(BeTrue())
+				Expect(bool(succeeded)).To(BeTrue())
+			})
+		})
+	})
+})
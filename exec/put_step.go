@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -23,6 +24,7 @@ type PutStep struct {
 	session        resource.Session
 	tags           atc.Tags
 	teamID         int
+	variables      creds.Variables
 	delegate       PutDelegate
 	tracker        resource.Tracker
 	resourceTypes  atc.ResourceTypes
@@ -47,6 +49,7 @@ func newPutStep(
 	session resource.Session,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate PutDelegate,
 	tracker resource.Tracker,
 	resourceTypes atc.ResourceTypes,
@@ -61,6 +64,7 @@ func newPutStep(
 		session:             session,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		tracker:             tracker,
 		resourceTypes:       resourceTypes,
@@ -91,6 +95,21 @@ func (step PutStep) Using(prev Step, repo *SourceRepository) Step {
 func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	step.delegate.Initializing()
 
+	source, err := step.variables.EvaluateSource(step.resourceConfig.Source)
+	if err != nil {
+		return err
+	}
+
+	params, err := step.variables.EvaluateParams(step.params)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := step.variables.EvaluateResourceTypes(step.resourceTypes)
+	if err != nil {
+		return err
+	}
+
 	sources := step.repository.AsMap()
 
 	resourceSources := make(map[string]resource.ArtifactSource)
@@ -109,7 +128,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.tags,
 		step.teamID,
 		resourceSources,
-		step.resourceTypes,
+		resourceTypes,
 		step.delegate,
 	)
 
@@ -138,11 +157,11 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	step.versionedSource, err = step.resource.Put(
 		resource.IOConfig{
-			Stdout: step.delegate.Stdout(),
-			Stderr: step.delegate.Stderr(),
+			Stdout: step.variables.Redact(step.delegate.Stdout()),
+			Stderr: step.variables.Redact(step.delegate.Stderr()),
 		},
-		step.resourceConfig.Source,
-		step.params,
+		source,
+		params,
 		artifactSource,
 		signals,
 		ready,
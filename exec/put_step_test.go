@@ -11,6 +11,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -63,6 +65,7 @@ var _ = Describe("GardenFactory", func() {
 	Describe("Put", func() {
 		var (
 			putDelegate    *execfakes.FakePutDelegate
+			fakeSecrets    *credsfakes.FakeSecrets
 			resourceConfig atc.ResourceConfig
 			params         atc.Params
 			tags           []string
@@ -79,6 +82,8 @@ var _ = Describe("GardenFactory", func() {
 		)
 
 		BeforeEach(func() {
+			fakeSecrets = new(credsfakes.FakeSecrets)
+
 			putDelegate = new(execfakes.FakePutDelegate)
 			putDelegate.StdoutReturns(stdoutBuf)
 			putDelegate.StderrReturns(stderrBuf)
@@ -117,6 +122,7 @@ var _ = Describe("GardenFactory", func() {
 				resourceConfig,
 				tags,
 				teamID,
+				creds.NewVariables(fakeSecrets, "some-team", "some-pipeline"),
 				params,
 				resourceTypes,
 				successTTL,
@@ -236,6 +242,61 @@ var _ = Describe("GardenFactory", func() {
 					Expect(fakeMountedSource.StreamToCallCount()).To(Equal(0))
 				})
 
+				Context("when the source and params reference secrets", func() {
+					BeforeEach(func() {
+						resourceConfig.Source = atc.Source{"some": "((source-secret))"}
+						params = atc.Params{"some-param": "((params-secret))"}
+						resourceTypes = atc.ResourceTypes{
+							{
+								Name:   "custom-resource",
+								Type:   "custom-type",
+								Source: atc.Source{"some-custom": "((type-secret))"},
+							},
+						}
+
+						fakeSecrets.GetStub = func(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+							return "resolved-" + secretName, true, nil
+						}
+					})
+
+					It("puts the resource with the secrets resolved", func() {
+						Expect(fakeResource.PutCallCount()).To(Equal(1))
+
+						_, putSource, putParams, _, _, _ := fakeResource.PutArgsForCall(0)
+						Expect(putSource).To(Equal(atc.Source{"some": "resolved-source-secret"}))
+						Expect(putParams).To(Equal(atc.Params{"some-param": "resolved-params-secret"}))
+					})
+
+					It("initializes the resource with the resource types' secrets resolved", func() {
+						Expect(fakeTracker.InitWithSourcesCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, _, actualResourceTypes, _ := fakeTracker.InitWithSourcesArgsForCall(0)
+						Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
+							{
+								Name:   "custom-resource",
+								Type:   "custom-type",
+								Source: atc.Source{"some-custom": "resolved-type-secret"},
+							},
+						}))
+					})
+
+					Context("when a secret is not defined", func() {
+						BeforeEach(func() {
+							fakeSecrets.GetStub = nil
+							fakeSecrets.GetReturns(nil, false, nil)
+						})
+
+						It("invokes the delegate's Failed callback without initializing the resource", func() {
+							Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedSecretError{Name: "source-secret"})))
+
+							Expect(fakeTracker.InitWithSourcesCallCount()).To(BeZero())
+
+							Expect(putDelegate.FailedCallCount()).To(Equal(1))
+							Expect(putDelegate.FailedArgsForCall(0)).To(Equal(creds.UndefinedSecretError{Name: "source-secret"}))
+						})
+					})
+				})
+
 				It("puts the resource with the io config forwarded", func() {
 					Expect(fakeResource.PutCallCount()).To(Equal(1))
 
@@ -0,0 +1,188 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . PipelineSaver
+
+// PipelineSaver validates and saves pipeline configs in the build's team.
+type PipelineSaver interface {
+	// ConfigVersion returns the current config version of the named pipeline,
+	// to be passed back to SavePipeline.
+	ConfigVersion(pipelineName string) (db.ConfigVersion, error)
+
+	// SavePipeline interpolates the vars into the config, as decoded from
+	// YAML, and saves it as the named pipeline, provided its config is still
+	// at the given version. If the config is invalid, the validation errors
+	// are returned and nothing is saved.
+	SavePipeline(pipelineName string, configStructure interface{}, vars template.Variables, from db.ConfigVersion) ([]config.Warning, []string, error)
+}
+
+// SetPipelineStep configures a pipeline in the build's team with a config
+// file from the SourceRepository.
+type SetPipelineStep struct {
+	plan     atc.SetPipelinePlan
+	delegate SetPipelineDelegate
+	saver    PipelineSaver
+	repo     *SourceRepository
+
+	succeeded bool
+}
+
+// SetPipeline constructs a SetPipelineStep factory.
+func SetPipeline(
+	plan atc.SetPipelinePlan,
+	delegate SetPipelineDelegate,
+	saver PipelineSaver,
+) SetPipelineStep {
+	return SetPipelineStep{
+		plan:     plan,
+		delegate: delegate,
+		saver:    saver,
+	}
+}
+
+// Using constructs a *SetPipelineStep.
+func (step SetPipelineStep) Using(prev Step, repo *SourceRepository) Step {
+	step.repo = repo
+	return &step
+}
+
+// Run reads the pipeline config and var files out of the SourceRepository and
+// saves the pipeline.
+//
+// Vars are taken from each of the var files in order, and then the vars given
+// in the plan, with later values taking precedence. Vars are saved as given;
+// any ((secrets)) they refer to are left to be resolved when the pipeline runs.
+//
+// The pipeline is only saved if its config has not changed since the step
+// started.
+//
+// Any validation warnings are written to stdout. If the config is invalid,
+// the validation errors are written to stderr and the step fails.
+func (step *SetPipelineStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	err := step.run()
+	if err != nil {
+		step.delegate.Failed(err)
+		return err
+	}
+
+	return nil
+}
+
+func (step *SetPipelineStep) run() error {
+	version, err := step.saver.ConfigVersion(step.plan.Name)
+	if err != nil {
+		return err
+	}
+
+	payload, err := step.repo.readFile(step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	var configStructure interface{}
+	err = yaml.Unmarshal(payload, &configStructure)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", step.plan.File, err)
+	}
+
+	vars := template.Variables{}
+
+	for _, varFile := range step.plan.VarFiles {
+		payload, err := step.repo.readFile(varFile)
+		if err != nil {
+			return err
+		}
+
+		var fileVars template.Variables
+		err = loadVars(payload, &fileVars)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %s", varFile, err)
+		}
+
+		for name, val := range fileVars {
+			vars[name] = val
+		}
+	}
+
+	for name, val := range step.plan.Vars {
+		vars[name] = val
+	}
+
+	if len(vars) == 0 {
+		vars = nil
+	}
+
+	warnings, errorMessages, err := step.saver.SavePipeline(step.plan.Name, configStructure, vars, version)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintf(step.delegate.Stdout(), "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintf(step.delegate.Stderr(), "invalid pipeline config:\n")
+
+		for _, message := range errorMessages {
+			fmt.Fprintf(step.delegate.Stderr(), "%s\n", message)
+		}
+
+		return nil
+	}
+
+	fmt.Fprintf(step.delegate.Stdout(), "configured pipeline %s\n", step.plan.Name)
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Release is a no-op.
+func (step *SetPipelineStep) Release() {}
+
+// Result indicates Success as true if the pipeline was saved.
+//
+// All other types are ignored.
+func (step *SetPipelineStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+	}
+
+	return false
+}
+
+func loadVars(payload []byte, vars *template.Variables) error {
+	var raw interface{}
+	err := yaml.Unmarshal(payload, &raw)
+	if err != nil {
+		return err
+	}
+
+	// convert YAML's map[interface{}]interface{} so that the vars can be
+	// saved as JSON
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     vars,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(raw)
+}
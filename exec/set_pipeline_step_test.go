@@ -0,0 +1,222 @@
+package exec_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/template"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		fakeSource   *execfakes.FakeArtifactSource
+		fakeDelegate *execfakes.FakeSetPipelineDelegate
+		fakeSaver    *execfakes.FakePipelineSaver
+		repo         *SourceRepository
+
+		stdoutBuf *bytes.Buffer
+		stderrBuf *bytes.Buffer
+
+		plan atc.SetPipelinePlan
+		step Step
+
+		files  map[string]string
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeSource = new(execfakes.FakeArtifactSource)
+		repo = NewSourceRepository()
+		repo.RegisterSource("some-source", fakeSource)
+
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		stdoutBuf = new(bytes.Buffer)
+		stderrBuf = new(bytes.Buffer)
+		fakeDelegate.StdoutReturns(stdoutBuf)
+		fakeDelegate.StderrReturns(stderrBuf)
+
+		fakeSaver = new(execfakes.FakePipelineSaver)
+		fakeSaver.ConfigVersionReturns(db.ConfigVersion(42), nil)
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-child-pipeline",
+			File: "some-source/pipeline.yml",
+		}
+
+		files = map[string]string{
+			"pipeline.yml": "jobs:\n- name: some-job\n",
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeSource.StreamFileStub = func(path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, errors.New("file not found: " + path)
+			}
+
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		step = SetPipeline(plan, fakeDelegate, fakeSaver).Using(nil, repo)
+		runErr = <-ifrit.Invoke(step).Wait()
+	})
+
+	It("saves the pipeline config read from the source", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeSaver.SavePipelineCallCount()).To(Equal(1))
+
+		name, configStructure, vars, _ := fakeSaver.SavePipelineArgsForCall(0)
+		Expect(name).To(Equal("some-child-pipeline"))
+		Expect(configStructure).To(Equal(map[interface{}]interface{}{
+			"jobs": []interface{}{
+				map[interface{}]interface{}{"name": "some-job"},
+			},
+		}))
+		Expect(vars).To(BeNil())
+	})
+
+	It("saves over the config version from when the step started", func() {
+		Expect(fakeSaver.ConfigVersionCallCount()).To(Equal(1))
+		Expect(fakeSaver.ConfigVersionArgsForCall(0)).To(Equal("some-child-pipeline"))
+
+		_, _, _, from := fakeSaver.SavePipelineArgsForCall(0)
+		Expect(from).To(Equal(db.ConfigVersion(42)))
+	})
+
+	It("logs that the pipeline was configured", func() {
+		Expect(stdoutBuf.String()).To(Equal("configured pipeline some-child-pipeline\n"))
+	})
+
+	It("succeeds", func() {
+		var success Success
+		Expect(step.Result(&success)).To(BeTrue())
+		Expect(success).To(Equal(Success(true)))
+	})
+
+	Context("when vars and var files are given", func() {
+		BeforeEach(func() {
+			files["vars-1.yml"] = "a: from-file-1\nb: from-file-1\n"
+			files["vars-2.yml"] = "b: from-file-2\nc: {nested: value}\n"
+
+			plan.VarFiles = []string{"some-source/vars-1.yml", "some-source/vars-2.yml"}
+			plan.Vars = map[string]interface{}{
+				"c": "from-plan",
+				"d": "((some-secret))",
+			}
+		})
+
+		It("merges them, with later values taking precedence, leaving ((vars)) unresolved", func() {
+			_, _, vars, _ := fakeSaver.SavePipelineArgsForCall(0)
+			Expect(vars).To(Equal(template.Variables{
+				"a": "from-file-1",
+				"b": "from-file-2",
+				"c": "from-plan",
+				"d": "((some-secret))",
+			}))
+		})
+	})
+
+	Context("when the config has warnings", func() {
+		BeforeEach(func() {
+			fakeSaver.SavePipelineReturns([]config.Warning{
+				{Type: "pipeline", Message: "some-warning"},
+			}, nil, nil)
+		})
+
+		It("writes them to stdout", func() {
+			Expect(stdoutBuf.String()).To(Equal("WARNING: some-warning\nconfigured pipeline some-child-pipeline\n"))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			fakeSaver.SavePipelineReturns(nil, []string{"some-error", "some-other-error"}, nil)
+		})
+
+		It("writes the errors to stderr", func() {
+			Expect(stderrBuf.String()).To(Equal("invalid pipeline config:\nsome-error\nsome-other-error\n"))
+		})
+
+		It("does not error", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+		})
+
+		It("does not succeed", func() {
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(false)))
+		})
+	})
+
+	Context("when saving the pipeline fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeSaver.SavePipelineReturns(nil, nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+
+		It("reports the error to the delegate", func() {
+			Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(disaster))
+		})
+	})
+
+	Context("when getting the config version fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeSaver.ConfigVersionReturns(0, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(fakeSaver.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the config file is not valid YAML", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = "{"
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(ContainSubstring("failed to load some-source/pipeline.yml"))
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(fakeSaver.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the source does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-source/pipeline.yml"
+		})
+
+		It("returns an UnknownArtifactSourceError", func() {
+			Expect(runErr).To(Equal(UnknownArtifactSourceError{"bogus-source"}))
+		})
+	})
+})
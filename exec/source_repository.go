@@ -3,6 +3,7 @@ package exec
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
 	"sync"
 
@@ -143,6 +144,35 @@ func (repo *SourceRepository) AsMap() map[SourceName]ArtifactSource {
 	return result
 }
 
+// readFile reads a single file given in the format SOURCE_NAME/FILE/PATH out
+// of the repository.
+//
+// If the source name is missing, UnspecifiedArtifactSourceError is returned.
+// If the source cannot be found, UnknownArtifactSourceError is returned.
+func (repo *SourceRepository) readFile(path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := SourceName(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
+
 type subdirectoryDestination struct {
 	destination  ArtifactDestination
 	subdirectory string
@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
@@ -43,6 +44,7 @@ type TaskStep struct {
 	metadata          worker.Metadata
 	tags              atc.Tags
 	teamID            int
+	variables         creds.Variables
 	delegate          TaskDelegate
 	privileged        Privileged
 	configSource      TaskConfigSource
@@ -56,6 +58,8 @@ type TaskStep struct {
 	repo              *SourceRepository
 
 	container           worker.Container
+	cacheWorker         worker.Worker
+	caches              []taskCache
 	containerSuccessTTL time.Duration
 	containerFailureTTL time.Duration
 
@@ -64,12 +68,21 @@ type TaskStep struct {
 	exitStatus int
 }
 
+// taskCache is the copy-on-write child of a job's cache that is mounted into
+// the task's container, along with how the cache is identified on the worker.
+type taskCache struct {
+	worker.VolumeMount
+
+	strategy worker.TaskCacheStrategy
+}
+
 func newTaskStep(
 	logger lager.Logger,
 	containerID worker.Identifier,
 	metadata worker.Metadata,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate TaskDelegate,
 	privileged Privileged,
 	configSource TaskConfigSource,
@@ -89,6 +102,7 @@ func newTaskStep(
 		metadata:            metadata,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		privileged:          privileged,
 		configSource:        configSource,
@@ -121,7 +135,8 @@ func (step TaskStep) Using(prev Step, repo *SourceRepository) Step {
 // available on the worker will be streamed in to the container.
 //
 // If any inputs are not available in the SourceRepository, MissingInputsError
-// is returned.
+// is returned. Optional inputs that are not available are left out of the
+// TaskConfig and are not mounted.
 //
 // Once all the inputs are satisfies, the task's script will be executed, and
 // the RunStep indicates that it's ready, and any signals will be forwarded to
@@ -131,13 +146,17 @@ func (step TaskStep) Using(prev Step, repo *SourceRepository) Step {
 // are registered with the SourceRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// Caches specified in the TaskConfig are mounted from volumes kept on the
+// worker for the job's step, and are replaced if the script exits successfully.
+// Builds not belonging to a job start with empty cache directories.
 func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	var err error
 	var found bool
 
 	processIO := garden.ProcessIO{
-		Stdout: step.delegate.Stdout(),
-		Stderr: step.delegate.Stderr(),
+		Stdout: step.variables.Redact(step.delegate.Stdout()),
+		Stderr: step.variables.Redact(step.delegate.Stderr()),
 	}
 
 	deprecationConfigSource := DeprecationConfigSource{
@@ -150,6 +169,14 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
+	config = step.withoutMissingOptionalInputs(config)
+
+	params, err := step.variables.EvaluateTaskParams(config.Params)
+	if err != nil {
+		return err
+	}
+
+	// container metadata is persisted, so it must not include resolved secrets
 	step.metadata.EnvironmentVariables = step.envForParams(config.Params)
 
 	runContainerID := step.containerID
@@ -233,12 +260,17 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		err = step.setupCaches(config.Caches)
+		if err != nil {
+			return err
+		}
+
 		step.delegate.Started()
 
 		step.process, err = step.container.Run(garden.ProcessSpec{
 			Path: config.Run.Path,
 			Args: config.Run.Args,
-			Env:  step.envForParams(config.Params),
+			Env:  step.envForParams(params),
 
 			Dir: path.Join(step.artifactsRoot, config.Run.Dir),
 			TTY: &garden.TTYSpec{},
@@ -291,6 +323,10 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		if processStatus == 0 {
+			step.saveCaches()
+		}
+
 		step.delegate.Finished(ExitStatus(processStatus))
 
 		return nil
@@ -298,6 +334,16 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 }
 
 func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
+	resourceTypes, err := step.variables.EvaluateResourceTypes(step.resourceTypes)
+	if err != nil {
+		return nil, []inputPair{}, err
+	}
+
+	imageResource, err := step.evaluateImageResource(config.ImageResource)
+	if err != nil {
+		return nil, []inputPair{}, err
+	}
+
 	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorkerWithMostVolumes(compatibleWorkers, config.Inputs)
 	if err != nil {
 		return nil, []inputPair{}, err
@@ -331,6 +377,62 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		step.logger.Debug("created-output-volume", lager.Data{"volume-Handle": outVolume.Handle()})
 	}
 
+	caches := []taskCache{}
+	cacheMounts := []worker.VolumeMount{}
+	if step.metadata.JobName != "" {
+		for _, cache := range config.Caches {
+			strategy := step.cacheStrategy(chosenWorker, cache)
+
+			cacheVolume, err := step.findOrCreateCache(chosenWorker, strategy)
+			if err == worker.ErrNoVolumeManager {
+				break
+			}
+
+			if err != nil {
+				return nil, []inputPair{}, err
+			}
+
+			// the container gets its own copy-on-write child of the cache, so
+			// that concurrent builds do not see each other's changes, and the
+			// child can replace the cache in place if the task succeeds
+			childVolume, err := chosenWorker.CreateVolume(step.logger, worker.VolumeSpec{
+				Strategy: worker.ContainerRootFSStrategy{
+					Parent: cacheVolume,
+				},
+				Privileged: bool(step.privileged),
+				TTL:        worker.VolumeTTL,
+			}, step.teamID)
+
+			cacheVolume.Release(nil)
+
+			if err != nil {
+				for _, mount := range cacheMounts {
+					mount.Volume.Release(nil)
+				}
+
+				return nil, []inputPair{}, err
+			}
+
+			step.logger.Debug("created-cache-child-volume", lager.Data{
+				"path":                strategy.Path,
+				"cache-volume-handle": cacheVolume.Handle(),
+				"volume-handle":       childVolume.Handle(),
+			})
+
+			mount := worker.VolumeMount{
+				Volume:    childVolume,
+				MountPath: step.cachePath(cache),
+			}
+
+			caches = append(caches, taskCache{
+				VolumeMount: mount,
+				strategy:    strategy,
+			})
+
+			cacheMounts = append(cacheMounts, mount)
+		}
+	}
+
 	var imageSpec worker.ImageSpec
 	if step.imageArtifactName != "" {
 		source, found := step.repo.SourceFor(SourceName(step.imageArtifactName))
@@ -408,21 +510,30 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 	} else {
 		imageSpec = worker.ImageSpec{
 			ImageURL:      config.Image,
-			ImageResource: config.ImageResource,
+			ImageResource: imageResource,
 			Privileged:    bool(step.privileged),
 		}
 	}
 
+	// caches are already copy-on-write children, so they are mounted
+	// directly, like outputs
+	containerOutputs := append([]worker.VolumeMount{}, outputMounts...)
+	containerOutputs = append(containerOutputs, cacheMounts...)
+
 	containerSpec := worker.ContainerSpec{
 		Platform:  config.Platform,
 		Tags:      step.tags,
 		TeamID:    step.teamID,
 		Inputs:    inputMounts,
-		Outputs:   outputMounts,
+		Outputs:   containerOutputs,
 		ImageSpec: imageSpec,
 		User:      config.Run.User,
 	}
 
+	if config.Limits != nil {
+		containerSpec.Limits = *config.Limits
+	}
+
 	runContainerID := step.containerID
 	runContainerID.Stage = db.ContainerStageRun
 
@@ -434,7 +545,7 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		runContainerID,
 		step.metadata,
 		containerSpec,
-		step.resourceTypes,
+		resourceTypes,
 	)
 
 	for _, mount := range inputMounts {
@@ -449,9 +560,85 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		mount.Volume.Release(nil)
 	}
 
+	if err != nil {
+		for _, mount := range cacheMounts {
+			mount.Volume.Release(nil)
+		}
+	} else {
+		// hold on to the caches until the task exits, so that they can be
+		// replaced if it succeeds
+		step.cacheWorker = chosenWorker
+		step.caches = caches
+	}
+
 	return container, inputsToStream, err
 }
 
+// evaluateImageResource resolves the placeholders in the task's image
+// resource. The config itself is left as-is, as it is sent to the build's
+// event stream.
+func (step *TaskStep) evaluateImageResource(imageResource *atc.ImageResource) (*atc.ImageResource, error) {
+	if imageResource == nil {
+		return nil, nil
+	}
+
+	source, err := step.variables.EvaluateSource(imageResource.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := step.variables.EvaluateParams(imageResource.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &atc.ImageResource{
+		Type:   imageResource.Type,
+		Source: source,
+		Params: params,
+	}, nil
+}
+func (step *TaskStep) cacheStrategy(chosenWorker worker.Worker, cache atc.TaskCacheConfig) worker.TaskCacheStrategy {
+	return worker.TaskCacheStrategy{
+		WorkerName: chosenWorker.Name(),
+		PipelineID: step.metadata.PipelineID,
+		JobName:    step.metadata.JobName,
+		StepName:   step.metadata.StepName,
+		Path:       cache.Path,
+	}
+}
+
+func (step *TaskStep) findOrCreateCache(chosenWorker worker.Worker, strategy worker.TaskCacheStrategy) (worker.Volume, error) {
+	cacheSpec := worker.VolumeSpec{
+		Strategy:   strategy,
+		Privileged: bool(step.privileged),
+		TTL:        0,
+	}
+
+	cacheVolume, found, err := chosenWorker.FindVolume(step.logger, cacheSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		step.logger.Debug("found-cache-volume", lager.Data{"path": strategy.Path, "volume-handle": cacheVolume.Handle()})
+		return cacheVolume, nil
+	}
+
+	cacheVolume, err = chosenWorker.CreateVolume(step.logger, cacheSpec, step.teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	step.logger.Debug("created-cache-volume", lager.Data{"path": strategy.Path, "volume-handle": cacheVolume.Handle()})
+
+	return cacheVolume, nil
+}
+
+func (step *TaskStep) cachePath(cache atc.TaskCacheConfig) string {
+	return filepath.Join(step.artifactsRoot, cache.Path)
+}
+
 func (step *TaskStep) registerSource(config atc.TaskConfig) {
 	volumeMounts := step.container.VolumeMounts()
 
@@ -498,6 +685,10 @@ func (step *TaskStep) Result(x interface{}) bool {
 }
 
 func (step *TaskStep) Release() {
+	for _, cache := range step.caches {
+		cache.Volume.Release(nil)
+	}
+
 	if step.container == nil {
 		return
 	}
@@ -587,6 +778,29 @@ func (step *TaskStep) inputsOn(inputs []atc.TaskInputConfig, chosenWorker worker
 	return mounts, inputPairs, nil
 }
 
+func (step *TaskStep) withoutMissingOptionalInputs(config atc.TaskConfig) atc.TaskConfig {
+	var inputs []atc.TaskInputConfig
+
+	for _, input := range config.Inputs {
+		if input.Optional {
+			inputName := input.Name
+			if sourceName, ok := step.inputMapping[inputName]; ok {
+				inputName = sourceName
+			}
+
+			if _, found := step.repo.SourceFor(SourceName(inputName)); !found {
+				continue
+			}
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	config.Inputs = inputs
+
+	return config
+}
+
 func (step *TaskStep) inputDestination(config atc.TaskInputConfig) string {
 	subdir := config.Path
 	if config.Path == "" {
@@ -630,6 +844,42 @@ func (step *TaskStep) setupOutputs(outputs []atc.TaskOutputConfig) error {
 	return nil
 }
 
+func (step *TaskStep) setupCaches(caches []atc.TaskCacheConfig) error {
+	for _, cache := range caches {
+		err := createContainerDir(step.container, step.cachePath(cache))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveCaches makes the copy-on-write child of each cache that was mounted
+// into the container the job's cache, so that the next build of the job
+// starts from it, without copying its contents. Builds still using the old
+// cache are unaffected, and a cache that fails to save is left as it was.
+// Caches are only an optimization, so failing to save them does not fail the
+// task.
+func (step *TaskStep) saveCaches() {
+	for _, cache := range step.caches {
+		logger := step.logger.Session("save-cache", lager.Data{
+			"path": cache.MountPath,
+		})
+
+		err := step.cacheWorker.SaveTaskCache(logger, cache.strategy, cache.Volume)
+		if err != nil {
+			logger.Error("failed-to-save-cache", err)
+			continue
+		}
+
+		logger.Debug("saved", lager.Data{"volume-handle": cache.Volume.Handle()})
+
+		// the cache lives as long as its job
+		cache.Volume.Release(worker.FinalTTL(0))
+	}
+}
+
 func (TaskStep) mergeTags(tagsOne []string, tagsTwo []string) []string {
 	var ret []string
 
@@ -17,6 +17,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -61,6 +63,7 @@ var _ = Describe("GardenFactory", func() {
 	Describe("Task", func() {
 		var (
 			taskDelegate  *execfakes.FakeTaskDelegate
+			fakeSecrets   *credsfakes.FakeSecrets
 			privileged    Privileged
 			tags          []string
 			teamID        int
@@ -80,6 +83,8 @@ var _ = Describe("GardenFactory", func() {
 		)
 
 		BeforeEach(func() {
+			fakeSecrets = new(credsfakes.FakeSecrets)
+
 			taskDelegate = new(execfakes.FakeTaskDelegate)
 			taskDelegate.StdoutReturns(stdoutBuf)
 			taskDelegate.StderrReturns(stderrBuf)
@@ -130,6 +135,7 @@ var _ = Describe("GardenFactory", func() {
 				privileged,
 				tags,
 				teamID,
+				creds.NewVariables(fakeSecrets, "some-team", "some-pipeline"),
 				configSource,
 				resourceTypes,
 				inputMapping,
@@ -312,6 +318,84 @@ var _ = Describe("GardenFactory", func() {
 							Expect(spec.TTY).To(Equal(&garden.TTYSpec{}))
 						})
 
+						Context("when the params reference secrets", func() {
+							BeforeEach(func() {
+								fetchedConfig.Params = map[string]string{"SOME": "((some-secret))"}
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+
+								fakeSecrets.GetReturns("resolved-secret", true, nil)
+							})
+
+							It("runs the process with the secrets resolved", func() {
+								Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+								spec, _ := fakeContainer.RunArgsForCall(0)
+								Expect(spec.Env).To(Equal([]string{"SOME=resolved-secret"}))
+							})
+
+							It("does not resolve the secrets in the container's metadata", func() {
+								Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+
+								_, _, _, _, createdMetadata, _, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(createdMetadata.EnvironmentVariables).To(Equal([]string{"SOME=((some-secret))"}))
+							})
+
+							It("does not resolve the secrets in the config given to the delegate", func() {
+								Expect(taskDelegate.InitializingCallCount()).To(Equal(1))
+								Expect(taskDelegate.InitializingArgsForCall(0)).To(Equal(fetchedConfig))
+							})
+
+							Context("when the image resource and resource types reference secrets", func() {
+								BeforeEach(func() {
+									fetchedConfig.ImageResource = &atc.ImageResource{
+										Type:   "docker",
+										Source: atc.Source{"password": "((image-secret))"},
+									}
+									configSource.FetchConfigReturns(fetchedConfig, nil)
+
+									resourceTypes = atc.ResourceTypes{
+										{
+											Name:   "custom-resource",
+											Type:   "custom-type",
+											Source: atc.Source{"password": "((type-secret))"},
+										},
+									}
+								})
+
+								It("creates the container with the secrets resolved", func() {
+									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+
+									_, _, _, _, _, spec, actualResourceTypes := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.ImageSpec.ImageResource).To(Equal(&atc.ImageResource{
+										Type:   "docker",
+										Source: atc.Source{"password": "resolved-secret"},
+									}))
+									Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
+										{
+											Name:   "custom-resource",
+											Type:   "custom-type",
+											Source: atc.Source{"password": "resolved-secret"},
+										},
+									}))
+								})
+
+								It("does not resolve the secrets in the config given to the delegate", func() {
+									Expect(taskDelegate.InitializingArgsForCall(0).ImageResource.Source).To(Equal(atc.Source{"password": "((image-secret))"}))
+								})
+							})
+
+							Context("when a secret is not defined", func() {
+								BeforeEach(func() {
+									fakeSecrets.GetReturns(nil, false, nil)
+								})
+
+								It("exits with the error without creating a container", func() {
+									Expect(<-process.Wait()).To(Equal(creds.UndefinedSecretError{Name: "some-secret"}))
+									Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						It("directs the process's stdout/stderr to the io config", func() {
 							Expect(fakeContainer.RunCallCount()).To(Equal(1))
 
@@ -332,6 +416,26 @@ var _ = Describe("GardenFactory", func() {
 							Expect(taskDelegate.StartedCallCount()).To(Equal(1))
 						})
 
+						Context("when the configuration specifies container limits", func() {
+							BeforeEach(func() {
+								fetchedConfig.Limits = &atc.ContainerLimits{
+									CPU:    512,
+									Memory: 1024,
+								}
+
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+							})
+
+							It("creates the container with the limits", func() {
+								Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+									CPU:    512,
+									Memory: 1024,
+								}))
+							})
+						})
+
 						Context("when privileged", func() {
 							BeforeEach(func() {
 								privileged = true
@@ -535,6 +639,82 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration specifies optional inputs", func() {
+							var inputSource *execfakes.FakeArtifactSource
+							var optionalInputSource *execfakes.FakeArtifactSource
+
+							BeforeEach(func() {
+								inputSource = new(execfakes.FakeArtifactSource)
+								optionalInputSource = new(execfakes.FakeArtifactSource)
+
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Inputs: []atc.TaskInputConfig{
+										{Name: "some-input"},
+										{Name: "some-optional-input", Optional: true},
+									},
+								}, nil)
+
+								repo.RegisterSource("some-input", inputSource)
+							})
+
+							Context("when the optional input is present in the source repository", func() {
+								BeforeEach(func() {
+									repo.RegisterSource("some-optional-input", optionalInputSource)
+								})
+
+								It("streams it in to the container", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(inputSource.StreamToCallCount()).To(Equal(1))
+									Expect(optionalInputSource.StreamToCallCount()).To(Equal(1))
+								})
+
+								It("records it as provided when initializing", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.InitializingCallCount()).To(Equal(1))
+									Expect(taskDelegate.InitializingArgsForCall(0).Inputs).To(Equal([]atc.TaskInputConfig{
+										{Name: "some-input"},
+										{Name: "some-optional-input", Optional: true},
+									}))
+								})
+							})
+
+							Context("when the optional input is missing", func() {
+								It("runs the task without it", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(inputSource.StreamToCallCount()).To(Equal(1))
+									Expect(fakeContainer.RunCallCount()).To(Equal(1))
+								})
+
+								It("leaves it out of the config given when initializing", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.InitializingCallCount()).To(Equal(1))
+									Expect(taskDelegate.InitializingArgsForCall(0).Inputs).To(Equal([]atc.TaskInputConfig{
+										{Name: "some-input"},
+									}))
+								})
+							})
+
+							Context("when a required input is missing too", func() {
+								BeforeEach(func() {
+									repo = NewSourceRepository()
+								})
+
+								It("only reports the required input as missing", func() {
+									var err error
+									Eventually(process.Wait()).Should(Receive(&err))
+									Expect(err).To(BeAssignableToTypeOf(MissingInputsError{}))
+									Expect(err.(MissingInputsError).Inputs).To(ConsistOf("some-input"))
+								})
+							})
+						})
+
 						Context("when input is remapped", func() {
 							var remappedInputSource *execfakes.FakeArtifactSource
 
@@ -1145,6 +1325,319 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration specifies caches", func() {
+							var (
+								fakeCacheVolume1 *wfakes.FakeVolume
+								fakeCacheVolume2 *wfakes.FakeVolume
+
+								fakeChildCacheVolume1 *wfakes.FakeVolume
+								fakeChildCacheVolume2 *wfakes.FakeVolume
+							)
+
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Caches: []atc.TaskCacheConfig{
+										{Path: "some-cache"},
+										{Path: "some/other-cache"},
+									},
+								}, nil)
+
+								fakeWorker.NameReturns("some-worker")
+
+								fakeCacheVolume1 = new(wfakes.FakeVolume)
+								fakeCacheVolume1.HandleReturns("some-cache-handle-1")
+
+								fakeCacheVolume2 = new(wfakes.FakeVolume)
+								fakeCacheVolume2.HandleReturns("some-cache-handle-2")
+
+								fakeWorker.FindVolumeStub = func(_ lager.Logger, spec worker.VolumeSpec) (worker.Volume, bool, error) {
+									if spec.Strategy.(worker.TaskCacheStrategy).Path == "some-cache" {
+										return fakeCacheVolume1, true, nil
+									}
+
+									return nil, false, nil
+								}
+
+								fakeChildCacheVolume1 = new(wfakes.FakeVolume)
+								fakeChildCacheVolume1.HandleReturns("some-child-cache-handle-1")
+
+								fakeChildCacheVolume2 = new(wfakes.FakeVolume)
+								fakeChildCacheVolume2.HandleReturns("some-child-cache-handle-2")
+
+								fakeWorker.CreateVolumeStub = func(_ lager.Logger, spec worker.VolumeSpec, _ int) (worker.Volume, error) {
+									switch strategy := spec.Strategy.(type) {
+									case worker.TaskCacheStrategy:
+										return fakeCacheVolume2, nil
+									case worker.ContainerRootFSStrategy:
+										if strategy.Parent == fakeCacheVolume1 {
+											return fakeChildCacheVolume1, nil
+										}
+
+										return fakeChildCacheVolume2, nil
+									}
+
+									return nil, errors.New("unexpected volume spec")
+								}
+							})
+
+							It("looks for the job's cache volumes on the chosen worker", func() {
+								Expect(fakeWorker.FindVolumeCallCount()).To(Equal(2))
+
+								_, vSpec := fakeWorker.FindVolumeArgsForCall(0)
+								Expect(vSpec).To(Equal(worker.VolumeSpec{
+									Strategy: worker.TaskCacheStrategy{
+										WorkerName: "some-worker",
+										JobName:    "some-job",
+										StepName:   "some-step",
+										Path:       "some-cache",
+									},
+									Privileged: bool(privileged),
+									TTL:        0,
+								}))
+
+								_, vSpec = fakeWorker.FindVolumeArgsForCall(1)
+								Expect(vSpec).To(Equal(worker.VolumeSpec{
+									Strategy: worker.TaskCacheStrategy{
+										WorkerName: "some-worker",
+										JobName:    "some-job",
+										StepName:   "some-step",
+										Path:       "some/other-cache",
+									},
+									Privileged: bool(privileged),
+									TTL:        0,
+								}))
+							})
+
+							It("creates the cache volumes that do not exist yet", func() {
+								cacheSpecs := []worker.VolumeSpec{}
+								cacheTeamIDs := []int{}
+								for i := 0; i < fakeWorker.CreateVolumeCallCount(); i++ {
+									_, vSpec, actualTeamID := fakeWorker.CreateVolumeArgsForCall(i)
+									if _, ok := vSpec.Strategy.(worker.TaskCacheStrategy); ok {
+										cacheSpecs = append(cacheSpecs, vSpec)
+										cacheTeamIDs = append(cacheTeamIDs, actualTeamID)
+									}
+								}
+
+								Expect(cacheSpecs).To(HaveLen(1))
+								Expect(cacheSpecs[0]).To(Equal(worker.VolumeSpec{
+									Strategy: worker.TaskCacheStrategy{
+										WorkerName: "some-worker",
+										JobName:    "some-job",
+										StepName:   "some-step",
+										Path:       "some/other-cache",
+									},
+									Privileged: bool(privileged),
+									TTL:        0,
+								}))
+								Expect(cacheTeamIDs).To(Equal([]int{teamID}))
+							})
+
+							It("creates a copy-on-write child of each cache volume", func() {
+								childSpecs := []worker.VolumeSpec{}
+								for i := 0; i < fakeWorker.CreateVolumeCallCount(); i++ {
+									_, vSpec, actualTeamID := fakeWorker.CreateVolumeArgsForCall(i)
+									if _, ok := vSpec.Strategy.(worker.ContainerRootFSStrategy); ok {
+										childSpecs = append(childSpecs, vSpec)
+										Expect(actualTeamID).To(Equal(teamID))
+									}
+								}
+
+								Expect(childSpecs).To(Equal([]worker.VolumeSpec{
+									{
+										Strategy:   worker.ContainerRootFSStrategy{Parent: fakeCacheVolume1},
+										Privileged: bool(privileged),
+										TTL:        worker.VolumeTTL,
+									},
+									{
+										Strategy:   worker.ContainerRootFSStrategy{Parent: fakeCacheVolume2},
+										Privileged: bool(privileged),
+										TTL:        worker.VolumeTTL,
+									},
+								}))
+							})
+
+							It("stops holding on to the caches the build started with", func() {
+								Expect(fakeCacheVolume1.ReleaseCallCount()).To(Equal(1))
+								Expect(fakeCacheVolume1.ReleaseArgsForCall(0)).To(BeNil())
+
+								Expect(fakeCacheVolume2.ReleaseCallCount()).To(Equal(1))
+								Expect(fakeCacheVolume2.ReleaseArgsForCall(0)).To(BeNil())
+							})
+
+							It("mounts the children directly into the container", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Inputs).To(BeEmpty())
+								Expect(spec.Outputs).To(ConsistOf(
+									worker.VolumeMount{
+										Volume:    fakeChildCacheVolume1,
+										MountPath: "/tmp/build/a1f5c0c1/some-cache",
+									},
+									worker.VolumeMount{
+										Volume:    fakeChildCacheVolume2,
+										MountPath: "/tmp/build/a1f5c0c1/some/other-cache",
+									},
+								))
+							})
+
+							Context("when creating a child volume fails", func() {
+								disaster := errors.New("nope")
+
+								BeforeEach(func() {
+									fakeWorker.CreateVolumeStub = func(_ lager.Logger, spec worker.VolumeSpec, _ int) (worker.Volume, error) {
+										switch spec.Strategy.(type) {
+										case worker.TaskCacheStrategy:
+											return fakeCacheVolume2, nil
+										case worker.ContainerRootFSStrategy:
+											if spec.Strategy.(worker.ContainerRootFSStrategy).Parent == fakeCacheVolume1 {
+												return fakeChildCacheVolume1, nil
+											}
+										}
+
+										return nil, disaster
+									}
+								})
+
+								It("releases the children already created and does not create the container", func() {
+									Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+
+									Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+
+									Expect(fakeChildCacheVolume1.ReleaseCallCount()).To(Equal(1))
+									Expect(fakeChildCacheVolume1.ReleaseArgsForCall(0)).To(BeNil())
+									Expect(fakeCacheVolume2.ReleaseCallCount()).To(Equal(1))
+								})
+							})
+
+							It("ensures the cache directories exist by streaming in an empty payload", func() {
+								Expect(fakeContainer.StreamInCallCount()).To(Equal(3))
+
+								spec := fakeContainer.StreamInArgsForCall(1)
+								Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-cache"))
+
+								spec = fakeContainer.StreamInArgsForCall(2)
+								Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some/other-cache"))
+							})
+
+							Context("when the process exits 0", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(0, nil)
+								})
+
+								It("does not copy the caches out of the container", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeContainer.StreamOutCallCount()).To(BeZero())
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(3))
+								})
+
+								It("replaces the job's caches with the children in place", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeWorker.SaveTaskCacheCallCount()).To(Equal(2))
+
+									_, strategy, volume := fakeWorker.SaveTaskCacheArgsForCall(0)
+									Expect(strategy).To(Equal(worker.TaskCacheStrategy{
+										WorkerName: "some-worker",
+										JobName:    "some-job",
+										StepName:   "some-step",
+										Path:       "some-cache",
+									}))
+									Expect(volume).To(Equal(fakeChildCacheVolume1))
+
+									_, strategy, volume = fakeWorker.SaveTaskCacheArgsForCall(1)
+									Expect(strategy.Path).To(Equal("some/other-cache"))
+									Expect(volume).To(Equal(fakeChildCacheVolume2))
+								})
+
+								It("keeps the new caches for as long as the job", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeChildCacheVolume1.ReleaseCallCount()).To(Equal(1))
+									Expect(fakeChildCacheVolume1.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(0)))
+
+									Expect(fakeChildCacheVolume2.ReleaseCallCount()).To(Equal(1))
+									Expect(fakeChildCacheVolume2.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(0)))
+								})
+
+								Context("when replacing a cache fails", func() {
+									BeforeEach(func() {
+										fakeWorker.SaveTaskCacheStub = func(_ lager.Logger, strategy worker.TaskCacheStrategy, _ worker.Volume) error {
+											if strategy.Path == "some-cache" {
+												return errors.New("nope")
+											}
+
+											return nil
+										}
+									})
+
+									It("still succeeds", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										var success Success
+										Expect(step.Result(&success)).To(BeTrue())
+										Expect(success).To(BeTrue())
+									})
+
+									It("keeps the job's cache as it was and lets the child expire once the step is released", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(fakeChildCacheVolume1.ReleaseCallCount()).To(BeZero())
+										Expect(fakeChildCacheVolume2.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(0)))
+
+										step.Release()
+
+										Expect(fakeChildCacheVolume1.ReleaseCallCount()).To(Equal(1))
+										Expect(fakeChildCacheVolume1.ReleaseArgsForCall(0)).To(BeNil())
+									})
+								})
+							})
+
+							Context("when the process exits nonzero", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(1, nil)
+								})
+
+								It("does not save the caches", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeWorker.SaveTaskCacheCallCount()).To(BeZero())
+								})
+
+								It("lets the children expire once the step is released", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									step.Release()
+
+									Expect(fakeChildCacheVolume1.ReleaseArgsForCall(0)).To(BeNil())
+									Expect(fakeChildCacheVolume2.ReleaseArgsForCall(0)).To(BeNil())
+								})
+							})
+
+							Context("when the build does not belong to a job", func() {
+								BeforeEach(func() {
+									workerMetadata.JobName = ""
+								})
+
+								It("does not use cache volumes", func() {
+									Expect(fakeWorker.FindVolumeCallCount()).To(BeZero())
+									Expect(fakeWorker.CreateVolumeCallCount()).To(BeZero())
+
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Inputs).To(BeEmpty())
+								})
+
+								It("still creates the cache directories", func() {
+									Expect(fakeContainer.StreamInCallCount()).To(Equal(3))
+								})
+							})
+						})
+
 						Context("when output is remapped", func() {
 							BeforeEach(func() {
 								outputMapping = map[string]string{"generic-remapped-output": "specific-remapped-output"}
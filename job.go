@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"encoding/json"
+	"errors"
+)
+
 type Job struct {
 	Name                 string `json:"name"`
 	URL                  string `json:"url"`
@@ -36,3 +41,61 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+type ClearJobCachesResponse struct {
+	CachesRemoved int `json:"caches_removed"`
+}
+
+// CreateJobBuildRequest is the optional body for triggering a job build with
+// chosen versions of some of its inputs. Unless Force is set, the versions
+// must satisfy the inputs' passed constraints.
+type CreateJobBuildRequest struct {
+	Inputs map[string]InputVersionChoice `json:"inputs,omitempty"`
+	Force  bool                          `json:"force,omitempty"`
+}
+
+// An InputVersionChoice identifies a version of an input's resource either by
+// its ID or by the version itself.
+type InputVersionChoice struct {
+	ID      int
+	Version Version
+}
+
+func (c InputVersionChoice) MarshalJSON() ([]byte, error) {
+	if c.Version != nil {
+		return json.Marshal(c.Version)
+	}
+
+	return json.Marshal(c.ID)
+}
+
+func (c *InputVersionChoice) UnmarshalJSON(choice []byte) error {
+	var data interface{}
+
+	err := json.Unmarshal(choice, &data)
+	if err != nil {
+		return err
+	}
+
+	switch actual := data.(type) {
+	case float64:
+		c.ID = int(actual)
+	case map[string]interface{}:
+		version := Version{}
+
+		for k, v := range actual {
+			s, ok := v.(string)
+			if !ok {
+				return errors.New("version values must be strings")
+			}
+
+			version[k] = s
+		}
+
+		c.Version = version
+	default:
+		return errors.New("input version must be a version ID or a version")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package lostandfound
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"time"
 
@@ -113,6 +114,8 @@ func (bc *baggageCollector) getLatestVersionSet() (hashedVersionSet, error) {
 				"job":      pipelineJob.Name,
 			})
 
+			insertOrIncreaseVersionTTL(latestVersions, taskCacheHashKey(pipeline.ID, pipelineJob.Name), 0) // live as long as the job
+
 			finishedBuild, _, err := pipelineDB.GetJobFinishedAndNextBuild(pipelineJob.Name)
 			if err != nil {
 				logger.Error("could-not-acquire-finished-and-next-builds-for-job", err)
@@ -164,6 +167,14 @@ func resourceCacheHashKey(volume db.SavedVolume) (string, bool) {
 	return string(version) + resourceCacheID.ResourceHash, true
 }
 
+func taskCacheHashKey(pipelineID int, jobName string) string {
+	return fmt.Sprintf("task-cache:%d:%s", pipelineID, jobName)
+}
+
+func taskCacheVolumeKey(identifier *db.TaskCacheIdentifier) string {
+	return identifier.StepName + ":" + identifier.Path
+}
+
 func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error {
 	volumesToExpire, err := bc.db.GetVolumes()
 	if err != nil {
@@ -173,6 +184,21 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 
 	sort.Sort(sortByHandle(volumesToExpire))
 
+	// builds replace a task cache with a new volume each time they save it,
+	// so only the newest volume of each cache is kept
+	newestTaskCaches := map[string]int{}
+	for _, volume := range volumesToExpire {
+		identifier := volume.Volume.Identifier.TaskCache
+		if identifier == nil {
+			continue
+		}
+
+		key := taskCacheHashKey(identifier.PipelineID, identifier.JobName) + taskCacheVolumeKey(identifier) + volume.WorkerName
+		if volume.ID > newestTaskCaches[key] {
+			newestTaskCaches[key] = volume.ID
+		}
+	}
+
 	seenIdentifiers := map[string]bool{}
 	for _, volumeToExpire := range volumesToExpire {
 		volumeWorker, err := bc.workerClient.GetWorker(volumeToExpire.WorkerName)
@@ -185,6 +211,7 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 		}
 
 		var hashKey string
+		var volumeKey string
 		switch {
 		case volumeToExpire.Volume.Identifier.ResourceCache != nil:
 			version, err := json.Marshal(volumeToExpire.Volume.Identifier.ResourceCache.ResourceVersion)
@@ -200,14 +227,25 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 			}
 
 			hashKey = identifier.WorkerName + identifier.Path + *identifier.Version
+		case volumeToExpire.Volume.Identifier.TaskCache != nil:
+			identifier := volumeToExpire.Volume.Identifier.TaskCache
+			hashKey = taskCacheHashKey(identifier.PipelineID, identifier.JobName)
+
+			// a job has a cache for each of its steps' cache paths
+			volumeKey = taskCacheVolumeKey(identifier)
 		default:
 			continue
 		}
 
-		identifier := hashKey + volumeToExpire.WorkerName
+		identifier := hashKey + volumeKey + volumeToExpire.WorkerName
+
+		_, seen := seenIdentifiers[identifier]
+		if volumeToExpire.Volume.Identifier.TaskCache != nil {
+			seen = volumeToExpire.ID != newestTaskCaches[identifier]
+		}
 
 		var ttlForVol time.Duration
-		if _, found := seenIdentifiers[identifier]; found {
+		if seen {
 			ttlForVol = bc.oldResourceGracePeriod
 		} else if ttl, found := latestVersions[hashKey]; found {
 			ttlForVol = ttl
@@ -0,0 +1,174 @@
+package lostandfound_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lostandfound"
+	"github.com/concourse/atc/lostandfound/lostandfoundfakes"
+	"github.com/concourse/atc/worker"
+
+	"github.com/concourse/atc/db/dbfakes"
+	wfakes "github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task caches are reaped", func() {
+	var (
+		fakeWorkerClient *wfakes.FakeClient
+		fakeWorker       *wfakes.FakeWorker
+		fakeVolume       *wfakes.FakeVolume
+
+		fakePipelineDBFactory          *dbfakes.FakePipelineDBFactory
+		fakePipelineDB                 *dbfakes.FakePipelineDB
+		fakeBaggageCollectorDB         *lostandfoundfakes.FakeBaggageCollectorDB
+		expectedOldResourceGracePeriod = 4 * time.Minute
+		expectedOneOffTTL              = 5 * time.Hour
+
+		baggageCollector lostandfound.BaggageCollector
+
+		savedPipeline   db.SavedPipeline
+		cacheVolume     db.SavedVolume
+		returnedVolumes []db.SavedVolume
+	)
+
+	BeforeEach(func() {
+		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeWorker = new(wfakes.FakeWorker)
+		fakeVolume = new(wfakes.FakeVolume)
+		baggageCollectorLogger := lagertest.NewTestLogger("test")
+		fakeBaggageCollectorDB = new(lostandfoundfakes.FakeBaggageCollectorDB)
+		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
+		fakePipelineDB = new(dbfakes.FakePipelineDB)
+
+		baggageCollector = lostandfound.NewBaggageCollector(
+			baggageCollectorLogger,
+			fakeWorkerClient,
+			fakeBaggageCollectorDB,
+			fakePipelineDBFactory,
+			expectedOldResourceGracePeriod,
+			expectedOneOffTTL,
+		)
+
+		savedPipeline = db.SavedPipeline{
+			Pipeline: db.Pipeline{
+				Name: "some-pipeline",
+				Config: atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job"},
+					},
+				},
+			},
+			ID:     7,
+			TeamID: 13,
+		}
+
+		cacheVolume = db.SavedVolume{
+			Volume: db.Volume{
+				WorkerName: "some-worker",
+				TTL:        0,
+				Handle:     "some-cache-handle",
+				Identifier: db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						WorkerName: "some-worker",
+						PipelineID: 7,
+						JobName:    "some-job",
+						StepName:   "some-task",
+						Path:       "some-cache",
+					},
+				},
+			},
+			ID: 123,
+		}
+
+		returnedVolumes = []db.SavedVolume{cacheVolume}
+
+		fakeBaggageCollectorDB.GetAllPipelinesReturns([]db.SavedPipeline{savedPipeline}, nil)
+		fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+		fakeWorkerClient.GetWorkerReturns(fakeWorker, nil)
+		fakeWorker.LookupVolumeReturns(fakeVolume, true, nil)
+	})
+
+	JustBeforeEach(func() {
+		fakeBaggageCollectorDB.GetVolumesReturns(returnedVolumes, nil)
+
+		err := baggageCollector.Run()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the job still exists", func() {
+		It("keeps the cache", func() {
+			Expect(fakeVolume.ReleaseCallCount()).To(BeZero())
+		})
+
+		Context("when the job has caches for other steps and paths on the same worker", func() {
+			var otherCacheVolume db.SavedVolume
+
+			BeforeEach(func() {
+				otherCacheVolume = cacheVolume
+				otherCacheVolume.Handle = "some-other-cache-handle"
+				otherCacheVolume.Identifier.TaskCache = &db.TaskCacheIdentifier{
+					WorkerName: "some-worker",
+					PipelineID: 7,
+					JobName:    "some-job",
+					StepName:   "some-other-task",
+					Path:       "some-cache",
+				}
+
+				returnedVolumes = append(returnedVolumes, otherCacheVolume)
+			})
+
+			It("keeps all of them", func() {
+				Expect(fakeVolume.ReleaseCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the cache has been replaced by a newer volume", func() {
+			var newerCacheVolume db.SavedVolume
+
+			BeforeEach(func() {
+				newerCacheVolume = cacheVolume
+				newerCacheVolume.Handle = "a-newer-cache-handle"
+				newerCacheVolume.ID = 456
+
+				returnedVolumes = append(returnedVolumes, newerCacheVolume)
+			})
+
+			It("expires the replaced cache after the grace period and keeps the newer one", func() {
+				Expect(fakeWorker.LookupVolumeCallCount()).To(Equal(1))
+				_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-cache-handle"))
+
+				Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+				Expect(fakeVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(expectedOldResourceGracePeriod)))
+			})
+		})
+	})
+
+	Context("when the job has been removed from the pipeline", func() {
+		BeforeEach(func() {
+			savedPipeline.Config.Jobs = atc.JobConfigs{{Name: "some-other-job"}}
+			fakeBaggageCollectorDB.GetAllPipelinesReturns([]db.SavedPipeline{savedPipeline}, nil)
+		})
+
+		It("expires the cache after the grace period", func() {
+			Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+			Expect(fakeVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(expectedOldResourceGracePeriod)))
+		})
+	})
+
+	Context("when the pipeline has been destroyed", func() {
+		BeforeEach(func() {
+			fakeBaggageCollectorDB.GetAllPipelinesReturns([]db.SavedPipeline{}, nil)
+		})
+
+		It("expires the cache after the grace period", func() {
+			Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+			Expect(fakeVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(expectedOldResourceGracePeriod)))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/bigdatadev/goryman"
+	"github.com/concourse/atc/db"
 )
 
 func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
@@ -19,6 +20,7 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 		trackedVolumes := TrackedVolumes.Max()
 		databaseQueries := DatabaseQueries.Delta()
 		databaseConnections := DatabaseConnections.Max()
+		versionsDBCacheHits, versionsDBCacheMisses := db.VersionsDBCacheCounts()
 
 		emit(
 			tLog.Session("tracked-containers", lager.Data{
@@ -64,6 +66,28 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			},
 		)
 
+		emit(
+			tLog.Session("versions-db-cache-hits", lager.Data{
+				"count": versionsDBCacheHits,
+			}),
+			goryman.Event{
+				Service: "versions db cache hits",
+				Metric:  versionsDBCacheHits,
+				State:   "ok",
+			},
+		)
+
+		emit(
+			tLog.Session("versions-db-cache-misses", lager.Data{
+				"count": versionsDBCacheMisses,
+			}),
+			goryman.Event{
+				Service: "versions db cache misses",
+				Metric:  versionsDBCacheMisses,
+				State:   "ok",
+			},
+		)
+
 		var memStats runtime.MemStats
 		runtime.ReadMemStats(&memStats)
 
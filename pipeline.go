@@ -1,10 +1,96 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
 type Pipeline struct {
-	Name     string       `json:"name"`
-	URL      string       `json:"url"`
-	Paused   bool         `json:"paused"`
-	Public   bool         `json:"public"`
-	Groups   GroupConfigs `json:"groups,omitempty"`
-	TeamName string       `json:"team_name"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	URL          string       `json:"url"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Groups       GroupConfigs `json:"groups,omitempty"`
+	TeamName     string       `json:"team_name"`
+
+	ParentBuildID int  `json:"parent_build_id,omitempty"`
+	Archived      bool `json:"archived,omitempty"`
+}
+
+// InstanceVars distinguish the instances of a pipeline that share a name,
+// e.g. one instance per branch.
+type InstanceVars map[string]interface{}
+
+// PipelineRef identifies a pipeline within a team by its name and instance
+// vars. A pipeline without instance vars is identified by its name alone.
+type PipelineRef struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+func (ref PipelineRef) String() string {
+	if len(ref.InstanceVars) == 0 {
+		return ref.Name
+	}
+
+	payload, _ := json.Marshal(ref.InstanceVars)
+	return ref.Name + "/" + string(payload)
+}
+
+const instanceVarQueryParamPrefix = "vars."
+
+// QueryParams encodes the instance vars as the query params that address the
+// pipeline in the API, with each var's value encoded as JSON, e.g.
+// vars.branch="feature".
+func (ref PipelineRef) QueryParams() url.Values {
+	params := url.Values{}
+
+	names := []string{}
+	for name := range ref.InstanceVars {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		payload, _ := json.Marshal(ref.InstanceVars[name])
+		params.Set(instanceVarQueryParamPrefix+name, string(payload))
+	}
+
+	return params
+}
+
+// InstanceVarsFromQueryParams decodes the instance vars encoded by
+// PipelineRef.QueryParams. Other query params are ignored.
+func InstanceVarsFromQueryParams(params url.Values) (InstanceVars, error) {
+	var instanceVars InstanceVars
+
+	for key, values := range params {
+		if !strings.HasPrefix(key, instanceVarQueryParamPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, instanceVarQueryParamPrefix)
+		if name == "" || len(values) == 0 {
+			return nil, fmt.Errorf("invalid instance var query param '%s'", key)
+		}
+
+		var value interface{}
+		err := json.Unmarshal([]byte(values[0]), &value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for instance var '%s': %s", name, err)
+		}
+
+		if instanceVars == nil {
+			instanceVars = InstanceVars{}
+		}
+
+		instanceVars[name] = value
+	}
+
+	return instanceVars, nil
 }
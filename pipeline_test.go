@@ -0,0 +1,82 @@
+package atc_test
+
+import (
+	"net/url"
+
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineRef", func() {
+	Describe("String", func() {
+		It("is the name for pipelines without instance vars", func() {
+			Expect(atc.PipelineRef{Name: "some-pipeline"}.String()).To(Equal("some-pipeline"))
+		})
+
+		It("includes the instance vars", func() {
+			ref := atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "feature", "build": 1},
+			}
+
+			Expect(ref.String()).To(Equal(`some-pipeline/{"branch":"feature","build":1}`))
+		})
+	})
+
+	Describe("QueryParams", func() {
+		It("encodes each instance var as JSON", func() {
+			ref := atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "feature", "build": 1},
+			}
+
+			Expect(ref.QueryParams()).To(Equal(url.Values{
+				"vars.branch": {`"feature"`},
+				"vars.build":  {"1"},
+			}))
+		})
+
+		It("is empty for pipelines without instance vars", func() {
+			Expect(atc.PipelineRef{Name: "some-pipeline"}.QueryParams()).To(BeEmpty())
+		})
+	})
+
+	Describe("InstanceVarsFromQueryParams", func() {
+		It("decodes the instance vars, ignoring other params", func() {
+			instanceVars, err := atc.InstanceVarsFromQueryParams(url.Values{
+				"vars.branch": {`"feature"`},
+				"vars.nested": {`{"some":"value"}`},
+				"since":       {"1"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instanceVars).To(Equal(atc.InstanceVars{
+				"branch": "feature",
+				"nested": map[string]interface{}{"some": "value"},
+			}))
+		})
+
+		It("returns nil when there are no instance vars", func() {
+			instanceVars, err := atc.InstanceVarsFromQueryParams(url.Values{"since": {"1"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instanceVars).To(BeNil())
+		})
+
+		It("errors when a value is not JSON", func() {
+			_, err := atc.InstanceVarsFromQueryParams(url.Values{"vars.branch": {"feature"}})
+			Expect(err).To(MatchError(ContainSubstring("invalid value for instance var 'branch'")))
+		})
+
+		It("round-trips PipelineRef.QueryParams", func() {
+			instanceVars := atc.InstanceVars{"branch": "feature", "enabled": true}
+
+			decoded, err := atc.InstanceVarsFromQueryParams(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: instanceVars,
+			}.QueryParams())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal(instanceVars))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
@@ -37,19 +38,10 @@ func (pdbh *PipelineHandlerFactory) HandlerFor(pipelineScopedHandler func(db.Pip
 			return
 		}
 
-		pipelineName := r.FormValue(":pipeline_name")
-		teamName := r.FormValue(":team_name")
-		teamDB := pdbh.teamDBFactory.GetTeamDB(teamName)
-		savedPipeline, err := teamDB.GetPipelineByName(pipelineName)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				w.WriteHeader(http.StatusNotFound)
-			} else {
-				w.WriteHeader(http.StatusInternalServerError)
-			}
+		pipelineDB, found := pdbh.pipelineDB(w, r)
+		if !found {
 			return
 		}
-		pipelineDB := pdbh.pipelineDBFactory.Build(savedPipeline)
 
 		if !authorized && !pipelineDB.IsPublic() {
 			if response == auth.Unauthorized {
@@ -63,3 +55,50 @@ func (pdbh *PipelineHandlerFactory) HandlerFor(pipelineScopedHandler func(db.Pip
 		pipelineScopedHandler(pipelineDB).ServeHTTP(w, r)
 	}
 }
+
+// UnauthenticatedHandlerFor scopes the handler to the pipeline without
+// checking authorization at all. The handler is responsible for verifying the
+// request itself, e.g. via a token configured in the pipeline.
+func (pdbh *PipelineHandlerFactory) UnauthenticatedHandlerFor(pipelineScopedHandler func(db.PipelineDB) http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pipelineDB, found := pdbh.pipelineDB(w, r)
+		if !found {
+			return
+		}
+
+		pipelineScopedHandler(pipelineDB).ServeHTTP(w, r)
+	}
+}
+
+func (pdbh *PipelineHandlerFactory) pipelineDB(w http.ResponseWriter, r *http.Request) (db.PipelineDB, bool) {
+	instanceVars, err := atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         r.FormValue(":pipeline_name"),
+		InstanceVars: instanceVars,
+	}
+
+	teamName := r.FormValue(":team_name")
+	teamDB := pdbh.teamDBFactory.GetTeamDB(teamName)
+	savedPipeline, err := teamDB.GetPipelineByRef(pipelineRef)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+
+	// archived pipelines are read-only, except for being destroyed
+	if savedPipeline.Archived && r.Method != "GET" && r.Method != "DELETE" {
+		w.WriteHeader(http.StatusConflict)
+		return nil, false
+	}
+
+	return pdbh.pipelineDBFactory.Build(savedPipeline), true
+}
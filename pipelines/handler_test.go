@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db"
@@ -27,6 +28,7 @@ var _ = Describe("Handler", func() {
 		authValidator     *authfakes.FakeValidator
 		userContextReader *authfakes.FakeUserContextReader
 		allowsPublic      bool
+		query             string
 	)
 
 	BeforeEach(func() {
@@ -38,6 +40,7 @@ var _ = Describe("Handler", func() {
 		delegate = &delegateHandler{}
 		authValidator = new(authfakes.FakeValidator)
 		userContextReader = new(authfakes.FakeUserContextReader)
+		query = ""
 	})
 
 	JustBeforeEach(func() {
@@ -51,7 +54,7 @@ var _ = Describe("Handler", func() {
 		server = httptest.NewServer(authHandler)
 
 		var err error
-		response, err = http.PostForm(server.URL+"?:team_name=some-team",
+		response, err = http.PostForm(server.URL+"?:team_name=some-team"+query,
 			url.Values{
 				":pipeline_name": {"some-pipeline"},
 				":team_name":     {"some-team"},
@@ -77,8 +80,8 @@ var _ = Describe("Handler", func() {
 			})
 
 			It("looks up the pipeline by the right name", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("some-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 			})
 
 			It("returns 200", func() {
@@ -88,6 +91,48 @@ var _ = Describe("Handler", func() {
 			It("calls the scoped handler", func() {
 				Expect(delegate.IsCalled).To(BeTrue())
 			})
+
+			Context("when instance vars are given as query params", func() {
+				BeforeEach(func() {
+					query = "&vars.branch=%22feature%22"
+				})
+
+				It("looks up the pipeline instance with the vars", func() {
+					Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+					Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature"},
+					}))
+				})
+			})
+
+			Context("when the instance vars are malformed", func() {
+				BeforeEach(func() {
+					query = "&vars.branch=feature"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not call the scoped handler", func() {
+					Expect(delegate.IsCalled).To(BeFalse())
+				})
+			})
+
+			Context("when the pipeline is archived", func() {
+				BeforeEach(func() {
+					teamDB.GetPipelineByRefReturns(db.SavedPipeline{Archived: true}, nil)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+
+				It("does not call the scoped handler", func() {
+					Expect(delegate.IsCalled).To(BeFalse())
+				})
+			})
 		})
 
 		Context("but not authorized", func() {
@@ -107,8 +152,8 @@ var _ = Describe("Handler", func() {
 				})
 
 				It("looks up the pipeline by the right name", func() {
-					Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-					Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("some-pipeline"))
+					Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+					Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				})
 
 				Context("and pipeline is public", func() {
@@ -177,8 +222,8 @@ var _ = Describe("Handler", func() {
 			})
 
 			It("looks up the pipeline by the right name", func() {
-				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
-				Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("some-pipeline"))
+				Expect(teamDB.GetPipelineByRefCallCount()).To(Equal(1))
+				Expect(teamDB.GetPipelineByRefArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 			})
 
 			Context("and pipeline is public", func() {
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
@@ -28,22 +29,25 @@ type radarSchedulerFactory struct {
 	tracker  resource.Tracker
 	interval time.Duration
 	engine   engine.Engine
+	secrets  creds.Secrets
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
 	engine engine.Engine,
+	secrets creds.Secrets,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		tracker:  tracker,
 		interval: interval,
 		engine:   engine,
+		secrets:  secrets,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, rsf.secrets, clock.NewClock(), externalURL)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
@@ -52,6 +56,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		rsf.tracker,
 		rsf.interval,
 		pipelineDB,
+		rsf.secrets,
 		externalURL,
 	)
 	return &scheduler.Scheduler{
@@ -5,13 +5,18 @@ type Plan struct {
 	Attempts []int  `json:"attempts,omitempty"`
 
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
+	InParallel   *InParallelPlan   `json:"in_parallel,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
 	Task         *TaskPlan         `json:"task,omitempty"`
+	LoadVar      *LoadVarPlan      `json:"load_var,omitempty"`
+	SetPipeline  *SetPipelinePlan  `json:"set_pipeline,omitempty"`
 	Ensure       *EnsurePlan       `json:"ensure,omitempty"`
 	OnSuccess    *OnSuccessPlan    `json:"on_success,omitempty"`
 	OnFailure    *OnFailurePlan    `json:"on_failure,omitempty"`
+	OnAbort      *OnAbortPlan      `json:"on_abort,omitempty"`
+	OnError      *OnErrorPlan      `json:"on_error,omitempty"`
 	Try          *TryPlan          `json:"try,omitempty"`
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
@@ -51,6 +56,16 @@ type OnFailurePlan struct {
 	Next Plan `json:"on_failure"`
 }
 
+type OnAbortPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_abort"`
+}
+
+type OnErrorPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_error"`
+}
+
 type EnsurePlan struct {
 	Step Plan `json:"step"`
 	Next Plan `json:"ensure"`
@@ -72,6 +87,12 @@ type TryPlan struct {
 
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -113,9 +134,29 @@ type TaskPlan struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
+
 	Pipeline      string        `json:"pipeline"`
 	PipelineID    int           `json:"pipeline_id"`
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 }
 
+// LoadVarPlan reads a file from an artifact and sets its contents, parsed
+// according to Format, as the local var Name.
+type LoadVarPlan struct {
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	Format    string `json:"format,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+// SetPipelinePlan configures the pipeline Name in the build's team using the
+// config in File, interpolated with vars from VarFiles and then Vars.
+type SetPipelinePlan struct {
+	Name     string                 `json:"name"`
+	File     string                 `json:"file"`
+	Vars     map[string]interface{} `json:"vars,omitempty"`
+	VarFiles []string               `json:"var_files,omitempty"`
+}
+
 type RetryPlan []Plan
@@ -27,6 +27,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 	switch t := step.(type) {
 	case AggregatePlan:
 		plan.Aggregate = &t
+	case InParallelPlan:
+		plan.InParallel = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -35,12 +37,20 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Put = &t
 	case TaskPlan:
 		plan.Task = &t
+	case LoadVarPlan:
+		plan.LoadVar = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
 	case EnsurePlan:
 		plan.Ensure = &t
 	case OnSuccessPlan:
 		plan.OnSuccess = &t
 	case OnFailurePlan:
 		plan.OnFailure = &t
+	case OnAbortPlan:
+		plan.OnAbort = &t
+	case OnErrorPlan:
+		plan.OnError = &t
 	case TryPlan:
 		plan.Try = &t
 	case DependentGetPlan:
@@ -244,6 +244,98 @@ var _ = Describe("Plan", func() {
 						},
 					},
 				},
+
+				atc.Plan{
+					ID: "26",
+					InParallel: &atc.InParallelPlan{
+						Steps: []atc.Plan{
+							atc.Plan{
+								ID: "27",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+						},
+						Limit:    1,
+						FailFast: true,
+					},
+				},
+
+				atc.Plan{
+					ID: "28",
+					LoadVar: &atc.LoadVarPlan{
+						Name:      "some-var",
+						File:      "some-source/some-file",
+						Format:    "json",
+						Sensitive: true,
+					},
+				},
+
+				atc.Plan{
+					ID: "29",
+					SetPipeline: &atc.SetPipelinePlan{
+						Name:     "some-pipeline",
+						File:     "some-source/pipeline.yml",
+						Vars:     map[string]interface{}{"some": "secret"},
+						VarFiles: []string{"some-source/vars.yml"},
+					},
+				},
+
+				atc.Plan{
+					ID: "30",
+					OnAbort: &atc.OnAbortPlan{
+						Step: atc.Plan{
+							ID: "31",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"some": "secret"},
+								},
+							},
+						},
+						Next: atc.Plan{
+							ID: "32",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"some": "secret"},
+								},
+							},
+						},
+					},
+				},
+
+				atc.Plan{
+					ID: "33",
+					OnError: &atc.OnErrorPlan{
+						Step: atc.Plan{
+							ID: "34",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"some": "secret"},
+								},
+							},
+						},
+						Next: atc.Plan{
+							ID: "35",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"some": "secret"},
+								},
+							},
+						},
+					},
+				},
 			},
 		}
 
@@ -417,6 +509,72 @@ var _ = Describe("Plan", func() {
           }
         }
       ]
+    },
+    {
+      "id": "26",
+      "in_parallel": {
+        "steps": [
+          {
+            "id": "27",
+            "task": {
+              "name": "name",
+              "privileged": false
+            }
+          }
+        ],
+        "limit": 1,
+        "fail_fast": true
+      }
+    },
+    {
+      "id": "28",
+      "load_var": {
+        "name": "some-var"
+      }
+    },
+    {
+      "id": "29",
+      "set_pipeline": {
+        "name": "some-pipeline"
+      }
+    },
+    {
+      "id": "30",
+      "on_abort": {
+        "step": {
+          "id": "31",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        },
+        "on_abort": {
+          "id": "32",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
+    },
+    {
+      "id": "33",
+      "on_error": {
+        "step": {
+          "id": "34",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        },
+        "on_error": {
+          "id": "35",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
     }
   ]
 }
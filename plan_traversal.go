@@ -29,6 +29,14 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 			}
 		}
 
+	case plan.InParallel != nil:
+		for i := range plan.InParallel.Steps {
+			err = pt.Traverse(&plan.InParallel.Steps[i])
+			if err != nil {
+				return err
+			}
+		}
+
 	case plan.Do != nil:
 		for i := range *plan.Do {
 			err = pt.Traverse(&(*plan.Do)[i])
@@ -57,6 +65,20 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 		}
 		return pt.Traverse(&plan.OnFailure.Next)
 
+	case plan.OnAbort != nil:
+		err = pt.Traverse(&plan.OnAbort.Step)
+		if err != nil {
+			return err
+		}
+		return pt.Traverse(&plan.OnAbort.Next)
+
+	case plan.OnError != nil:
+		err = pt.Traverse(&plan.OnError.Step)
+		if err != nil {
+			return err
+		}
+		return pt.Traverse(&plan.OnError.Next)
+
 	case plan.Ensure != nil:
 		err = pt.Traverse(&plan.Ensure.Step)
 		if err != nil {
@@ -178,13 +178,63 @@ var _ = Describe("PlanTraversal", func() {
 							},
 						},
 					},
+
+					atc.Plan{
+						ID: "26",
+						InParallel: &atc.InParallelPlan{
+							Steps: []atc.Plan{
+								atc.Plan{
+									ID: "27",
+									Task: &atc.TaskPlan{
+										Name: "name",
+									},
+								},
+							},
+						},
+					},
+
+					atc.Plan{
+						ID: "28",
+						OnAbort: &atc.OnAbortPlan{
+							Step: atc.Plan{
+								ID: "29",
+								Task: &atc.TaskPlan{
+									Name: "name",
+								},
+							},
+							Next: atc.Plan{
+								ID: "30",
+								Task: &atc.TaskPlan{
+									Name: "name",
+								},
+							},
+						},
+					},
+
+					atc.Plan{
+						ID: "31",
+						OnError: &atc.OnErrorPlan{
+							Step: atc.Plan{
+								ID: "32",
+								Task: &atc.TaskPlan{
+									Name: "name",
+								},
+							},
+							Next: atc.Plan{
+								ID: "33",
+								Task: &atc.TaskPlan{
+									Name: "name",
+								},
+							},
+						},
+					},
 				},
 			}
 
 			err := planTraversal.Traverse(plan)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(allPlans).To(HaveLen(26))
+			Expect(allPlans).To(HaveLen(34))
 			Expect(allPlans[0]).To(Equal(plan))
 			Expect(allPlans[1]).To(Equal(&(*plan.Aggregate)[0]))
 			Expect(allPlans[2]).To(Equal(&(*(*plan.Aggregate)[0].Aggregate)[0]))
@@ -211,6 +261,14 @@ var _ = Describe("PlanTraversal", func() {
 			Expect(allPlans[23]).To(Equal(&(*(*plan.Aggregate)[11].Retry)[0]))
 			Expect(allPlans[24]).To(Equal(&(*(*plan.Aggregate)[11].Retry)[1]))
 			Expect(allPlans[25]).To(Equal(&(*(*plan.Aggregate)[11].Retry)[2]))
+			Expect(allPlans[26]).To(Equal(&(*plan.Aggregate)[12]))
+			Expect(allPlans[27]).To(Equal(&(*plan.Aggregate)[12].InParallel.Steps[0]))
+			Expect(allPlans[28]).To(Equal(&(*plan.Aggregate)[13]))
+			Expect(allPlans[29]).To(Equal(&(*plan.Aggregate)[13].OnAbort.Step))
+			Expect(allPlans[30]).To(Equal(&(*plan.Aggregate)[13].OnAbort.Next))
+			Expect(allPlans[31]).To(Equal(&(*plan.Aggregate)[14]))
+			Expect(allPlans[32]).To(Equal(&(*plan.Aggregate)[14].OnError.Step))
+			Expect(allPlans[33]).To(Equal(&(*plan.Aggregate)[14].OnError.Next))
 		})
 		It("propagates errors from traverseFunc and stops the traversal", func() {
 			allPlans := []*atc.Plan{}
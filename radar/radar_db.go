@@ -12,6 +12,7 @@ import (
 
 type RadarDB interface {
 	GetPipelineName() string
+	GetTeamName() string
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
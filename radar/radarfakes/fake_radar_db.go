@@ -18,6 +18,12 @@ type FakeRadarDB struct {
 	getPipelineNameReturns     struct {
 		result1 string
 	}
+	GetTeamNameStub        func() string
+	getTeamNameMutex       sync.RWMutex
+	getTeamNameArgsForCall []struct{}
+	getTeamNameReturns     struct {
+		result1 string
+	}
 	GetPipelineIDStub        func() int
 	getPipelineIDMutex       sync.RWMutex
 	getPipelineIDArgsForCall []struct{}
@@ -182,6 +188,31 @@ func (fake *FakeRadarDB) GetPipelineNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRadarDB) GetTeamName() string {
+	fake.getTeamNameMutex.Lock()
+	fake.getTeamNameArgsForCall = append(fake.getTeamNameArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamName", []interface{}{})
+	fake.getTeamNameMutex.Unlock()
+	if fake.GetTeamNameStub != nil {
+		return fake.GetTeamNameStub()
+	} else {
+		return fake.getTeamNameReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) GetTeamNameCallCount() int {
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
+	return len(fake.getTeamNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetTeamNameReturns(result1 string) {
+	fake.GetTeamNameStub = nil
+	fake.getTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRadarDB) GetPipelineID() int {
 	fake.getPipelineIDMutex.Lock()
 	fake.getPipelineIDArgsForCall = append(fake.getPipelineIDArgsForCall, struct{}{})
@@ -678,6 +709,8 @@ func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.getPipelineNameMutex.RLock()
 	defer fake.getPipelineNameMutex.RUnlock()
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
 	fake.getPipelineIDMutex.RLock()
 	defer fake.getPipelineIDMutex.RUnlock()
 	fake.scopedNameMutex.RLock()
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -18,6 +19,7 @@ type resourceScanner struct {
 	tracker         resource.Tracker
 	defaultInterval time.Duration
 	db              RadarDB
+	secrets         creds.Secrets
 	externalURL     string
 }
 
@@ -26,6 +28,7 @@ func NewResourceScanner(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
 	db RadarDB,
+	secrets creds.Secrets,
 	externalURL string,
 ) Scanner {
 	return &resourceScanner{
@@ -33,6 +36,7 @@ func NewResourceScanner(
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
 		db:              db,
+		secrets:         secrets,
 		externalURL:     externalURL,
 	}
 }
@@ -194,6 +198,22 @@ func (scanner *resourceScanner) scan(
 
 	pipelineID := scanner.db.GetPipelineID()
 
+	variables := creds.NewVariables(scanner.secrets, scanner.db.GetTeamName(), scanner.db.GetPipelineName())
+
+	source, err := variables.EvaluateSource(resourceConfig.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		scanner.setCheckError(logger, savedResource, err)
+		return err
+	}
+
+	evaluatedResourceTypes, err := variables.EvaluateResourceTypes(resourceTypes)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+		scanner.setCheckError(logger, savedResource, err)
+		return err
+	}
+
 	var resourceTypeVersion atc.Version
 	_, found := resourceTypes.Lookup(resourceConfig.Type)
 	if found {
@@ -234,7 +254,7 @@ func (scanner *resourceScanner) scan(
 		resource.ResourceType(resourceConfig.Type),
 		[]string{},
 		scanner.db.TeamID(),
-		resourceTypes,
+		evaluatedResourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
 	if err != nil {
@@ -248,12 +268,9 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(resourceConfig.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
-	setErr := scanner.db.SetResourceCheckError(savedResource, err)
-	if setErr != nil {
-		logger.Error("failed-to-set-check-error", err)
-	}
+	scanner.setCheckError(logger, savedResource, err)
 
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
@@ -285,6 +302,13 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+func (scanner *resourceScanner) setCheckError(logger lager.Logger, savedResource db.SavedResource, err error) {
+	setErr := scanner.db.SetResourceCheckError(savedResource, err)
+	if setErr != nil {
+		logger.Error("failed-to-set-check-error", err)
+	}
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
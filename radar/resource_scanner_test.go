@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 
@@ -26,6 +28,7 @@ var _ = Describe("ResourceScanner", func() {
 
 		fakeTracker *rfakes.FakeTracker
 		fakeRadarDB *radarfakes.FakeRadarDB
+		fakeSecrets *credsfakes.FakeSecrets
 		fakeClock   *fakeclock.FakeClock
 		interval    time.Duration
 
@@ -42,6 +45,7 @@ var _ = Describe("ResourceScanner", func() {
 		epoch = time.Unix(123, 456).UTC()
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
+		fakeSecrets = new(credsfakes.FakeSecrets)
 		fakeClock = fakeclock.NewFakeClock(epoch)
 		interval = 1 * time.Minute
 
@@ -51,6 +55,7 @@ var _ = Describe("ResourceScanner", func() {
 			fakeTracker,
 			interval,
 			fakeRadarDB,
+			fakeSecrets,
 			"https://www.example.com",
 		)
 
@@ -167,6 +172,77 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 			})
 
+			Context("when the source and resource types reference secrets", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetTeamNameReturns("some-team")
+					fakeRadarDB.GetPipelineNameReturns("some-pipeline")
+
+					resourceConfig.Source = atc.Source{"uri": "((uri-secret))"}
+
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							resourceConfig,
+						},
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:   "some-custom-resource",
+								Type:   "docker-image",
+								Source: atc.Source{"custom": "((type-secret))"},
+							},
+						},
+					}, 1, true, nil)
+
+					fakeSecrets.GetStub = func(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+						return "resolved-" + secretName, true, nil
+					}
+				})
+
+				It("checks with the secrets resolved", func() {
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"uri": "resolved-uri-secret"}))
+				})
+
+				It("constructs the resource with the resource types' secrets resolved", func() {
+					_, _, session, _, _, _, customTypes, _ := fakeTracker.InitArgsForCall(0)
+					Expect(customTypes).To(Equal(atc.ResourceTypes{
+						{
+							Name:   "some-custom-resource",
+							Type:   "docker-image",
+							Source: atc.Source{"custom": "resolved-type-secret"},
+						},
+					}))
+
+					Expect(session.ID.CheckSource).To(Equal(atc.Source{"uri": "((uri-secret))"}))
+				})
+
+				It("looks up the secrets for the pipeline", func() {
+					teamName, pipelineName, _ := fakeSecrets.GetArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(pipelineName).To(Equal("some-pipeline"))
+				})
+
+				Context("when a secret is not defined", func() {
+					BeforeEach(func() {
+						fakeSecrets.GetStub = nil
+						fakeSecrets.GetReturns(nil, false, nil)
+					})
+
+					It("returns the error without checking", func() {
+						Expect(runErr).To(Equal(creds.UndefinedSecretError{Name: "uri-secret"}))
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+
+					It("sets the check error on the resource", func() {
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						_, checkErr := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(checkErr).To(Equal(creds.UndefinedSecretError{Name: "uri-secret"}))
+					})
+				})
+			})
+
 			Context("when the resource config has a specified check interval", func() {
 				BeforeEach(func() {
 					resourceConfig.CheckEvery = "10ms"
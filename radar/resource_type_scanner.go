@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -14,6 +15,7 @@ type resourceTypeScanner struct {
 	tracker         resource.Tracker
 	defaultInterval time.Duration
 	db              RadarDB
+	secrets         creds.Secrets
 	externalURL     string
 }
 
@@ -21,12 +23,14 @@ func NewResourceTypeScanner(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
 	db RadarDB,
+	secrets creds.Secrets,
 	externalURL string,
 ) Scanner {
 	return &resourceTypeScanner{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
 		db:              db,
+		secrets:         secrets,
 		externalURL:     externalURL,
 	}
 }
@@ -48,22 +52,28 @@ func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName st
 		return 0, err
 	}
 
+	interval, err := scanner.checkInterval(resourceType)
+	if err != nil {
+		logger.Error("failed-to-parse-check-every", err)
+		return 0, err
+	}
+
 	leaseLogger := logger.Session("lease", lager.Data{
 		"resource-type": resourceTypeName,
 	})
 
-	lease, leased, err := scanner.db.LeaseResourceTypeChecking(logger, resourceTypeName, scanner.defaultInterval, false)
+	lease, leased, err := scanner.db.LeaseResourceTypeChecking(logger, resourceTypeName, interval, false)
 
 	if err != nil {
 		leaseLogger.Error("failed-to-get-lease", err, lager.Data{
 			"resource-type": resourceTypeName,
 		})
-		return scanner.defaultInterval, ErrFailedToAcquireLease
+		return interval, ErrFailedToAcquireLease
 	}
 
 	if !leased {
 		leaseLogger.Debug("did-not-get-lease")
-		return scanner.defaultInterval, ErrFailedToAcquireLease
+		return interval, ErrFailedToAcquireLease
 	}
 
 	err = scanner.resourceTypeScan(logger.Session("tick"), resourceType)
@@ -74,7 +84,7 @@ func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName st
 		return 0, err
 	}
 
-	return scanner.defaultInterval, nil
+	return interval, nil
 }
 
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
@@ -99,6 +109,14 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	pipelineID := scanner.db.GetPipelineID()
 
+	variables := creds.NewVariables(scanner.secrets, scanner.db.GetTeamName(), scanner.db.GetPipelineName())
+
+	source, err := variables.EvaluateSource(resourceType.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		return err
+	}
+
 	session := resource.Session{
 		ID: worker.Identifier{
 			Stage:               db.ContainerStageCheck,
@@ -121,7 +139,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		resource.EmptyMetadata{},
 		session,
 		resource.ResourceType(resourceType.Type),
-		[]string{},
+		resourceType.Tags,
 		scanner.db.TeamID(),
 		atc.ResourceTypes{},
 		worker.NoopImageFetchingDelegate{},
@@ -134,7 +152,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	logger.Debug("checking")
 
-	newVersions, err := res.Check(resourceType.Source, atc.Version(from))
+	newVersions, err := res.Check(source, atc.Version(from))
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -167,6 +185,20 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 	return nil
 }
 
+func (scanner *resourceTypeScanner) checkInterval(resourceType atc.ResourceType) (time.Duration, error) {
+	interval := scanner.defaultInterval
+	if resourceType.CheckEvery != "" {
+		configuredInterval, err := time.ParseDuration(resourceType.CheckEvery)
+		if err != nil {
+			return 0, err
+		}
+
+		interval = configuredInterval
+	}
+
+	return interval, nil
+}
+
 func (scanner *resourceTypeScanner) getResourceTypeConfig(logger lager.Logger, resourceTypeName string) (atc.ResourceType, error) {
 	config, _, found, err := scanner.db.GetConfig()
 	if err != nil {
@@ -6,6 +6,8 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -24,6 +26,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 		fakeTracker *rfakes.FakeTracker
 		fakeRadarDB *radarfakes.FakeRadarDB
+		fakeSecrets *credsfakes.FakeSecrets
 		interval    time.Duration
 
 		scanner Scanner
@@ -38,6 +41,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		epoch = time.Unix(123, 456).UTC()
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
+		fakeSecrets = new(credsfakes.FakeSecrets)
 		interval = 1 * time.Minute
 
 		fakeRadarDB.GetPipelineIDReturns(42)
@@ -45,6 +49,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			fakeTracker,
 			interval,
 			fakeRadarDB,
+			fakeSecrets,
 			"https://www.example.com",
 		)
 
@@ -142,6 +147,45 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Expect(delegate).To(Equal(worker.NoopImageFetchingDelegate{}))
 			})
 
+			Context("when the source references secrets", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:   "some-resource-type",
+								Type:   "docker-image",
+								Source: atc.Source{"custom": "((type-secret))"},
+							},
+						},
+					}, 1, true, nil)
+
+					fakeSecrets.GetReturns("resolved-secret", true, nil)
+				})
+
+				It("checks with the secrets resolved", func() {
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"custom": "resolved-secret"}))
+				})
+
+				It("does not resolve the secrets in the container's identifier", func() {
+					_, _, session, _, _, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(session.ID.CheckSource).To(Equal(atc.Source{"custom": "((type-secret))"}))
+				})
+
+				Context("when a secret is not defined", func() {
+					BeforeEach(func() {
+						fakeSecrets.GetReturns(nil, false, nil)
+					})
+
+					It("returns the error without checking", func() {
+						Expect(runErr).To(Equal(creds.UndefinedSecretError{Name: "type-secret"}))
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			It("grabs a periodic resource checking lease before checking, breaks lease after done", func() {
 				Expect(fakeRadarDB.LeaseResourceTypeCheckingCallCount()).To(Equal(1))
 
@@ -157,6 +201,53 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Eventually(fakeResource.ReleaseCallCount).Should(Equal(1))
 			})
 
+			Context("when the resource type configures check_every and tags", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:       "some-resource-type",
+								Type:       "docker-image",
+								Source:     atc.Source{"custom": "source"},
+								CheckEvery: "10ms",
+								Tags:       atc.Tags{"some-tag"},
+							},
+						},
+					}, 1, true, nil)
+				})
+
+				It("leases for and returns the configured interval", func() {
+					_, _, leaseInterval, _ := fakeRadarDB.LeaseResourceTypeCheckingArgsForCall(0)
+					Expect(leaseInterval).To(Equal(10 * time.Millisecond))
+					Expect(actualInterval).To(Equal(10 * time.Millisecond))
+				})
+
+				It("checks on workers with the configured tags", func() {
+					_, _, _, _, tags, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(tags).To(Equal(atc.Tags{"some-tag"}))
+				})
+			})
+
+			Context("when the resource type has an invalid check_every", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:       "some-resource-type",
+								Type:       "docker-image",
+								CheckEvery: "bogus",
+							},
+						},
+					}, 1, true, nil)
+				})
+
+				It("returns an error without checking", func() {
+					Expect(runErr).To(HaveOccurred())
+					Expect(fakeRadarDB.LeaseResourceTypeCheckingCallCount()).To(BeZero())
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when there is no current version", func() {
 				It("checks from nil", func() {
 					_, version := fakeResource.CheckArgsForCall(0)
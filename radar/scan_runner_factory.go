@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
 
@@ -43,6 +44,7 @@ func NewScanRunnerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
 	db RadarDB,
+	secrets creds.Secrets,
 	clock clock.Clock,
 	externalURL string,
 ) ScanRunnerFactory {
@@ -51,12 +53,14 @@ func NewScanRunnerFactory(
 		tracker,
 		defaultInterval,
 		db,
+		secrets,
 		externalURL,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
 		tracker,
 		defaultInterval,
 		db,
+		secrets,
 		externalURL,
 	)
 
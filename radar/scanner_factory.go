@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 )
 
@@ -14,21 +15,24 @@ type ScannerFactory interface {
 type scannerFactory struct {
 	tracker         resource.Tracker
 	defaultInterval time.Duration
+	secrets         creds.Secrets
 	externalURL     string
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	secrets creds.Secrets,
 	externalURL string,
 ) ScannerFactory {
 	return &scannerFactory{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
+		secrets:         secrets,
 		externalURL:     externalURL,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.secrets, f.externalURL)
 }
@@ -10,4 +10,12 @@ type Resource struct {
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
+
+	PinnedVersion Version `json:"pinned_version,omitempty"`
+	PinComment    string  `json:"pin_comment,omitempty"`
+	PinnedBy      string  `json:"pinned_by,omitempty"`
+}
+
+type PinVersionRequestBody struct {
+	Comment string `json:"pin_comment"`
 }
@@ -95,11 +95,10 @@ func (tracker *tracker) InitWithSources(
 		return NewResource(container), missingNames, nil
 	}
 
+	imageSpec, tags := resourceImageSpec(typ, tags, resourceTypes)
+
 	resourceSpec := worker.ContainerSpec{
-		ImageSpec: worker.ImageSpec{
-			ResourceType: string(typ),
-			Privileged:   true,
-		},
+		ImageSpec: imageSpec,
 		Ephemeral: session.Ephemeral,
 		Tags:      tags,
 		TeamID:    teamID,
@@ -206,6 +205,8 @@ func (tracker *tracker) Init(
 
 	logger.Debug("tracker-init-creating-container", lager.Data{"container-id": session.ID})
 
+	imageSpec, tags := resourceImageSpec(typ, tags, resourceTypes)
+
 	container, err = tracker.workerClient.CreateContainer(
 		logger,
 		nil,
@@ -213,10 +214,7 @@ func (tracker *tracker) Init(
 		session.ID,
 		session.Metadata,
 		worker.ContainerSpec{
-			ImageSpec: worker.ImageSpec{
-				ResourceType: string(typ),
-				Privileged:   true,
-			},
+			ImageSpec: imageSpec,
 			Ephemeral: session.Ephemeral,
 			Tags:      tags,
 			TeamID:    teamID,
@@ -232,3 +230,28 @@ func (tracker *tracker) Init(
 
 	return NewResource(container), nil
 }
+
+// resourceImageSpec determines the image and worker tags for a resource's
+// container. Built-in resource types always run privileged, as do custom types
+// unless configured otherwise. Custom types add their own tags to the step's.
+func resourceImageSpec(typ ResourceType, tags atc.Tags, resourceTypes atc.ResourceTypes) (worker.ImageSpec, atc.Tags) {
+	imageSpec := worker.ImageSpec{
+		ResourceType: string(typ),
+		Privileged:   true,
+	}
+
+	customType, found := resourceTypes.Lookup(string(typ))
+	if !found {
+		return imageSpec, tags
+	}
+
+	if customType.Privileged != nil {
+		imageSpec.Privileged = *customType.Privileged
+	}
+
+	if len(customType.Tags) > 0 {
+		tags = append(append(atc.Tags{}, tags...), customType.Tags...)
+	}
+
+	return imageSpec, tags
+}
@@ -107,6 +107,52 @@ var _ = Describe("Tracker", func() {
 				Expect(actualCustomTypes).To(Equal(customTypes))
 			})
 
+			Context("when the resource's type is a custom type", func() {
+				BeforeEach(func() {
+					initType = "custom-type-a"
+				})
+
+				It("creates a privileged container by default", func() {
+					_, _, _, _, _, spec, _ := workerClient.CreateContainerArgsForCall(0)
+					Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
+						ResourceType: "custom-type-a",
+						Privileged:   true,
+					}))
+				})
+
+				Context("when the custom type is configured to be unprivileged", func() {
+					BeforeEach(func() {
+						privileged := false
+						customTypes[0].Privileged = &privileged
+					})
+
+					It("creates an unprivileged container", func() {
+						_, _, _, _, _, spec, _ := workerClient.CreateContainerArgsForCall(0)
+						Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
+							ResourceType: "custom-type-a",
+							Privileged:   false,
+						}))
+					})
+				})
+
+				Context("when the custom type is privileged and has tags", func() {
+					BeforeEach(func() {
+						privileged := true
+						customTypes[0].Privileged = &privileged
+						customTypes[0].Tags = atc.Tags{"type", "tags"}
+					})
+
+					It("creates a privileged container on workers with both the step's and the type's tags", func() {
+						_, _, _, _, _, spec, _ := workerClient.CreateContainerArgsForCall(0)
+						Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
+							ResourceType: "custom-type-a",
+							Privileged:   true,
+						}))
+						Expect(spec.Tags).To(ConsistOf("resource", "tags", "type", "tags"))
+					})
+				})
+			})
+
 			Context("when creating the container fails", func() {
 				disaster := errors.New("oh no!")
 
@@ -322,6 +368,25 @@ var _ = Describe("Tracker", func() {
 					})
 				})
 
+				Context("when the resource's type is a custom type with tags", func() {
+					BeforeEach(func() {
+						initType = "custom-type-a"
+						customTypes[0].Tags = atc.Tags{"type", "tags"}
+					})
+
+					It("chooses a worker satisfying both the step's and the type's tags", func() {
+						Expect(workerClient.AllSatisfyingCallCount()).To(Equal(1))
+						actualSpec, _ := workerClient.AllSatisfyingArgsForCall(0)
+						Expect(actualSpec.Tags).To(ConsistOf("resource", "tags", "type", "tags"))
+					})
+
+					It("creates a privileged container by default", func() {
+						Expect(satisfyingWorker.CreateContainerCallCount()).To(Equal(1))
+						_, _, _, _, _, spec, _ := satisfyingWorker.CreateContainerArgsForCall(0)
+						Expect(spec.ImageSpec.Privileged).To(BeTrue())
+					})
+				})
+
 				Context("when there are no volumes on the container (e.g. doesn't support volumes)", func() {
 					BeforeEach(func() {
 						inputSource1.VolumeOnReturns(nil, false, nil)
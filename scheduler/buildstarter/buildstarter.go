@@ -14,6 +14,7 @@ type BuildStarter interface {
 	TryStartAllPendingBuilds(
 		logger lager.Logger,
 		jobConfig atc.JobConfig,
+		serialGroupConfigs atc.SerialGroupConfigs,
 		resourceConfigs atc.ResourceConfigs,
 		resourceTypes atc.ResourceTypes,
 	) error
@@ -66,13 +67,14 @@ type buildStarter struct {
 func (s *buildStarter) TryStartAllPendingBuilds(
 	logger lager.Logger,
 	jobConfig atc.JobConfig,
+	serialGroupConfigs atc.SerialGroupConfigs,
 	resourceConfigs atc.ResourceConfigs,
 	resourceTypes atc.ResourceTypes,
 ) error {
 	started := true
 	for started {
 		var err error
-		started, err = s.tryStartNextPendingBuild(logger, jobConfig, resourceConfigs, resourceTypes)
+		started, err = s.tryStartNextPendingBuild(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
 		if err != nil {
 			return err
 		}
@@ -84,6 +86,7 @@ func (s *buildStarter) TryStartAllPendingBuilds(
 func (s *buildStarter) tryStartNextPendingBuild(
 	logger lager.Logger,
 	jobConfig atc.JobConfig,
+	serialGroupConfigs atc.SerialGroupConfigs,
 	resourceConfigs atc.ResourceConfigs,
 	resourceTypes atc.ResourceTypes,
 ) (bool, error) {
@@ -103,7 +106,7 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		"build-name": nextPendingBuild.Name(),
 	})
 
-	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, jobConfig, nextPendingBuild.ID())
+	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, jobConfig, serialGroupConfigs, nextPendingBuild.ID())
 	if err != nil {
 		return false, err
 	}
@@ -111,13 +114,23 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	buildInputs, found, err := s.db.GetNextBuildInputs(jobConfig.Name)
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
-	if !found {
-		return false, nil
+	var buildInputs []db.BuildInput
+	if nextPendingBuild.IsManuallyPinned() {
+		// the inputs were chosen when the build was created
+		buildInputs, _, err = nextPendingBuild.GetResources()
+		if err != nil {
+			logger.Error("failed-to-get-manually-pinned-build-inputs", err)
+			return false, err
+		}
+	} else {
+		buildInputs, found, err = s.db.GetNextBuildInputs(jobConfig.Name)
+		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
+			return false, err
+		}
+		if !found {
+			return false, nil
+		}
 	}
 
 	pipelinePaused, err := s.db.IsPaused()
@@ -149,9 +162,11 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	err = s.db.UseInputsForBuild(nextPendingBuild.ID(), buildInputs)
-	if err != nil {
-		return false, err
+	if !nextPendingBuild.IsManuallyPinned() {
+		err = s.db.UseInputsForBuild(nextPendingBuild.ID(), buildInputs)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	plan, err := s.factory.Create(jobConfig, resourceConfigs, resourceTypes, buildInputs)
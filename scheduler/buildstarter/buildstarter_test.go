@@ -46,6 +46,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 			tryStartErr = buildStarter.TryStartAllPendingBuilds(
 				lagertest.NewTestLogger("test"),
 				atc.JobConfig{Name: "some-job"},
+				atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}},
 				atc.ResourceConfigs{{Name: "some-resource"}},
 				atc.ResourceTypes{{Name: "some-resource-type"}})
 		})
@@ -267,8 +268,9 @@ var _ = Describe("I'm a BuildStarter", func() {
 				itUpdatedMaxInFlightForTheRightJob := func() {
 					It("updated max in flight for the right job", func() {
 						Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
-						_, actualJobConfig, actualBuildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
+						_, actualJobConfig, actualSerialGroups, actualBuildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
 						Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+						Expect(actualSerialGroups).To(Equal(atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}}))
 						Expect(actualBuildID).To(Equal(99))
 					})
 				}
@@ -344,6 +346,54 @@ var _ = Describe("I'm a BuildStarter", func() {
 					itUpdatedMaxInFlightForTheRightJob()
 				})
 			})
+
+			Context("when there is a manually pinned pending build", func() {
+				var pendingBuild *dbfakes.FakeBuild
+
+				BeforeEach(func() {
+					pendingBuild = new(dbfakes.FakeBuild)
+					pendingBuild.IDReturns(99)
+					pendingBuild.IsManuallyPinnedReturns(true)
+					pendingBuild.GetResourcesReturns([]db.BuildInput{{Name: "some-chosen-input"}}, nil, nil)
+
+					fakeDB.GetNextPendingBuildStub = func(string) (db.Build, bool, error) {
+						if fakeDB.GetNextPendingBuildCallCount() == 1 {
+							return pendingBuild, true, nil
+						}
+						return nil, false, nil
+					}
+
+					fakeDB.UpdateBuildToScheduledReturns(true, nil)
+					fakeFactory.CreateReturns(atc.Plan{}, nil)
+					fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+				})
+
+				It("creates the build plan with the build's own inputs", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+					_, _, _, actualInputs := fakeFactory.CreateArgsForCall(0)
+					Expect(actualInputs).To(Equal([]db.BuildInput{{Name: "some-chosen-input"}}))
+				})
+
+				It("does not use the job's next build inputs", func() {
+					Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+					Expect(fakeDB.UseInputsForBuildCallCount()).To(BeZero())
+				})
+
+				Context("when getting the build's inputs fails", func() {
+					BeforeEach(func() {
+						pendingBuild.GetResourcesReturns(nil, nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(tryStartErr).To(Equal(disaster))
+					})
+
+					It("does not mark the build as scheduled", func() {
+						Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+					})
+				})
+			})
 		})
 	})
 })
@@ -10,13 +10,14 @@ import (
 )
 
 type FakeBuildStarter struct {
-	TryStartAllPendingBuildsStub        func(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error
+	TryStartAllPendingBuildsStub        func(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error
 	tryStartAllPendingBuildsMutex       sync.RWMutex
 	tryStartAllPendingBuildsArgsForCall []struct {
-		logger          lager.Logger
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
 	}
 	tryStartAllPendingBuildsReturns struct {
 		result1 error
@@ -25,18 +26,19 @@ type FakeBuildStarter struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildStarter) TryStartAllPendingBuilds(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error {
+func (fake *FakeBuildStarter) TryStartAllPendingBuilds(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error {
 	fake.tryStartAllPendingBuildsMutex.Lock()
 	fake.tryStartAllPendingBuildsArgsForCall = append(fake.tryStartAllPendingBuildsArgsForCall, struct {
-		logger          lager.Logger
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
-	}{logger, jobConfig, resourceConfigs, resourceTypes})
-	fake.recordInvocation("TryStartAllPendingBuilds", []interface{}{logger, jobConfig, resourceConfigs, resourceTypes})
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
+	}{logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes})
+	fake.recordInvocation("TryStartAllPendingBuilds", []interface{}{logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes})
 	fake.tryStartAllPendingBuildsMutex.Unlock()
 	if fake.TryStartAllPendingBuildsStub != nil {
-		return fake.TryStartAllPendingBuildsStub(logger, jobConfig, resourceConfigs, resourceTypes)
+		return fake.TryStartAllPendingBuildsStub(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
 	} else {
 		return fake.tryStartAllPendingBuildsReturns.result1
 	}
@@ -48,10 +50,10 @@ func (fake *FakeBuildStarter) TryStartAllPendingBuildsCallCount() int {
 	return len(fake.tryStartAllPendingBuildsArgsForCall)
 }
 
-func (fake *FakeBuildStarter) TryStartAllPendingBuildsArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+func (fake *FakeBuildStarter) TryStartAllPendingBuildsArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.SerialGroupConfigs, atc.ResourceConfigs, atc.ResourceTypes) {
 	fake.tryStartAllPendingBuildsMutex.RLock()
 	defer fake.tryStartAllPendingBuildsMutex.RUnlock()
-	return fake.tryStartAllPendingBuildsArgsForCall[i].logger, fake.tryStartAllPendingBuildsArgsForCall[i].jobConfig, fake.tryStartAllPendingBuildsArgsForCall[i].resourceConfigs, fake.tryStartAllPendingBuildsArgsForCall[i].resourceTypes
+	return fake.tryStartAllPendingBuildsArgsForCall[i].logger, fake.tryStartAllPendingBuildsArgsForCall[i].jobConfig, fake.tryStartAllPendingBuildsArgsForCall[i].serialGroupConfigs, fake.tryStartAllPendingBuildsArgsForCall[i].resourceConfigs, fake.tryStartAllPendingBuildsArgsForCall[i].resourceTypes
 }
 
 func (fake *FakeBuildStarter) TryStartAllPendingBuildsReturns(result1 error) {
@@ -10,12 +10,13 @@ import (
 )
 
 type FakeUpdater struct {
-	UpdateMaxInFlightReachedStub        func(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
+	UpdateMaxInFlightReachedStub        func(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, buildID int) (bool, error)
 	updateMaxInFlightReachedMutex       sync.RWMutex
 	updateMaxInFlightReachedArgsForCall []struct {
-		logger    lager.Logger
-		jobConfig atc.JobConfig
-		buildID   int
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		buildID            int
 	}
 	updateMaxInFlightReachedReturns struct {
 		result1 bool
@@ -25,17 +26,18 @@ type FakeUpdater struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeUpdater) UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+func (fake *FakeUpdater) UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, buildID int) (bool, error) {
 	fake.updateMaxInFlightReachedMutex.Lock()
 	fake.updateMaxInFlightReachedArgsForCall = append(fake.updateMaxInFlightReachedArgsForCall, struct {
-		logger    lager.Logger
-		jobConfig atc.JobConfig
-		buildID   int
-	}{logger, jobConfig, buildID})
-	fake.recordInvocation("UpdateMaxInFlightReached", []interface{}{logger, jobConfig, buildID})
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		buildID            int
+	}{logger, jobConfig, serialGroupConfigs, buildID})
+	fake.recordInvocation("UpdateMaxInFlightReached", []interface{}{logger, jobConfig, serialGroupConfigs, buildID})
 	fake.updateMaxInFlightReachedMutex.Unlock()
 	if fake.UpdateMaxInFlightReachedStub != nil {
-		return fake.UpdateMaxInFlightReachedStub(logger, jobConfig, buildID)
+		return fake.UpdateMaxInFlightReachedStub(logger, jobConfig, serialGroupConfigs, buildID)
 	} else {
 		return fake.updateMaxInFlightReachedReturns.result1, fake.updateMaxInFlightReachedReturns.result2
 	}
@@ -47,10 +49,10 @@ func (fake *FakeUpdater) UpdateMaxInFlightReachedCallCount() int {
 	return len(fake.updateMaxInFlightReachedArgsForCall)
 }
 
-func (fake *FakeUpdater) UpdateMaxInFlightReachedArgsForCall(i int) (lager.Logger, atc.JobConfig, int) {
+func (fake *FakeUpdater) UpdateMaxInFlightReachedArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.SerialGroupConfigs, int) {
 	fake.updateMaxInFlightReachedMutex.RLock()
 	defer fake.updateMaxInFlightReachedMutex.RUnlock()
-	return fake.updateMaxInFlightReachedArgsForCall[i].logger, fake.updateMaxInFlightReachedArgsForCall[i].jobConfig, fake.updateMaxInFlightReachedArgsForCall[i].buildID
+	return fake.updateMaxInFlightReachedArgsForCall[i].logger, fake.updateMaxInFlightReachedArgsForCall[i].jobConfig, fake.updateMaxInFlightReachedArgsForCall[i].serialGroupConfigs, fake.updateMaxInFlightReachedArgsForCall[i].buildID
 }
 
 func (fake *FakeUpdater) UpdateMaxInFlightReachedReturns(result1 bool, result2 error) {
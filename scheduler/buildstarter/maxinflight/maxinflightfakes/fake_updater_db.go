@@ -4,6 +4,7 @@ package maxinflightfakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/scheduler/buildstarter/maxinflight"
 )
@@ -19,6 +20,15 @@ type FakeUpdaterDB struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetRunningJobBuildsStub        func(jobName string) ([]db.Build, error)
+	getRunningJobBuildsMutex       sync.RWMutex
+	getRunningJobBuildsArgsForCall []struct {
+		jobName string
+	}
+	getRunningJobBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetNextPendingBuildBySerialGroupStub        func(jobName string, serialGroups []string) (db.Build, bool, error)
 	getNextPendingBuildBySerialGroupMutex       sync.RWMutex
 	getNextPendingBuildBySerialGroupArgsForCall []struct {
@@ -30,6 +40,16 @@ type FakeUpdaterDB struct {
 		result2 bool
 		result3 error
 	}
+	GetSerialGroupQueuesStub        func(jobName string, serialGroups []string) (map[string]db.SerialGroupQueue, error)
+	getSerialGroupQueuesMutex       sync.RWMutex
+	getSerialGroupQueuesArgsForCall []struct {
+		jobName      string
+		serialGroups []string
+	}
+	getSerialGroupQueuesReturns struct {
+		result1 map[string]db.SerialGroupQueue
+		result2 error
+	}
 	SetMaxInFlightReachedStub        func(jobName string, reached bool) error
 	setMaxInFlightReachedMutex       sync.RWMutex
 	setMaxInFlightReachedArgsForCall []struct {
@@ -83,6 +103,40 @@ func (fake *FakeUpdaterDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Bui
 	}{result1, result2}
 }
 
+func (fake *FakeUpdaterDB) GetRunningJobBuilds(jobName string) ([]db.Build, error) {
+	fake.getRunningJobBuildsMutex.Lock()
+	fake.getRunningJobBuildsArgsForCall = append(fake.getRunningJobBuildsArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetRunningJobBuilds", []interface{}{jobName})
+	fake.getRunningJobBuildsMutex.Unlock()
+	if fake.GetRunningJobBuildsStub != nil {
+		return fake.GetRunningJobBuildsStub(jobName)
+	} else {
+		return fake.getRunningJobBuildsReturns.result1, fake.getRunningJobBuildsReturns.result2
+	}
+}
+
+func (fake *FakeUpdaterDB) GetRunningJobBuildsCallCount() int {
+	fake.getRunningJobBuildsMutex.RLock()
+	defer fake.getRunningJobBuildsMutex.RUnlock()
+	return len(fake.getRunningJobBuildsArgsForCall)
+}
+
+func (fake *FakeUpdaterDB) GetRunningJobBuildsArgsForCall(i int) string {
+	fake.getRunningJobBuildsMutex.RLock()
+	defer fake.getRunningJobBuildsMutex.RUnlock()
+	return fake.getRunningJobBuildsArgsForCall[i].jobName
+}
+
+func (fake *FakeUpdaterDB) GetRunningJobBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningJobBuildsStub = nil
+	fake.getRunningJobBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUpdaterDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error) {
 	var serialGroupsCopy []string
 	if serialGroups != nil {
@@ -124,6 +178,46 @@ func (fake *FakeUpdaterDB) GetNextPendingBuildBySerialGroupReturns(result1 db.Bu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeUpdaterDB) GetSerialGroupQueues(jobName string, serialGroups []string) (map[string]db.SerialGroupQueue, error) {
+	var serialGroupsCopy []string
+	if serialGroups != nil {
+		serialGroupsCopy = make([]string, len(serialGroups))
+		copy(serialGroupsCopy, serialGroups)
+	}
+	fake.getSerialGroupQueuesMutex.Lock()
+	fake.getSerialGroupQueuesArgsForCall = append(fake.getSerialGroupQueuesArgsForCall, struct {
+		jobName      string
+		serialGroups []string
+	}{jobName, serialGroupsCopy})
+	fake.recordInvocation("GetSerialGroupQueues", []interface{}{jobName, serialGroupsCopy})
+	fake.getSerialGroupQueuesMutex.Unlock()
+	if fake.GetSerialGroupQueuesStub != nil {
+		return fake.GetSerialGroupQueuesStub(jobName, serialGroups)
+	} else {
+		return fake.getSerialGroupQueuesReturns.result1, fake.getSerialGroupQueuesReturns.result2
+	}
+}
+
+func (fake *FakeUpdaterDB) GetSerialGroupQueuesCallCount() int {
+	fake.getSerialGroupQueuesMutex.RLock()
+	defer fake.getSerialGroupQueuesMutex.RUnlock()
+	return len(fake.getSerialGroupQueuesArgsForCall)
+}
+
+func (fake *FakeUpdaterDB) GetSerialGroupQueuesArgsForCall(i int) (string, []string) {
+	fake.getSerialGroupQueuesMutex.RLock()
+	defer fake.getSerialGroupQueuesMutex.RUnlock()
+	return fake.getSerialGroupQueuesArgsForCall[i].jobName, fake.getSerialGroupQueuesArgsForCall[i].serialGroups
+}
+
+func (fake *FakeUpdaterDB) GetSerialGroupQueuesReturns(result1 map[string]db.SerialGroupQueue, result2 error) {
+	fake.GetSerialGroupQueuesStub = nil
+	fake.getSerialGroupQueuesReturns = struct {
+		result1 map[string]db.SerialGroupQueue
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUpdaterDB) SetMaxInFlightReached(jobName string, reached bool) error {
 	fake.setMaxInFlightReachedMutex.Lock()
 	fake.setMaxInFlightReachedArgsForCall = append(fake.setMaxInFlightReachedArgsForCall, struct {
@@ -163,8 +257,12 @@ func (fake *FakeUpdaterDB) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.getRunningBuildsBySerialGroupMutex.RLock()
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	fake.getRunningJobBuildsMutex.RLock()
+	defer fake.getRunningJobBuildsMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	fake.getSerialGroupQueuesMutex.RLock()
+	defer fake.getSerialGroupQueuesMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
 	return fake.invocations
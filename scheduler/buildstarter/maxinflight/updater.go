@@ -9,14 +9,16 @@ import (
 //go:generate counterfeiter . Updater
 
 type Updater interface {
-	UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
+	UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, buildID int) (bool, error)
 }
 
 //go:generate counterfeiter . UpdaterDB
 
 type UpdaterDB interface {
 	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error)
+	GetRunningJobBuilds(jobName string) ([]db.Build, error)
 	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error)
+	GetSerialGroupQueues(jobName string, serialGroups []string) (map[string]db.SerialGroupQueue, error)
 	SetMaxInFlightReached(jobName string, reached bool) error
 }
 
@@ -28,9 +30,9 @@ type updater struct {
 	db UpdaterDB
 }
 
-func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, buildID int) (bool, error) {
 	logger = logger.Session("is-max-in-flight-reached")
-	reached, err := u.isMaxInFlightReached(logger, jobConfig, buildID)
+	reached, err := u.isMaxInFlightReached(logger, jobConfig, serialGroupConfigs, buildID)
 	if err != nil {
 		return false, err
 	}
@@ -44,13 +46,22 @@ func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.Jo
 	return reached, nil
 }
 
-func (u *updater) isMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+func (u *updater) isMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, buildID int) (bool, error) {
 	maxInFlight := jobConfig.MaxInFlight()
 
 	if maxInFlight == 0 {
 		return false, nil
 	}
 
+	if hasConfiguredSerialGroup(serialGroupConfigs, jobConfig.SerialGroups) {
+		reached, err := u.isSerialGroupCapacityReached(logger, serialGroupConfigs, jobConfig, buildID)
+		if err != nil || reached {
+			return reached, err
+		}
+
+		return u.isJobMaxInFlightReached(logger, jobConfig)
+	}
+
 	builds, err := u.db.GetRunningBuildsBySerialGroup(jobConfig.Name, jobConfig.GetSerialGroups())
 	if err != nil {
 		logger.Error("failed-to-get-running-builds-by-serial-group", err)
@@ -74,3 +85,62 @@ func (u *updater) isMaxInFlightReached(logger lager.Logger, jobConfig atc.JobCon
 
 	return nextMostPendingBuild.ID() != buildID, nil
 }
+
+// isSerialGroupCapacityReached checks each of the job's serial groups
+// separately, allowing the build to run only if, in every group, the builds
+// already running plus those ahead of it in line leave room under the group's
+// capacity.
+func (u *updater) isSerialGroupCapacityReached(logger lager.Logger, serialGroupConfigs atc.SerialGroupConfigs, jobConfig atc.JobConfig, buildID int) (bool, error) {
+	queues, err := u.db.GetSerialGroupQueues(jobConfig.Name, jobConfig.SerialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-serial-group-queues", err)
+		return false, err
+	}
+
+	for _, serialGroup := range jobConfig.SerialGroups {
+		queue := queues[serialGroup]
+
+		position := queue.Position(buildID)
+		if position == -1 {
+			logger.Info("pending-build-disappeared-from-serial-group", lager.Data{"serial-group": serialGroup})
+			return true, nil
+		}
+
+		if queue.Running+position >= serialGroupConfigs.MaxInFlight(serialGroup) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isJobMaxInFlightReached checks the job's own serial or max_in_flight limit,
+// which still applies when its serial groups allow more than one build.
+func (u *updater) isJobMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig) (bool, error) {
+	maxInFlight := jobConfig.RawMaxInFlight
+	if jobConfig.Serial {
+		maxInFlight = 1
+	}
+
+	if maxInFlight == 0 {
+		return false, nil
+	}
+
+	builds, err := u.db.GetRunningJobBuilds(jobConfig.Name)
+	if err != nil {
+		logger.Error("failed-to-get-running-job-builds", err)
+		return false, err
+	}
+
+	return len(builds) >= maxInFlight, nil
+}
+
+func hasConfiguredSerialGroup(serialGroupConfigs atc.SerialGroupConfigs, serialGroups []string) bool {
+	for _, serialGroup := range serialGroups {
+		if _, found := serialGroupConfigs.Lookup(serialGroup); found {
+			return true
+		}
+	}
+
+	return false
+}
@@ -29,18 +29,27 @@ var _ = Describe("Updater", func() {
 
 	Describe("UpdateMaxInFlightReached", func() {
 		var rawMaxInFlight int
+		var serial bool
 		var serialGroups []string
+		var serialGroupConfigs atc.SerialGroupConfigs
 		var updateErr error
 		var reached bool
 
+		BeforeEach(func() {
+			serial = false
+			serialGroupConfigs = nil
+		})
+
 		JustBeforeEach(func() {
 			reached, updateErr = updater.UpdateMaxInFlightReached(
 				lagertest.NewTestLogger("test"),
 				atc.JobConfig{
 					Name:           "some-job",
+					Serial:         serial,
 					SerialGroups:   serialGroups,
 					RawMaxInFlight: rawMaxInFlight,
 				},
+				serialGroupConfigs,
 				57,
 			)
 		})
@@ -245,5 +254,141 @@ var _ = Describe("Updater", func() {
 				itReturnsFalseIfOurBuildIsNext()
 			})
 		})
+
+		Context("when the job is in serial groups with configured capacity", func() {
+			BeforeEach(func() {
+				rawMaxInFlight = 0
+				serialGroups = []string{"serial-group-1", "serial-group-2"}
+
+				serialGroupConfigs = atc.SerialGroupConfigs{
+					{Name: "serial-group-1", MaxInFlight: 3},
+				}
+			})
+
+			Context("when looking up the serial group queues fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetSerialGroupQueuesReturns(nil, disaster)
+				})
+
+				itReturnsTheError()
+
+				It("looked up the queues with the right job name and serial groups", func() {
+					Expect(fakeDB.GetSerialGroupQueuesCallCount()).To(Equal(1))
+					actualJobName, actualSerialGroups := fakeDB.GetSerialGroupQueuesArgsForCall(0)
+					Expect(actualJobName).To(Equal("some-job"))
+					Expect(actualSerialGroups).To(ConsistOf("serial-group-1", "serial-group-2"))
+				})
+			})
+
+			Context("when the configured group has room for the build", func() {
+				BeforeEach(func() {
+					fakeDB.GetSerialGroupQueuesReturns(map[string]db.SerialGroupQueue{
+						"serial-group-1": {Running: 1, Pending: []int{42, 57}},
+						"serial-group-2": {Running: 0, Pending: []int{57}},
+					}, nil)
+				})
+
+				itReturnsFalseAndNoError()
+
+				It("doesn't use the serial-group-wide lookups", func() {
+					Expect(fakeDB.GetRunningBuildsBySerialGroupCallCount()).To(BeZero())
+					Expect(fakeDB.GetNextPendingBuildBySerialGroupCallCount()).To(BeZero())
+				})
+
+				It("doesn't look up the job's running builds", func() {
+					Expect(fakeDB.GetRunningJobBuildsCallCount()).To(BeZero())
+				})
+
+				Context("when the job is serial", func() {
+					BeforeEach(func() {
+						serial = true
+					})
+
+					Context("when the job already has a build running", func() {
+						BeforeEach(func() {
+							fakeDB.GetRunningJobBuildsReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+						})
+
+						itReturnsTrueAndNoError()
+
+						It("looked up the running builds for the right job", func() {
+							Expect(fakeDB.GetRunningJobBuildsCallCount()).To(Equal(1))
+							Expect(fakeDB.GetRunningJobBuildsArgsForCall(0)).To(Equal("some-job"))
+						})
+					})
+
+					Context("when the job has no builds running", func() {
+						BeforeEach(func() {
+							fakeDB.GetRunningJobBuildsReturns([]db.Build{}, nil)
+						})
+
+						itReturnsFalseAndNoError()
+					})
+
+					Context("when looking up the job's running builds fails", func() {
+						BeforeEach(func() {
+							fakeDB.GetRunningJobBuildsReturns(nil, disaster)
+						})
+
+						itReturnsTheError()
+					})
+				})
+
+				Context("when the job has a max in flight", func() {
+					BeforeEach(func() {
+						rawMaxInFlight = 2
+					})
+
+					Context("when the job is at its max in flight", func() {
+						BeforeEach(func() {
+							fakeDB.GetRunningJobBuildsReturns([]db.Build{new(dbfakes.FakeBuild), new(dbfakes.FakeBuild)}, nil)
+						})
+
+						itReturnsTrueAndNoError()
+					})
+
+					Context("when the job is below its max in flight", func() {
+						BeforeEach(func() {
+							fakeDB.GetRunningJobBuildsReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+						})
+
+						itReturnsFalseAndNoError()
+					})
+				})
+			})
+
+			Context("when the configured group is at capacity", func() {
+				BeforeEach(func() {
+					fakeDB.GetSerialGroupQueuesReturns(map[string]db.SerialGroupQueue{
+						"serial-group-1": {Running: 2, Pending: []int{42, 57}},
+						"serial-group-2": {Running: 0, Pending: []int{57}},
+					}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+			})
+
+			Context("when an unconfigured group already has a build running", func() {
+				BeforeEach(func() {
+					fakeDB.GetSerialGroupQueuesReturns(map[string]db.SerialGroupQueue{
+						"serial-group-1": {Running: 0, Pending: []int{57}},
+						"serial-group-2": {Running: 1, Pending: []int{57}},
+					}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+			})
+
+			Context("when the build is no longer pending", func() {
+				BeforeEach(func() {
+					fakeDB.GetSerialGroupQueuesReturns(map[string]db.SerialGroupQueue{
+						"serial-group-1": {Running: 0, Pending: []int{}},
+						"serial-group-2": {Running: 0, Pending: []int{}},
+					}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+			})
+		})
 	})
 })
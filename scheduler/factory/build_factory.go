@@ -1,10 +1,13 @@
 package factory
 
 import (
+	"encoding/json"
 	"errors"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
+	"github.com/mitchellh/mapstructure"
 )
 
 const defaultTaskName = "build"
@@ -37,16 +40,62 @@ func (factory *buildFactory) Create(
 ) (atc.Plan, error) {
 	planSequence := job.Plan
 
+	var plan atc.Plan
+	var err error
+
 	if len(planSequence) == 1 {
-		return factory.constructPlanFromConfig(
+		plan, err = factory.constructPlanFromConfig(
 			planSequence[0],
 			resources,
 			resourceTypes,
 			inputs,
 		)
+	} else {
+		plan, err = factory.do(planSequence, resources, resourceTypes, inputs)
+	}
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return factory.jobHooks(constructionParams{
+		plan: plan,
+		planConfig: atc.PlanConfig{
+			Failure: job.Failure,
+			Success: job.Success,
+			Abort:   job.Abort,
+			Ensure:  job.Ensure,
+		},
+		resources:     resources,
+		resourceTypes: resourceTypes,
+		inputs:        inputs,
+	})
+}
+
+// jobHooks wraps the job's entire plan in its hooks, in the same order as
+// step hooks are applied, with on_abort inside of ensure so that the ensure
+// hook runs after the abort hook.
+func (factory *buildFactory) jobHooks(cp constructionParams) (atc.Plan, error) {
+	cp, err := factory.failureIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	cp, err = factory.successIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
 	}
 
-	return factory.do(planSequence, resources, resourceTypes, inputs)
+	cp, err = factory.abortIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	cp, err = factory.ensureIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return cp.plan, nil
 }
 
 func (factory *buildFactory) do(
@@ -81,6 +130,10 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.ResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -121,6 +174,16 @@ func (factory *buildFactory) constructPlanFromConfig(
 		return atc.Plan{}, err
 	}
 
+	constructionParams, err = factory.errorIfPresent(constructionParams)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	constructionParams, err = factory.abortIfPresent(constructionParams)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
 	constructionParams, err = factory.ensureIfPresent(constructionParams)
 	if err != nil {
 		return atc.Plan{}, err
@@ -235,7 +298,25 @@ func (factory *buildFactory) constructUnhookedPlan(
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			ContainerLimits:   planConfig.ContainerLimits,
+		})
+
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:      planConfig.LoadVar,
+			File:      planConfig.TaskConfigPath,
+			Format:    planConfig.Format,
+			Sensitive: planConfig.Sensitive,
+		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:     planConfig.SetPipeline,
+			File:     planConfig.TaskConfigPath,
+			Vars:     planConfig.Vars,
+			VarFiles: planConfig.VarFiles,
 		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -269,6 +350,29 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		inParallel := atc.InParallelPlan{
+			Steps:    []atc.Plan{},
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			inParallel.Steps = append(inParallel.Steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(inParallel)
 	}
 
 	if planConfig.Timeout != "" {
@@ -281,6 +385,102 @@ func (factory *buildFactory) constructUnhookedPlan(
 	return plan, nil
 }
 
+// across constructs the step once for every combination of its across vars,
+// with the combination's values interpolated into the step. The combinations
+// run as an in_parallel step, so at most MaxInFlight run at once and FailFast
+// interrupts the rest once one has failed.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	step := planConfig
+	step.Across = nil
+	step.MaxInFlight = 0
+	step.FailFast = false
+
+	inParallel := atc.InParallelPlan{
+		Steps:    []atc.Plan{},
+		Limit:    planConfig.MaxInFlight,
+		FailFast: planConfig.FailFast,
+	}
+
+	for _, vars := range acrossCombinations(planConfig.Across) {
+		combination, err := interpolatePlanConfig(step, vars)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		plan, err := factory.constructPlanFromConfig(combination, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		inParallel.Steps = append(inParallel.Steps, plan)
+	}
+
+	return factory.planFactory.NewPlan(inParallel), nil
+}
+
+func acrossCombinations(acrossVars []atc.AcrossVarConfig) []template.Variables {
+	combinations := []template.Variables{{}}
+
+	for _, acrossVar := range acrossVars {
+		expanded := []template.Variables{}
+
+		for _, combination := range combinations {
+			for _, value := range acrossVar.Values {
+				vars := template.Variables{}
+				for name, val := range combination {
+					vars[name] = val
+				}
+
+				vars[acrossVar.Var] = value
+
+				expanded = append(expanded, vars)
+			}
+		}
+
+		combinations = expanded
+	}
+
+	return combinations
+}
+
+func interpolatePlanConfig(planConfig atc.PlanConfig, vars template.Variables) (atc.PlanConfig, error) {
+	payload, err := json.Marshal(planConfig)
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	var tree interface{}
+	err = json.Unmarshal(payload, &tree)
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	var interpolated atc.PlanConfig
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &interpolated,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			atc.SanitizeDecodeHook,
+			atc.VersionConfigDecodeHook,
+		),
+	})
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	err = decoder.Decode(vars.Evaluate(tree))
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	return interpolated, nil
+}
+
 type constructionParams struct {
 	plan          atc.Plan
 	planConfig    atc.PlanConfig
@@ -350,3 +550,45 @@ func (factory *buildFactory) ensureIfPresent(cp constructionParams) (constructio
 	}
 	return cp, nil
 }
+
+func (factory *buildFactory) errorIfPresent(cp constructionParams) (constructionParams, error) {
+	if cp.planConfig.Error != nil {
+		nextPlan, err := factory.constructPlanFromConfig(
+			*cp.planConfig.Error,
+			cp.resources,
+			cp.resourceTypes,
+			cp.inputs,
+		)
+		if err != nil {
+			return constructionParams{}, err
+		}
+
+		cp.plan = factory.planFactory.NewPlan(atc.OnErrorPlan{
+			Step: cp.plan,
+			Next: nextPlan,
+		})
+	}
+
+	return cp, nil
+}
+
+func (factory *buildFactory) abortIfPresent(cp constructionParams) (constructionParams, error) {
+	if cp.planConfig.Abort != nil {
+		nextPlan, err := factory.constructPlanFromConfig(
+			*cp.planConfig.Abort,
+			cp.resources,
+			cp.resourceTypes,
+			cp.inputs,
+		)
+		if err != nil {
+			return constructionParams{}, err
+		}
+
+		cp.plan = factory.planFactory.NewPlan(atc.OnAbortPlan{
+			Step: cp.plan,
+			Next: nextPlan,
+		})
+	}
+
+	return cp, nil
+}
@@ -0,0 +1,176 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+
+		step atc.PlanConfig
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+
+		step = atc.PlanConfig{
+			Task:           "unit-((platform))",
+			TaskConfigPath: "some/config/path.yml",
+			Params: atc.Params{
+				"GO_VERSION": "((go_version))",
+			},
+			Across: []atc.AcrossVarConfig{
+				{Var: "go_version", Values: []interface{}{"1.6", "1.7"}},
+				{Var: "platform", Values: []interface{}{"linux", "darwin"}},
+			},
+		}
+	})
+
+	combination := func(goVersion string, platform string) atc.Plan {
+		return expectedPlanFactory.NewPlan(atc.TaskPlan{
+			Name:          "unit-" + platform,
+			PipelineID:    42,
+			ConfigPath:    "some/config/path.yml",
+			ResourceTypes: resourceTypes,
+			Params: atc.Params{
+				"GO_VERSION": goVersion,
+			},
+		})
+	}
+
+	Context("when a step runs across some vars", func() {
+		It("runs every combination of the vars in parallel", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{step},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					combination("1.6", "linux"),
+					combination("1.6", "darwin"),
+					combination("1.7", "linux"),
+					combination("1.7", "darwin"),
+				},
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when the step has hooks", func() {
+		BeforeEach(func() {
+			step.Task = "unit-linux"
+			step.Across = step.Across[:1]
+			step.Failure = &atc.PlanConfig{
+				Task: "alert-((go_version))",
+			}
+		})
+
+		It("interpolates the vars into the hooks of each combination", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{step},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			withHook := func(goVersion string) atc.Plan {
+				plan := combination(goVersion, "linux")
+				return expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+					Step: plan,
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "alert-" + goVersion,
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				})
+			}
+
+			first := withHook("1.6")
+			second := withHook("1.7")
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{first, second},
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when max_in_flight is given", func() {
+		BeforeEach(func() {
+			step.MaxInFlight = 2
+		})
+
+		It("limits how many combinations run at once", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{step},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					combination("1.6", "linux"),
+					combination("1.6", "darwin"),
+					combination("1.7", "linux"),
+					combination("1.7", "darwin"),
+				},
+				Limit: 2,
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when fail_fast is set", func() {
+		BeforeEach(func() {
+			step.FailFast = true
+		})
+
+		It("fails fast even when every combination runs at once", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{step},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					combination("1.6", "linux"),
+					combination("1.6", "darwin"),
+					combination("1.7", "linux"),
+					combination("1.7", "darwin"),
+				},
+				FailFast: true,
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -41,6 +41,167 @@ var _ = Describe("Factory Hooks", func() {
 		}
 	})
 
+	Context("when a step has abort and error hooks", func() {
+		It("wraps the step in the error hook, then the abort hook", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+						Abort: &atc.PlanConfig{
+							Task: "some abort",
+						},
+						Error: &atc.PlanConfig{
+							Task: "some error",
+						},
+						Ensure: &atc.PlanConfig{
+							Task: "some ensure",
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.EnsurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.OnAbortPlan{
+					Step: expectedPlanFactory.NewPlan(atc.OnErrorPlan{
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "those who resist our will",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "some error",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some abort",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some ensure",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when the job has hooks", func() {
+		var input atc.JobConfig
+
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+					},
+					{
+						Task: "those who also resist our will",
+					},
+				},
+				Failure: &atc.PlanConfig{
+					Task: "some failure",
+				},
+				Success: &atc.PlanConfig{
+					Task: "some success",
+				},
+				Abort: &atc.PlanConfig{
+					Task: "some abort",
+				},
+				Ensure: &atc.PlanConfig{
+					Task: "some ensure",
+				},
+			}
+		})
+
+		It("wraps the job's entire plan in the hooks", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.EnsurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.OnAbortPlan{
+					Step: expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+						Step: expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+							Step: expectedPlanFactory.NewPlan(atc.DoPlan{
+								expectedPlanFactory.NewPlan(atc.TaskPlan{
+									Name:          "those who resist our will",
+									PipelineID:    42,
+									ResourceTypes: resourceTypes,
+								}),
+								expectedPlanFactory.NewPlan(atc.TaskPlan{
+									Name:          "those who also resist our will",
+									PipelineID:    42,
+									ResourceTypes: resourceTypes,
+								}),
+							}),
+							Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+								Name:          "some failure",
+								PipelineID:    42,
+								ResourceTypes: resourceTypes,
+							}),
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "some success",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some abort",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some ensure",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		Context("when the job has a single step", func() {
+			BeforeEach(func() {
+				input.Plan = atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+					},
+				}
+				input.Success = nil
+				input.Abort = nil
+				input.Ensure = nil
+			})
+
+			It("wraps the step in the hooks", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "those who resist our will",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some failure",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				})
+
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+	})
+
 	Context("when there is a do with three steps with a hook", func() {
 		var input atc.JobConfig
 
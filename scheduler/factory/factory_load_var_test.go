@@ -0,0 +1,71 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory LoadVar Step", func() {
+	var (
+		resourceTypes atc.ResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(321)
+		expectedPlanFactory = atc.NewPlanFactory(321)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+	})
+
+	Context("when there is a load_var step followed by a task", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						LoadVar:        "version",
+						TaskConfigPath: "version/number",
+						Format:         "trim",
+						Sensitive:      true,
+					},
+					{
+						Task:   "some-task",
+						Params: atc.Params{"VERSION": "((.:version))"},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.LoadVarPlan{
+					Name:      "version",
+					File:      "version/number",
+					Format:    "trim",
+					Sensitive: true,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+					Params:        atc.Params{"VERSION": "((.:version))"},
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -181,5 +181,39 @@ var _ = Describe("Factory Task", func() {
 				Expect(actual).To(testhelpers.MatchPlan(expected))
 			})
 		})
+
+		Context("when container limits are specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:           "some-task",
+							TaskConfigPath: "some/config/path.yml",
+							ContainerLimits: &atc.ContainerLimits{
+								CPU:    512,
+								Memory: 1024,
+							},
+						},
+					},
+				}
+			})
+
+			It("creates build plan with the container limits", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+					ConfigPath:    "some/config/path.yml",
+					ContainerLimits: &atc.ContainerLimits{
+						CPU:    512,
+						Memory: 1024,
+					},
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
 	})
 })
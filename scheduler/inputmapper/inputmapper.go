@@ -1,6 +1,9 @@
 package inputmapper
 
 import (
+	"errors"
+	"fmt"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
@@ -16,6 +19,42 @@ type InputMapper interface {
 		versions *algorithm.VersionsDB,
 		job atc.JobConfig,
 	) (algorithm.InputMapping, error)
+
+	ExplainInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job atc.JobConfig,
+	) (algorithm.Explanation, error)
+
+	MapManualInputs(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job atc.JobConfig,
+		versionIDs map[string]int,
+		force bool,
+	) (algorithm.InputMapping, error)
+}
+
+var ErrManualInputsNotSatisfiable = errors.New("the job's inputs cannot be satisfied with the chosen versions")
+
+type UnknownInputVersionError struct {
+	Input     string
+	VersionID int
+}
+
+func (err UnknownInputVersionError) Error() string {
+	return fmt.Sprintf("version %d is not an enabled version of the resource for input '%s'", err.VersionID, err.Input)
+}
+
+// UnresolvableInputError is returned when a version is chosen for an input
+// that cannot be resolved, e.g. because its configured pinned version does
+// not exist.
+type UnresolvableInputError struct {
+	Input string
+}
+
+func (err UnresolvableInputError) Error() string {
+	return fmt.Sprintf("input '%s' cannot be resolved for the job", err.Input)
 }
 
 //go:generate counterfeiter . InputMapperDB
@@ -92,3 +131,92 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+func (i *inputMapper) ExplainInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job atc.JobConfig,
+) (algorithm.Explanation, error) {
+	logger = logger.Session("explain-input-mapping")
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name, config.JobInputs(job))
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return algorithm.Explanation{}, err
+	}
+
+	_, _, explanation := algorithmInputConfigs.Explain(versions)
+
+	return explanation, nil
+}
+
+// MapManualInputs resolves the job's inputs with the chosen inputs pinned to
+// the given versions, overriding any pinned versions of their resources. If
+// force is true the chosen versions do not have to satisfy their passed
+// constraints. The mapping is not saved as the job's next inputs.
+func (i *inputMapper) MapManualInputs(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job atc.JobConfig,
+	versionIDs map[string]int,
+	force bool,
+) (algorithm.InputMapping, error) {
+	logger = logger.Session("map-manual-inputs")
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name, config.JobInputs(job))
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, err
+	}
+
+	manualVersions := *versions
+	manualVersions.PinnedVersions = map[int]int{}
+	for resourceID, versionID := range versions.PinnedVersions {
+		manualVersions.PinnedVersions[resourceID] = versionID
+	}
+
+	mapped := map[string]bool{}
+	for idx, inputConfig := range algorithmInputConfigs {
+		versionID, chosen := versionIDs[inputConfig.Name]
+		if !chosen {
+			continue
+		}
+
+		mapped[inputConfig.Name] = true
+
+		if !hasVersion(versions, inputConfig.ResourceID, versionID) {
+			return nil, UnknownInputVersionError{Input: inputConfig.Name, VersionID: versionID}
+		}
+
+		delete(manualVersions.PinnedVersions, inputConfig.ResourceID)
+
+		algorithmInputConfigs[idx].PinnedVersionID = versionID
+
+		if force {
+			algorithmInputConfigs[idx].Passed = nil
+		}
+	}
+
+	for inputName := range versionIDs {
+		if !mapped[inputName] {
+			return nil, UnresolvableInputError{Input: inputName}
+		}
+	}
+
+	mapping, ok := algorithmInputConfigs.Resolve(&manualVersions)
+	if !ok {
+		return nil, ErrManualInputsNotSatisfiable
+	}
+
+	return mapping, nil
+}
+
+func hasVersion(versions *algorithm.VersionsDB, resourceID int, versionID int) bool {
+	for _, version := range versions.ResourceVersions {
+		if version.ResourceID == resourceID && version.VersionID == versionID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -384,4 +384,242 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("ExplainInputMapping", func() {
+		var (
+			versionsDB  *algorithm.VersionsDB
+			jobConfig   atc.JobConfig
+			explanation algorithm.Explanation
+			explainErr  error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+				},
+			}
+
+			jobConfig = atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "a", Passed: []string{"upstream"}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			explanation, explainErr = inputMapper.ExplainInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				jobConfig,
+			)
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when transforming the input configs succeeds", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{
+						Name:       "a",
+						ResourceID: 11,
+						Passed:     algorithm.JobSet{2: struct{}{}},
+						JobID:      1,
+					},
+				}, nil)
+			})
+
+			It("explains why the inputs don't resolve", func() {
+				Expect(explainErr).NotTo(HaveOccurred())
+				Expect(explanation.Resolved).To(BeFalse())
+				Expect(explanation.Inputs).To(Equal([]algorithm.InputExplanation{
+					{
+						Name:       "a",
+						Versions:   1,
+						Candidates: 0,
+						Passed: []algorithm.PassedExplanation{
+							{JobID: 2, JobName: "upstream", Versions: 0, EliminatedVersionIDs: []int{}},
+						},
+					},
+				}))
+			})
+
+			It("does not save any input mappings", func() {
+				Expect(fakeDB.SaveIndependentInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.DeleteNextInputMappingCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("MapManualInputs", func() {
+		var (
+			versionsDB   *algorithm.VersionsDB
+			jobConfig    atc.JobConfig
+			versionIDs   map[string]int
+			force        bool
+			inputMapping algorithm.InputMapping
+			mappingErr   error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 11, CheckOrder: 2},
+					{VersionID: 3, ResourceID: 12, CheckOrder: 1},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+						BuildID:         98,
+						JobID:           2,
+					},
+				},
+				PinnedVersions: map[int]int{11: 1},
+			}
+
+			jobConfig = atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "a", Passed: []string{"upstream"}},
+					{Get: "b"},
+				},
+			}
+
+			versionIDs = map[string]int{"a": 2}
+			force = false
+		})
+
+		JustBeforeEach(func() {
+			inputMapping, mappingErr = inputMapper.MapManualInputs(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				jobConfig,
+				versionIDs,
+				force,
+			)
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(mappingErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when transforming the input configs succeeds", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{
+						Name:       "a",
+						ResourceID: 11,
+						Passed:     algorithm.JobSet{2: struct{}{}},
+						JobID:      1,
+					},
+					{
+						Name:       "b",
+						ResourceID: 12,
+						JobID:      1,
+					},
+				}, nil)
+			})
+
+			It("transforms the job's input configs", func() {
+				Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+				actualVersionsDB, actualJobName, actualInputConfigs := fakeTransformer.TransformInputConfigsArgsForCall(0)
+				Expect(actualVersionsDB).To(Equal(versionsDB))
+				Expect(actualJobName).To(Equal("some-job"))
+				Expect(actualInputConfigs).To(Equal(config.JobInputs(jobConfig)))
+			})
+
+			Context("when the chosen version has not passed the constraints", func() {
+				It("returns ErrManualInputsNotSatisfiable", func() {
+					Expect(mappingErr).To(Equal(inputmapper.ErrManualInputsNotSatisfiable))
+				})
+
+				Context("when forced", func() {
+					BeforeEach(func() {
+						force = true
+					})
+
+					It("uses the chosen version, overriding the resource's pinned version", func() {
+						Expect(mappingErr).NotTo(HaveOccurred())
+						Expect(inputMapping).To(Equal(algorithm.InputMapping{
+							"a": algorithm.InputVersion{VersionID: 2, FirstOccurrence: true},
+							"b": algorithm.InputVersion{VersionID: 3, FirstOccurrence: true},
+						}))
+					})
+
+					It("does not modify the given versions DB", func() {
+						Expect(versionsDB.PinnedVersions).To(Equal(map[int]int{11: 1}))
+					})
+				})
+			})
+
+			Context("when the chosen version has passed the constraints", func() {
+				BeforeEach(func() {
+					versionsDB.PinnedVersions = nil
+					versionIDs = map[string]int{"a": 1}
+				})
+
+				It("resolves the remaining inputs as usual", func() {
+					Expect(mappingErr).NotTo(HaveOccurred())
+					Expect(inputMapping).To(Equal(algorithm.InputMapping{
+						"a": algorithm.InputVersion{VersionID: 1, FirstOccurrence: true},
+						"b": algorithm.InputVersion{VersionID: 3, FirstOccurrence: true},
+					}))
+				})
+			})
+
+			Context("when the chosen version is not a version of the input's resource", func() {
+				BeforeEach(func() {
+					versionIDs = map[string]int{"a": 3}
+					force = true
+				})
+
+				It("returns an UnknownInputVersionError", func() {
+					Expect(mappingErr).To(Equal(inputmapper.UnknownInputVersionError{Input: "a", VersionID: 3}))
+				})
+			})
+
+			Context("when the chosen input is not one of the transformed input configs", func() {
+				BeforeEach(func() {
+					fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+						{
+							Name:       "b",
+							ResourceID: 12,
+							JobID:      1,
+						},
+					}, nil)
+				})
+
+				It("returns an UnresolvableInputError", func() {
+					Expect(mappingErr).To(Equal(inputmapper.UnresolvableInputError{Input: "a"}))
+				})
+			})
+
+			It("does not save any input mappings", func() {
+				Expect(fakeDB.SaveIndependentInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.DeleteNextInputMappingCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -22,6 +22,30 @@ type FakeInputMapper struct {
 		result1 algorithm.InputMapping
 		result2 error
 	}
+	ExplainInputMappingStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig) (algorithm.Explanation, error)
+	explainInputMappingMutex       sync.RWMutex
+	explainInputMappingArgsForCall []struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      atc.JobConfig
+	}
+	explainInputMappingReturns struct {
+		result1 algorithm.Explanation
+		result2 error
+	}
+	MapManualInputsStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, versionIDs map[string]int, force bool) (algorithm.InputMapping, error)
+	mapManualInputsMutex       sync.RWMutex
+	mapManualInputsArgsForCall []struct {
+		logger     lager.Logger
+		versions   *algorithm.VersionsDB
+		job        atc.JobConfig
+		versionIDs map[string]int
+		force      bool
+	}
+	mapManualInputsReturns struct {
+		result1 algorithm.InputMapping
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -62,11 +86,89 @@ func (fake *FakeInputMapper) SaveNextInputMappingReturns(result1 algorithm.Input
 	}{result1, result2}
 }
 
+func (fake *FakeInputMapper) ExplainInputMapping(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig) (algorithm.Explanation, error) {
+	fake.explainInputMappingMutex.Lock()
+	fake.explainInputMappingArgsForCall = append(fake.explainInputMappingArgsForCall, struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      atc.JobConfig
+	}{logger, versions, job})
+	fake.recordInvocation("ExplainInputMapping", []interface{}{logger, versions, job})
+	fake.explainInputMappingMutex.Unlock()
+	if fake.ExplainInputMappingStub != nil {
+		return fake.ExplainInputMappingStub(logger, versions, job)
+	} else {
+		return fake.explainInputMappingReturns.result1, fake.explainInputMappingReturns.result2
+	}
+}
+
+func (fake *FakeInputMapper) ExplainInputMappingCallCount() int {
+	fake.explainInputMappingMutex.RLock()
+	defer fake.explainInputMappingMutex.RUnlock()
+	return len(fake.explainInputMappingArgsForCall)
+}
+
+func (fake *FakeInputMapper) ExplainInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig) {
+	fake.explainInputMappingMutex.RLock()
+	defer fake.explainInputMappingMutex.RUnlock()
+	return fake.explainInputMappingArgsForCall[i].logger, fake.explainInputMappingArgsForCall[i].versions, fake.explainInputMappingArgsForCall[i].job
+}
+
+func (fake *FakeInputMapper) ExplainInputMappingReturns(result1 algorithm.Explanation, result2 error) {
+	fake.ExplainInputMappingStub = nil
+	fake.explainInputMappingReturns = struct {
+		result1 algorithm.Explanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) MapManualInputs(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, versionIDs map[string]int, force bool) (algorithm.InputMapping, error) {
+	fake.mapManualInputsMutex.Lock()
+	fake.mapManualInputsArgsForCall = append(fake.mapManualInputsArgsForCall, struct {
+		logger     lager.Logger
+		versions   *algorithm.VersionsDB
+		job        atc.JobConfig
+		versionIDs map[string]int
+		force      bool
+	}{logger, versions, job, versionIDs, force})
+	fake.recordInvocation("MapManualInputs", []interface{}{logger, versions, job, versionIDs, force})
+	fake.mapManualInputsMutex.Unlock()
+	if fake.MapManualInputsStub != nil {
+		return fake.MapManualInputsStub(logger, versions, job, versionIDs, force)
+	} else {
+		return fake.mapManualInputsReturns.result1, fake.mapManualInputsReturns.result2
+	}
+}
+
+func (fake *FakeInputMapper) MapManualInputsCallCount() int {
+	fake.mapManualInputsMutex.RLock()
+	defer fake.mapManualInputsMutex.RUnlock()
+	return len(fake.mapManualInputsArgsForCall)
+}
+
+func (fake *FakeInputMapper) MapManualInputsArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, map[string]int, bool) {
+	fake.mapManualInputsMutex.RLock()
+	defer fake.mapManualInputsMutex.RUnlock()
+	return fake.mapManualInputsArgsForCall[i].logger, fake.mapManualInputsArgsForCall[i].versions, fake.mapManualInputsArgsForCall[i].job, fake.mapManualInputsArgsForCall[i].versionIDs, fake.mapManualInputsArgsForCall[i].force
+}
+
+func (fake *FakeInputMapper) MapManualInputsReturns(result1 algorithm.InputMapping, result2 error) {
+	fake.MapManualInputsStub = nil
+	fake.mapManualInputsReturns = struct {
+		result1 algorithm.InputMapping
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInputMapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainInputMappingMutex.RLock()
+	defer fake.explainInputMappingMutex.RUnlock()
+	fake.mapManualInputsMutex.RLock()
+	defer fake.mapManualInputsMutex.RUnlock()
 	return fake.invocations
 }
 
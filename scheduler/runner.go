@@ -19,16 +19,28 @@ type BuildScheduler interface {
 		logger lager.Logger,
 		versions *algorithm.VersionsDB,
 		jobConfig atc.JobConfig,
+		serialGroupConfigs atc.SerialGroupConfigs,
 		resourceConfigs atc.ResourceConfigs,
 		resourceTypes atc.ResourceTypes,
 	) error
 	TriggerImmediately(
 		logger lager.Logger,
 		jobConfig atc.JobConfig,
+		serialGroupConfigs atc.SerialGroupConfigs,
 		resourceConfigs atc.ResourceConfigs,
 		resourceTypes atc.ResourceTypes,
 	) (db.Build, Waiter, error)
+	TriggerWithInputs(
+		logger lager.Logger,
+		jobConfig atc.JobConfig,
+		serialGroupConfigs atc.SerialGroupConfigs,
+		resourceConfigs atc.ResourceConfigs,
+		resourceTypes atc.ResourceTypes,
+		versionIDs map[string]int,
+		force bool,
+	) (db.Build, Waiter, error)
 	SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error
+	ExplainInputMapping(logger lager.Logger, job atc.JobConfig) (algorithm.Explanation, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
@@ -129,7 +141,7 @@ func (runner *Runner) tick(logger lager.Logger) error {
 
 		jStart := time.Now()
 
-		runner.Scheduler.Schedule(sLog, versions, job, config.Resources, config.ResourceTypes)
+		runner.Scheduler.Schedule(sLog, versions, job, config.SerialGroups, config.Resources, config.ResourceTypes)
 
 		metric.SchedulingJobDuration{
 			PipelineName: runner.DB.GetPipelineName(),
@@ -72,6 +72,10 @@ var _ = Describe("Runner", func() {
 				},
 			},
 
+			SerialGroups: atc.SerialGroupConfigs{
+				{Name: "some-serial-group", MaxInFlight: 2},
+			},
+
 			Resources: atc.ResourceConfigs{
 				{
 					Name:   "some-resource",
@@ -140,15 +144,17 @@ var _ = Describe("Runner", func() {
 	It("schedules pending builds", func() {
 		Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
 
-		_, versions, job, resources, resourceTypes := scheduler.ScheduleArgsForCall(0)
+		_, versions, job, serialGroups, resources, resourceTypes := scheduler.ScheduleArgsForCall(0)
 		Expect(versions).To(Equal(someVersions))
 		Expect(job).To(Equal(atc.JobConfig{Name: "some-job"}))
+		Expect(serialGroups).To(Equal(initialConfig.SerialGroups))
 		Expect(resources).To(Equal(initialConfig.Resources))
 		Expect(resourceTypes).To(Equal(initialConfig.ResourceTypes))
 
-		_, versions, job, resources, resourceTypes = scheduler.ScheduleArgsForCall(1)
+		_, versions, job, serialGroups, resources, resourceTypes = scheduler.ScheduleArgsForCall(1)
 		Expect(versions).To(Equal(someVersions))
 		Expect(job).To(Equal(atc.JobConfig{Name: "some-other-job"}))
+		Expect(serialGroups).To(Equal(initialConfig.SerialGroups))
 		Expect(resources).To(Equal(initialConfig.Resources))
 		Expect(resourceTypes).To(Equal(initialConfig.ResourceTypes))
 	})
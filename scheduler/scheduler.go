@@ -30,6 +30,7 @@ type SchedulerDB interface {
 	GetPipelineName() string
 	GetConfig() (atc.Config, db.ConfigVersion, bool, error)
 	CreateJobBuild(job string) (db.Build, error)
+	CreateManualJobBuild(job string, inputMapping algorithm.InputMapping) (db.Build, error)
 	EnsurePendingBuildExists(jobName string) error
 	LeaseResourceCheckingForJob(logger lager.Logger, job string, interval time.Duration) (db.Lease, bool, error)
 }
@@ -44,6 +45,7 @@ func (s *Scheduler) Schedule(
 	logger lager.Logger,
 	versions *algorithm.VersionsDB,
 	jobConfig atc.JobConfig,
+	serialGroupConfigs atc.SerialGroupConfigs,
 	resourceConfigs atc.ResourceConfigs,
 	resourceTypes atc.ResourceTypes,
 ) error {
@@ -67,7 +69,7 @@ func (s *Scheduler) Schedule(
 		}
 	}
 
-	return s.BuildStarter.TryStartAllPendingBuilds(logger, jobConfig, resourceConfigs, resourceTypes)
+	return s.BuildStarter.TryStartAllPendingBuilds(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
 }
 
 type Waiter interface {
@@ -77,6 +79,7 @@ type Waiter interface {
 func (s *Scheduler) TriggerImmediately(
 	logger lager.Logger,
 	jobConfig atc.JobConfig,
+	serialGroupConfigs atc.SerialGroupConfigs,
 	resourceConfigs atc.ResourceConfigs,
 	resourceTypes atc.ResourceTypes,
 ) (db.Build, Waiter, error) {
@@ -137,7 +140,54 @@ func (s *Scheduler) TriggerImmediately(
 			lease.Break()
 		}
 
-		err = s.BuildStarter.TryStartAllPendingBuilds(logger, jobConfig, resourceConfigs, resourceTypes)
+		err = s.BuildStarter.TryStartAllPendingBuilds(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
+	}()
+
+	return build, wg, nil
+}
+
+// TriggerWithInputs creates a build which runs with the given versions of its
+// inputs instead of the job's next inputs. Inputs which were not chosen are
+// resolved from the versions already known, without checking for new ones.
+func (s *Scheduler) TriggerWithInputs(
+	logger lager.Logger,
+	jobConfig atc.JobConfig,
+	serialGroupConfigs atc.SerialGroupConfigs,
+	resourceConfigs atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	versionIDs map[string]int,
+	force bool,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("trigger-with-inputs", lager.Data{"job_name": jobConfig.Name})
+
+	versions, err := s.DB.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return nil, nil, err
+	}
+
+	inputMapping, err := s.InputMapper.MapManualInputs(logger, versions, jobConfig, versionIDs, force)
+	if err != nil {
+		logger.Info("failed-to-map-manual-inputs", lager.Data{"error": err.Error()})
+		return nil, nil, err
+	}
+
+	build, err := s.DB.CreateManualJobBuild(jobConfig.Name, inputMapping)
+	if err != nil {
+		logger.Error("failed-to-create-manual-job-build", err)
+		return nil, nil, err
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		err := s.BuildStarter.TryStartAllPendingBuilds(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
+		if err != nil {
+			logger.Error("failed-to-start-pending-builds", err)
+		}
 	}()
 
 	return build, wg, nil
@@ -153,3 +203,13 @@ func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig)
 	_, err = s.InputMapper.SaveNextInputMapping(logger, versions, job)
 	return err
 }
+
+func (s *Scheduler) ExplainInputMapping(logger lager.Logger, job atc.JobConfig) (algorithm.Explanation, error) {
+	versions, err := s.DB.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return algorithm.Explanation{}, err
+	}
+
+	return s.InputMapper.ExplainInputMapping(logger, versions, job)
+}
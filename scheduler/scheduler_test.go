@@ -61,6 +61,7 @@ var _ = Describe("Scheduler", func() {
 				lagertest.NewTestLogger("test"),
 				versionsDB,
 				jobConfig,
+				atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}},
 				atc.ResourceConfigs{{Name: "some-resource"}},
 				atc.ResourceTypes{{Name: "some-resource-type"}},
 			)
@@ -107,8 +108,9 @@ var _ = Describe("Scheduler", func() {
 
 					It("started all pending builds for the right job", func() {
 						Expect(fakeBuildStarter.TryStartAllPendingBuildsCallCount()).To(Equal(1))
-						_, actualJob, actualResources, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
+						_, actualJob, actualSerialGroups, actualResources, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
 						Expect(actualJob).To(Equal(jobConfig))
+						Expect(actualSerialGroups).To(Equal(atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}}))
 						Expect(actualResources).To(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
 						Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{{Name: "some-resource-type"}}))
 					})
@@ -227,6 +229,7 @@ var _ = Describe("Scheduler", func() {
 			triggeredBuild, waiter, triggerErr = scheduler.TriggerImmediately(
 				lagertest.NewTestLogger("test"),
 				jobConfig,
+				atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}},
 				atc.ResourceConfigs{{Name: "some-resource"}},
 				atc.ResourceTypes{{Name: "some-resource-type"}})
 			if waiter != nil {
@@ -290,8 +293,9 @@ var _ = Describe("Scheduler", func() {
 
 					It("tried to start all pending builds after creating the build", func() {
 						Expect(fakeBuildStarter.TryStartAllPendingBuildsCallCount()).To(Equal(1))
-						_, actualJob, actualResources, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
+						_, actualJob, actualSerialGroups, actualResources, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
 						Expect(actualJob).To(Equal(jobConfig))
+						Expect(actualSerialGroups).To(Equal(atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}}))
 						Expect(actualResources).To(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
 						Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{{Name: "some-resource-type"}}))
 					})
@@ -451,8 +455,9 @@ var _ = Describe("Scheduler", func() {
 
 								It("started all pending builds for the right job after breaking the lease", func() {
 									Expect(fakeBuildStarter.TryStartAllPendingBuildsCallCount()).To(Equal(1))
-									_, actualJob, actualResources, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
+									_, actualJob, actualSerialGroups, actualResources, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
 									Expect(actualJob).To(Equal(jobConfig))
+									Expect(actualSerialGroups).To(Equal(atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}}))
 									Expect(actualResources).To(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
 									Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{{Name: "some-resource-type"}}))
 								})
@@ -475,6 +480,137 @@ var _ = Describe("Scheduler", func() {
 		})
 	})
 
+	Describe("TriggerWithInputs", func() {
+		var (
+			jobConfig      atc.JobConfig
+			triggeredBuild db.Build
+			triggerErr     error
+		)
+
+		BeforeEach(func() {
+			jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "input-1"}, {Get: "input-2"}}}
+		})
+
+		JustBeforeEach(func() {
+			var waiter Waiter
+			triggeredBuild, waiter, triggerErr = scheduler.TriggerWithInputs(
+				lagertest.NewTestLogger("test"),
+				jobConfig,
+				atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}},
+				atc.ResourceConfigs{{Name: "some-resource"}},
+				atc.ResourceTypes{{Name: "some-resource-type"}},
+				map[string]int{"input-1": 42},
+				true,
+			)
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		Context("when loading the versions DB fails", func() {
+			BeforeEach(func() {
+				fakeDB.LoadVersionsDBReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(triggerErr).To(Equal(disaster))
+			})
+
+			It("does not create a build", func() {
+				Expect(fakeDB.CreateManualJobBuildCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when loading the versions DB succeeds", func() {
+			var versionsDB *algorithm.VersionsDB
+
+			BeforeEach(func() {
+				versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"j1": 1}}
+				fakeDB.LoadVersionsDBReturns(versionsDB, nil)
+			})
+
+			Context("when mapping the inputs fails", func() {
+				BeforeEach(func() {
+					fakeInputMapper.MapManualInputsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(triggerErr).To(Equal(disaster))
+				})
+
+				It("mapped the chosen versions for the right job", func() {
+					Expect(fakeInputMapper.MapManualInputsCallCount()).To(Equal(1))
+					_, actualVersionsDB, actualJobConfig, actualVersionIDs, actualForce := fakeInputMapper.MapManualInputsArgsForCall(0)
+					Expect(actualVersionsDB).To(Equal(versionsDB))
+					Expect(actualJobConfig).To(Equal(jobConfig))
+					Expect(actualVersionIDs).To(Equal(map[string]int{"input-1": 42}))
+					Expect(actualForce).To(BeTrue())
+				})
+
+				It("does not create a build", func() {
+					Expect(fakeDB.CreateManualJobBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when mapping the inputs succeeds", func() {
+				var inputMapping algorithm.InputMapping
+
+				BeforeEach(func() {
+					inputMapping = algorithm.InputMapping{
+						"input-1": algorithm.InputVersion{VersionID: 42, FirstOccurrence: true},
+						"input-2": algorithm.InputVersion{VersionID: 43, FirstOccurrence: false},
+					}
+					fakeInputMapper.MapManualInputsReturns(inputMapping, nil)
+				})
+
+				Context("when creating the build fails", func() {
+					BeforeEach(func() {
+						fakeDB.CreateManualJobBuildReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(triggerErr).To(Equal(disaster))
+					})
+
+					It("does not try to start the build", func() {
+						Expect(fakeBuildStarter.TryStartAllPendingBuildsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when creating the build succeeds", func() {
+					var createdBuild *dbfakes.FakeBuild
+
+					BeforeEach(func() {
+						createdBuild = new(dbfakes.FakeBuild)
+						fakeDB.CreateManualJobBuildReturns(createdBuild, nil)
+					})
+
+					It("creates a build with the mapped inputs", func() {
+						Expect(fakeDB.CreateManualJobBuildCallCount()).To(Equal(1))
+						actualJobName, actualInputMapping := fakeDB.CreateManualJobBuildArgsForCall(0)
+						Expect(actualJobName).To(Equal("some-job"))
+						Expect(actualInputMapping).To(Equal(inputMapping))
+					})
+
+					It("returns the build", func() {
+						Expect(triggerErr).NotTo(HaveOccurred())
+						Expect(triggeredBuild).To(Equal(createdBuild))
+					})
+
+					It("tries to start pending builds without scanning", func() {
+						Expect(fakeScanner.ScanCallCount()).To(BeZero())
+						Expect(fakeBuildStarter.TryStartAllPendingBuildsCallCount()).To(Equal(1))
+						_, actualJobConfig, actualSerialGroups, actualResourceConfigs, actualResourceTypes := fakeBuildStarter.TryStartAllPendingBuildsArgsForCall(0)
+						Expect(actualJobConfig).To(Equal(jobConfig))
+						Expect(actualSerialGroups).To(Equal(atc.SerialGroupConfigs{{Name: "some-serial-group", MaxInFlight: 2}}))
+						Expect(actualResourceConfigs).To(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
+						Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{{Name: "some-resource-type"}}))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("SaveNextInputMapping", func() {
 		var saveErr error
 
@@ -530,4 +666,50 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 	})
+
+	Describe("ExplainInputMapping", func() {
+		var explanation algorithm.Explanation
+		var explainErr error
+
+		JustBeforeEach(func() {
+			explanation, explainErr = scheduler.ExplainInputMapping(lagertest.NewTestLogger("test"), atc.JobConfig{Name: "some-job"})
+		})
+
+		Context("when loading the versions DB fails", func() {
+			BeforeEach(func() {
+				fakeDB.LoadVersionsDBReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when loading the versions DB succeeds", func() {
+			var versionsDB *algorithm.VersionsDB
+
+			BeforeEach(func() {
+				versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"j1": 1}}
+				fakeDB.LoadVersionsDBReturns(versionsDB, nil)
+
+				fakeInputMapper.ExplainInputMappingReturns(algorithm.Explanation{
+					Inputs: []algorithm.InputExplanation{{Name: "some-input"}},
+				}, nil)
+			})
+
+			It("explains the input mapping for the right job and versions", func() {
+				Expect(fakeInputMapper.ExplainInputMappingCallCount()).To(Equal(1))
+				_, actualVersionsDB, actualJobConfig := fakeInputMapper.ExplainInputMappingArgsForCall(0)
+				Expect(actualVersionsDB).To(Equal(versionsDB))
+				Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+			})
+
+			It("returns the explanation", func() {
+				Expect(explainErr).NotTo(HaveOccurred())
+				Expect(explanation).To(Equal(algorithm.Explanation{
+					Inputs: []algorithm.InputExplanation{{Name: "some-input"}},
+				}))
+			})
+		})
+	})
 })
@@ -12,31 +12,49 @@ import (
 )
 
 type FakeBuildScheduler struct {
-	ScheduleStub        func(logger lager.Logger, versions *algorithm.VersionsDB, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error
+	ScheduleStub        func(logger lager.Logger, versions *algorithm.VersionsDB, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error
 	scheduleMutex       sync.RWMutex
 	scheduleArgsForCall []struct {
-		logger          lager.Logger
-		versions        *algorithm.VersionsDB
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
+		logger             lager.Logger
+		versions           *algorithm.VersionsDB
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
 	}
 	scheduleReturns struct {
 		result1 error
 	}
-	TriggerImmediatelyStub        func(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error)
+	TriggerImmediatelyStub        func(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error)
 	triggerImmediatelyMutex       sync.RWMutex
 	triggerImmediatelyArgsForCall []struct {
-		logger          lager.Logger
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
 	}
 	triggerImmediatelyReturns struct {
 		result1 db.Build
 		result2 scheduler.Waiter
 		result3 error
 	}
+	TriggerWithInputsStub        func(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes, versionIDs map[string]int, force bool) (db.Build, scheduler.Waiter, error)
+	triggerWithInputsMutex       sync.RWMutex
+	triggerWithInputsArgsForCall []struct {
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
+		versionIDs         map[string]int
+		force              bool
+	}
+	triggerWithInputsReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
 	SaveNextInputMappingStub        func(logger lager.Logger, job atc.JobConfig) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -46,23 +64,34 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturns struct {
 		result1 error
 	}
+	ExplainInputMappingStub        func(logger lager.Logger, job atc.JobConfig) (algorithm.Explanation, error)
+	explainInputMappingMutex       sync.RWMutex
+	explainInputMappingArgsForCall []struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}
+	explainInputMappingReturns struct {
+		result1 algorithm.Explanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, versions *algorithm.VersionsDB, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error {
+func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, versions *algorithm.VersionsDB, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error {
 	fake.scheduleMutex.Lock()
 	fake.scheduleArgsForCall = append(fake.scheduleArgsForCall, struct {
-		logger          lager.Logger
-		versions        *algorithm.VersionsDB
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
-	}{logger, versions, jobConfig, resourceConfigs, resourceTypes})
-	fake.recordInvocation("Schedule", []interface{}{logger, versions, jobConfig, resourceConfigs, resourceTypes})
+		logger             lager.Logger
+		versions           *algorithm.VersionsDB
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
+	}{logger, versions, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes})
+	fake.recordInvocation("Schedule", []interface{}{logger, versions, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes})
 	fake.scheduleMutex.Unlock()
 	if fake.ScheduleStub != nil {
-		return fake.ScheduleStub(logger, versions, jobConfig, resourceConfigs, resourceTypes)
+		return fake.ScheduleStub(logger, versions, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
 	} else {
 		return fake.scheduleReturns.result1
 	}
@@ -74,10 +103,10 @@ func (fake *FakeBuildScheduler) ScheduleCallCount() int {
 	return len(fake.scheduleArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.SerialGroupConfigs, atc.ResourceConfigs, atc.ResourceTypes) {
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
-	return fake.scheduleArgsForCall[i].logger, fake.scheduleArgsForCall[i].versions, fake.scheduleArgsForCall[i].jobConfig, fake.scheduleArgsForCall[i].resourceConfigs, fake.scheduleArgsForCall[i].resourceTypes
+	return fake.scheduleArgsForCall[i].logger, fake.scheduleArgsForCall[i].versions, fake.scheduleArgsForCall[i].jobConfig, fake.scheduleArgsForCall[i].serialGroupConfigs, fake.scheduleArgsForCall[i].resourceConfigs, fake.scheduleArgsForCall[i].resourceTypes
 }
 
 func (fake *FakeBuildScheduler) ScheduleReturns(result1 error) {
@@ -87,18 +116,19 @@ func (fake *FakeBuildScheduler) ScheduleReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error) {
+func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error) {
 	fake.triggerImmediatelyMutex.Lock()
 	fake.triggerImmediatelyArgsForCall = append(fake.triggerImmediatelyArgsForCall, struct {
-		logger          lager.Logger
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
-	}{logger, jobConfig, resourceConfigs, resourceTypes})
-	fake.recordInvocation("TriggerImmediately", []interface{}{logger, jobConfig, resourceConfigs, resourceTypes})
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
+	}{logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes})
+	fake.recordInvocation("TriggerImmediately", []interface{}{logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes})
 	fake.triggerImmediatelyMutex.Unlock()
 	if fake.TriggerImmediatelyStub != nil {
-		return fake.TriggerImmediatelyStub(logger, jobConfig, resourceConfigs, resourceTypes)
+		return fake.TriggerImmediatelyStub(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes)
 	} else {
 		return fake.triggerImmediatelyReturns.result1, fake.triggerImmediatelyReturns.result2, fake.triggerImmediatelyReturns.result3
 	}
@@ -110,10 +140,10 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyCallCount() int {
 	return len(fake.triggerImmediatelyArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.SerialGroupConfigs, atc.ResourceConfigs, atc.ResourceTypes) {
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
-	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].jobConfig, fake.triggerImmediatelyArgsForCall[i].resourceConfigs, fake.triggerImmediatelyArgsForCall[i].resourceTypes
+	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].jobConfig, fake.triggerImmediatelyArgsForCall[i].serialGroupConfigs, fake.triggerImmediatelyArgsForCall[i].resourceConfigs, fake.triggerImmediatelyArgsForCall[i].resourceTypes
 }
 
 func (fake *FakeBuildScheduler) TriggerImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
@@ -125,6 +155,47 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyReturns(result1 db.Build, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildScheduler) TriggerWithInputs(logger lager.Logger, jobConfig atc.JobConfig, serialGroupConfigs atc.SerialGroupConfigs, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes, versionIDs map[string]int, force bool) (db.Build, scheduler.Waiter, error) {
+	fake.triggerWithInputsMutex.Lock()
+	fake.triggerWithInputsArgsForCall = append(fake.triggerWithInputsArgsForCall, struct {
+		logger             lager.Logger
+		jobConfig          atc.JobConfig
+		serialGroupConfigs atc.SerialGroupConfigs
+		resourceConfigs    atc.ResourceConfigs
+		resourceTypes      atc.ResourceTypes
+		versionIDs         map[string]int
+		force              bool
+	}{logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes, versionIDs, force})
+	fake.recordInvocation("TriggerWithInputs", []interface{}{logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes, versionIDs, force})
+	fake.triggerWithInputsMutex.Unlock()
+	if fake.TriggerWithInputsStub != nil {
+		return fake.TriggerWithInputsStub(logger, jobConfig, serialGroupConfigs, resourceConfigs, resourceTypes, versionIDs, force)
+	} else {
+		return fake.triggerWithInputsReturns.result1, fake.triggerWithInputsReturns.result2, fake.triggerWithInputsReturns.result3
+	}
+}
+
+func (fake *FakeBuildScheduler) TriggerWithInputsCallCount() int {
+	fake.triggerWithInputsMutex.RLock()
+	defer fake.triggerWithInputsMutex.RUnlock()
+	return len(fake.triggerWithInputsArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) TriggerWithInputsArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.SerialGroupConfigs, atc.ResourceConfigs, atc.ResourceTypes, map[string]int, bool) {
+	fake.triggerWithInputsMutex.RLock()
+	defer fake.triggerWithInputsMutex.RUnlock()
+	return fake.triggerWithInputsArgsForCall[i].logger, fake.triggerWithInputsArgsForCall[i].jobConfig, fake.triggerWithInputsArgsForCall[i].serialGroupConfigs, fake.triggerWithInputsArgsForCall[i].resourceConfigs, fake.triggerWithInputsArgsForCall[i].resourceTypes, fake.triggerWithInputsArgsForCall[i].versionIDs, fake.triggerWithInputsArgsForCall[i].force
+}
+
+func (fake *FakeBuildScheduler) TriggerWithInputsReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.TriggerWithInputsStub = nil
+	fake.triggerWithInputsReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error {
 	fake.saveNextInputMappingMutex.Lock()
 	fake.saveNextInputMappingArgsForCall = append(fake.saveNextInputMappingArgsForCall, struct {
@@ -159,6 +230,41 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) ExplainInputMapping(logger lager.Logger, job atc.JobConfig) (algorithm.Explanation, error) {
+	fake.explainInputMappingMutex.Lock()
+	fake.explainInputMappingArgsForCall = append(fake.explainInputMappingArgsForCall, struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}{logger, job})
+	fake.recordInvocation("ExplainInputMapping", []interface{}{logger, job})
+	fake.explainInputMappingMutex.Unlock()
+	if fake.ExplainInputMappingStub != nil {
+		return fake.ExplainInputMappingStub(logger, job)
+	} else {
+		return fake.explainInputMappingReturns.result1, fake.explainInputMappingReturns.result2
+	}
+}
+
+func (fake *FakeBuildScheduler) ExplainInputMappingCallCount() int {
+	fake.explainInputMappingMutex.RLock()
+	defer fake.explainInputMappingMutex.RUnlock()
+	return len(fake.explainInputMappingArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) ExplainInputMappingArgsForCall(i int) (lager.Logger, atc.JobConfig) {
+	fake.explainInputMappingMutex.RLock()
+	defer fake.explainInputMappingMutex.RUnlock()
+	return fake.explainInputMappingArgsForCall[i].logger, fake.explainInputMappingArgsForCall[i].job
+}
+
+func (fake *FakeBuildScheduler) ExplainInputMappingReturns(result1 algorithm.Explanation, result2 error) {
+	fake.ExplainInputMappingStub = nil
+	fake.explainInputMappingReturns = struct {
+		result1 algorithm.Explanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -166,8 +272,12 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.scheduleMutex.RUnlock()
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
+	fake.triggerWithInputsMutex.RLock()
+	defer fake.triggerWithInputsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainInputMappingMutex.RLock()
+	defer fake.explainInputMappingMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -55,6 +55,16 @@ type FakeSchedulerDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateManualJobBuildStub        func(job string, inputMapping algorithm.InputMapping) (db.Build, error)
+	createManualJobBuildMutex       sync.RWMutex
+	createManualJobBuildArgsForCall []struct {
+		job          string
+		inputMapping algorithm.InputMapping
+	}
+	createManualJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -228,6 +238,41 @@ func (fake *FakeSchedulerDB) CreateJobBuildReturns(result1 db.Build, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeSchedulerDB) CreateManualJobBuild(job string, inputMapping algorithm.InputMapping) (db.Build, error) {
+	fake.createManualJobBuildMutex.Lock()
+	fake.createManualJobBuildArgsForCall = append(fake.createManualJobBuildArgsForCall, struct {
+		job          string
+		inputMapping algorithm.InputMapping
+	}{job, inputMapping})
+	fake.recordInvocation("CreateManualJobBuild", []interface{}{job, inputMapping})
+	fake.createManualJobBuildMutex.Unlock()
+	if fake.CreateManualJobBuildStub != nil {
+		return fake.CreateManualJobBuildStub(job, inputMapping)
+	} else {
+		return fake.createManualJobBuildReturns.result1, fake.createManualJobBuildReturns.result2
+	}
+}
+
+func (fake *FakeSchedulerDB) CreateManualJobBuildCallCount() int {
+	fake.createManualJobBuildMutex.RLock()
+	defer fake.createManualJobBuildMutex.RUnlock()
+	return len(fake.createManualJobBuildArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) CreateManualJobBuildArgsForCall(i int) (string, algorithm.InputMapping) {
+	fake.createManualJobBuildMutex.RLock()
+	defer fake.createManualJobBuildMutex.RUnlock()
+	return fake.createManualJobBuildArgsForCall[i].job, fake.createManualJobBuildArgsForCall[i].inputMapping
+}
+
+func (fake *FakeSchedulerDB) CreateManualJobBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateManualJobBuildStub = nil
+	fake.createManualJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSchedulerDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
@@ -311,6 +356,8 @@ func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	defer fake.getConfigMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createManualJobBuildMutex.RLock()
+	defer fake.createManualJobBuildMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.leaseResourceCheckingForJobMutex.RLock()
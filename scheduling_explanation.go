@@ -0,0 +1,38 @@
+package atc
+
+// SchedulingExplanation describes why a job's inputs can or cannot be
+// resolved to a set of versions.
+type SchedulingExplanation struct {
+	Resolved  bool                 `json:"resolved"`
+	Reason    string               `json:"reason,omitempty"`
+	Inputs    []InputExplanation   `json:"inputs"`
+	Conflicts []ConstraintConflict `json:"conflicts,omitempty"`
+}
+
+type InputExplanation struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+
+	Versions   int                 `json:"versions"`
+	Candidates int                 `json:"candidates"`
+	Passed     []PassedExplanation `json:"passed,omitempty"`
+
+	PinnedVersionID       int  `json:"pinned_version_id,omitempty"`
+	PinnedVersionExcluded bool `json:"pinned_version_excluded,omitempty"`
+	PinnedVersionNotFound bool `json:"pinned_version_not_found,omitempty"`
+
+	DisabledVersionIDs []int `json:"disabled_version_ids,omitempty"`
+}
+
+type PassedExplanation struct {
+	Job                  string `json:"job"`
+	Versions             int    `json:"versions"`
+	EliminatedVersionIDs []int  `json:"eliminated_version_ids,omitempty"`
+}
+
+// ConstraintConflict is a job which the inputs must all have passed, but
+// for which they have no build in common.
+type ConstraintConflict struct {
+	Job    string   `json:"job"`
+	Inputs []string `json:"inputs"`
+}
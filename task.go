@@ -31,11 +31,42 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Directories whose contents are kept on the worker between builds of the
+	// job.
+	Caches []TaskCacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Resource limits for the task's container.
+	Limits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+// ContainerLimits constrains the resources available to a container. A zero
+// value leaves the resource unlimited.
+type ContainerLimits struct {
+	// CPU shares, relative to other containers on the worker.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory in bytes.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Merge returns the limits, with any limits set in other taking precedence.
+func (limits ContainerLimits) Merge(other ContainerLimits) ContainerLimits {
+	if other.CPU != 0 {
+		limits.CPU = other.CPU
+	}
+
+	if other.Memory != 0 {
+		limits.Memory = other.Memory
+	}
+
+	return limits
 }
 
 type ImageResource struct {
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 }
 
 func LoadTaskConfig(configBytes []byte) (TaskConfig, error) {
@@ -110,6 +141,16 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.Limits != nil {
+		var limits ContainerLimits
+		if config.Limits != nil {
+			limits = *config.Limits
+		}
+
+		limits = limits.Merge(*other.Limits)
+		config.Limits = &limits
+	}
+
 	return config
 }
 
@@ -140,6 +181,7 @@ func (config TaskConfig) validateInputsAndOutputs() []string {
 	messages = append(messages, config.validateOutputContainsNames()...)
 	messages = append(messages, config.validateDotPath()...)
 	messages = append(messages, config.validateOverlappingPaths()...)
+	messages = append(messages, config.validateCachePaths()...)
 
 	return messages
 }
@@ -327,6 +369,23 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCachePaths() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		path := strings.TrimPrefix(cache.Path, "./")
+
+		switch {
+		case path == "":
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+		case path == "." || filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), ".."):
+			messages = append(messages, fmt.Sprintf("  cache path '%s' must be a subdirectory of the working directory", cache.Path))
+		}
+	}
+
+	return messages
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -339,6 +398,10 @@ type TaskRunConfig struct {
 type TaskInputConfig struct {
 	Name string `json:"name" yaml:"name"`
 	Path string `json:"path,omitempty" yaml:"path"`
+
+	// Optional inputs are not mounted if no artifact is provided for them,
+	// rather than failing the step.
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
 }
 
 func (input TaskInputConfig) resolvePath() string {
@@ -360,6 +423,10 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+type TaskCacheConfig struct {
+	Path string `json:"path,omitempty" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
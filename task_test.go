@@ -234,6 +234,45 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, TaskCacheConfig{Path: "node_modules"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when cache.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, TaskCacheConfig{Path: "node_modules"}, TaskCacheConfig{Path: ""})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+
+			Context("when cache.path is outside of the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(
+						invalidConfig.Caches,
+						TaskCacheConfig{Path: "."},
+						TaskCacheConfig{Path: "../elsewhere"},
+						TaskCacheConfig{Path: "/root/.cache"},
+					)
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+
+					Expect(err).To(MatchError(ContainSubstring("  cache path '.' must be a subdirectory of the working directory")))
+					Expect(err).To(MatchError(ContainSubstring("  cache path '../elsewhere' must be a subdirectory of the working directory")))
+					Expect(err).To(MatchError(ContainSubstring("  cache path '/root/.cache' must be a subdirectory of the working directory")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -723,5 +762,18 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("overrides the container limits that are set", func() {
+			Expect(TaskConfig{
+				Limits: &ContainerLimits{CPU: 512, Memory: 1024},
+			}.Merge(TaskConfig{
+				Limits: &ContainerLimits{Memory: 2048},
+			})).To(
+
+				Equal(TaskConfig{
+					Limits: &ContainerLimits{CPU: 512, Memory: 2048},
+				}))
+
+		})
 	})
 })
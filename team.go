@@ -11,6 +11,9 @@ type Team struct {
 	BasicAuth  *BasicAuth  `json:"basic_auth,omitempty"`
 	GitHubAuth *GitHubAuth `json:"github_auth,omitempty"`
 	UAAAuth    *UAAAuth    `json:"uaa_auth,omitempty"`
+
+	// ContainerLimits are the default limits for the team's task containers
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
 }
 
 type BasicAuth struct {
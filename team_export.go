@@ -0,0 +1,48 @@
+package atc
+
+import "github.com/concourse/atc/template"
+
+// TeamExport is a snapshot of all of a team's pipelines, in order, which can
+// be imported to recreate them.
+type TeamExport struct {
+	Pipelines []ExportedPipeline `json:"pipelines"`
+}
+
+type ExportedPipeline struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Config       Config       `json:"config"`
+
+	// Template and Vars are the config as it was submitted, before
+	// interpolation, and the vars it was interpolated with
+	Template RawConfig          `json:"template,omitempty"`
+	Vars     template.Variables `json:"vars,omitempty"`
+
+	// Resources records the versions that were pinned or disabled by hand
+	Resources []ExportedResource `json:"resources,omitempty"`
+}
+
+func (pipeline ExportedPipeline) Ref() PipelineRef {
+	return PipelineRef{
+		Name:         pipeline.Name,
+		InstanceVars: pipeline.InstanceVars,
+	}
+}
+
+type ExportedResource struct {
+	Name             string    `json:"name"`
+	PinnedVersion    Version   `json:"pinned_version,omitempty"`
+	PinComment       string    `json:"pin_comment,omitempty"`
+	DisabledVersions []Version `json:"disabled_versions,omitempty"`
+}
+
+// TeamImportResponse reports the outcome of importing a TeamExport. When
+// there are conflicts or errors, nothing is imported.
+type TeamImportResponse struct {
+	DryRun    bool          `json:"dry_run"`
+	Conflicts []PipelineRef `json:"conflicts,omitempty"`
+	Errors    []string      `json:"errors,omitempty"`
+	Warnings  []string      `json:"warnings,omitempty"`
+}
@@ -0,0 +1,183 @@
+package template
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\(\(([-/\.:\w\pL]+)\)\)`)
+
+// Variables are the values that ((name)) placeholders in a config are
+// replaced with.
+type Variables map[string]interface{}
+
+// Evaluate walks a tree of maps, slices, and scalars, as produced by decoding
+// JSON or YAML, and returns a copy with every placeholder that has a
+// corresponding variable replaced by its value.
+//
+// A string consisting of nothing but a placeholder is replaced by the value
+// itself, so that e.g. a number or a map can be templated in. Placeholders
+// embedded in a larger string are replaced by the value's string form.
+//
+// Placeholders with no corresponding variable are left untouched.
+func (vars Variables) Evaluate(obj interface{}) interface{} {
+	evaluated, _ := Resolve(obj, vars.lookup)
+	return evaluated
+}
+
+func (vars Variables) lookup(name string) (interface{}, bool, error) {
+	val, found := vars[name]
+	return val, found, nil
+}
+
+// Lookup finds the value of the named placeholder.
+type Lookup func(name string) (interface{}, bool, error)
+
+// Resolve is like Evaluate, but looks up each placeholder's value using the
+// given function. The first error returned by the lookup aborts resolution.
+func Resolve(obj interface{}, lookup Lookup) (interface{}, error) {
+	switch val := obj.(type) {
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			r, err := Resolve(v, lookup)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved[k] = r
+		}
+
+		return resolved, nil
+
+	case map[interface{}]interface{}:
+		resolved := make(map[interface{}]interface{}, len(val))
+		for k, v := range val {
+			r, err := Resolve(v, lookup)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved[k] = r
+		}
+
+		return resolved, nil
+
+	case []interface{}:
+		resolved := make([]interface{}, len(val))
+		for i, v := range val {
+			r, err := Resolve(v, lookup)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved[i] = r
+		}
+
+		return resolved, nil
+
+	case string:
+		return resolveString(val, lookup)
+
+	default:
+		return obj, nil
+	}
+}
+
+func resolveString(str string, lookup Lookup) (interface{}, error) {
+	match := placeholderRegexp.FindStringSubmatch(str)
+	if match == nil {
+		return str, nil
+	}
+
+	if match[0] == str {
+		val, found, err := lookup(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return str, nil
+		}
+
+		return val, nil
+	}
+
+	var lookupErr error
+
+	resolved := placeholderRegexp.ReplaceAllStringFunc(str, func(placeholder string) string {
+		if lookupErr != nil {
+			return placeholder
+		}
+
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+
+		val, found, err := lookup(name)
+		if err != nil {
+			lookupErr = err
+			return placeholder
+		}
+
+		if !found {
+			return placeholder
+		}
+
+		if s, ok := val.(string); ok {
+			return s
+		}
+
+		payload, err := json.Marshal(val)
+		if err != nil {
+			return placeholder
+		}
+
+		return string(payload)
+	})
+
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+
+	return resolved, nil
+}
+
+// Placeholders returns the sorted names of all placeholders remaining in the
+// given tree.
+func Placeholders(obj interface{}) []string {
+	found := map[string]bool{}
+	collectPlaceholders(obj, found)
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func collectPlaceholders(obj interface{}, found map[string]bool) {
+	switch val := obj.(type) {
+	case map[string]interface{}:
+		for _, v := range val {
+			collectPlaceholders(v, found)
+		}
+
+	case map[interface{}]interface{}:
+		for _, v := range val {
+			collectPlaceholders(v, found)
+		}
+
+	case []interface{}:
+		for _, v := range val {
+			collectPlaceholders(v, found)
+		}
+
+	case string:
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(val, -1) {
+			found[match[1]] = true
+		}
+	}
+}
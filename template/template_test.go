@@ -0,0 +1,149 @@
+package template_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	var vars template.Variables
+
+	BeforeEach(func() {
+		vars = template.Variables{
+			"name":     "some-name",
+			"number":   42,
+			"nested":   map[string]interface{}{"key": "value"},
+			"with.dot": "dotted",
+		}
+	})
+
+	Describe("Evaluate", func() {
+		It("replaces placeholders that make up an entire string with the value", func() {
+			Expect(vars.Evaluate(map[string]interface{}{
+				"a": "((name))",
+				"b": "((number))",
+				"c": "((nested))",
+			})).To(Equal(map[string]interface{}{
+				"a": "some-name",
+				"b": 42,
+				"c": map[string]interface{}{"key": "value"},
+			}))
+		})
+
+		It("interpolates placeholders embedded in a larger string", func() {
+			Expect(vars.Evaluate("hello ((name)), you are ((number)) and ((with.dot))")).To(Equal(
+				"hello some-name, you are 42 and dotted",
+			))
+		})
+
+		It("marshals non-string values embedded in a larger string as JSON", func() {
+			Expect(vars.Evaluate("value: ((nested))")).To(Equal(`value: {"key":"value"}`))
+		})
+
+		It("walks nested maps and slices, including those decoded from YAML", func() {
+			Expect(vars.Evaluate(map[interface{}]interface{}{
+				"list": []interface{}{"((name))", 1, true},
+				"map": map[interface{}]interface{}{
+					"key": "((number))",
+				},
+			})).To(Equal(map[interface{}]interface{}{
+				"list": []interface{}{"some-name", 1, true},
+				"map": map[interface{}]interface{}{
+					"key": 42,
+				},
+			}))
+		})
+
+		It("leaves placeholders with no corresponding variable untouched", func() {
+			Expect(vars.Evaluate([]interface{}{
+				"((missing))",
+				"((name)) and ((missing))",
+			})).To(Equal([]interface{}{
+				"((missing))",
+				"some-name and ((missing))",
+			}))
+		})
+
+		It("does not modify the original tree", func() {
+			original := map[string]interface{}{"a": "((name))"}
+			vars.Evaluate(original)
+			Expect(original).To(Equal(map[string]interface{}{"a": "((name))"}))
+		})
+	})
+
+	Describe("Resolve", func() {
+		var lookedUp []string
+		var lookupErr error
+
+		lookup := func(name string) (interface{}, bool, error) {
+			lookedUp = append(lookedUp, name)
+
+			if lookupErr != nil {
+				return nil, false, lookupErr
+			}
+
+			if name == "missing" {
+				return nil, false, nil
+			}
+
+			return "resolved-" + name, true, nil
+		}
+
+		BeforeEach(func() {
+			lookedUp = nil
+			lookupErr = nil
+		})
+
+		It("replaces placeholders with the looked-up values", func() {
+			resolved, err := template.Resolve(map[string]interface{}{
+				"a": "((foo))",
+				"b": []interface{}{"x-((bar))-y", "((missing))"},
+			}, lookup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(Equal(map[string]interface{}{
+				"a": "resolved-foo",
+				"b": []interface{}{"x-resolved-bar-y", "((missing))"},
+			}))
+		})
+
+		Context("when the lookup fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				lookupErr = disaster
+			})
+
+			It("returns the error", func() {
+				_, err := template.Resolve([]interface{}{"((foo))"}, lookup)
+				Expect(err).To(Equal(disaster))
+
+				_, err = template.Resolve("embedded ((foo)) and ((bar))", lookup)
+				Expect(err).To(Equal(disaster))
+				Expect(lookedUp).To(Equal([]string{"foo", "foo"}))
+			})
+		})
+	})
+
+	Describe("Placeholders", func() {
+		It("returns the sorted, unique names of every placeholder in the tree", func() {
+			Expect(template.Placeholders(map[string]interface{}{
+				"a": "((foo))",
+				"b": []interface{}{"((bar)) and ((foo))"},
+				"c": map[interface{}]interface{}{"d": "((baz))"},
+				"e": 42,
+			})).To(Equal([]string{"bar", "baz", "foo"}))
+		})
+
+		It("includes placeholders for local vars", func() {
+			Expect(template.Placeholders("((.:some-var))")).To(Equal([]string{".:some-var"}))
+		})
+
+		It("returns an empty list when there are none", func() {
+			Expect(template.Placeholders(map[string]interface{}{"a": "b"})).To(BeEmpty())
+		})
+	})
+})
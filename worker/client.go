@@ -34,6 +34,7 @@ type Client interface {
 	CreateVolume(logger lager.Logger, vs VolumeSpec, teamID int) (Volume, error)
 	ListVolumes(lager.Logger, VolumeProperties) ([]Volume, error)
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
+	SaveTaskCache(lager.Logger, TaskCacheStrategy, Volume) error
 
 	Satisfying(WorkerSpec, atc.ResourceTypes) (Worker, error)
 	AllSatisfying(WorkerSpec, atc.ResourceTypes) ([]Worker, error)
@@ -151,6 +152,30 @@ func (strategy HostRootFSStrategy) dbIdentifier() db.VolumeIdentifier {
 	}
 }
 
+type TaskCacheStrategy struct {
+	WorkerName string
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
+func (strategy TaskCacheStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			WorkerName: strategy.WorkerName,
+			PipelineID: strategy.PipelineID,
+			JobName:    strategy.JobName,
+			StepName:   strategy.StepName,
+			Path:       strategy.Path,
+		},
+	}
+}
+
 //go:generate counterfeiter . Container
 
 type Container interface {
@@ -31,6 +31,10 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Resource limits for the container. Zero values leave the resource
+	// unlimited.
+	Limits atc.ContainerLimits
 }
 
 type ImageSpec struct {
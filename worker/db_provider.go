@@ -31,6 +31,7 @@ type WorkerDB interface {
 
 	InsertVolume(db.Volume) error
 	GetVolumesByIdentifier(db.VolumeIdentifier) ([]db.SavedVolume, error)
+	SetTaskCacheVolume(handle string, id db.TaskCacheIdentifier) error
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
 	ReapVolume(handle string) error
 	SetVolumeTTL(string, time.Duration) error
@@ -52,6 +52,7 @@ func (i *image) Fetch() (worker.Volume, io.ReadCloser, atc.Version, error) {
 		Type:    resource.ResourceType(i.imageResource.Type),
 		Version: version,
 		Source:  i.imageResource.Source,
+		Params:  i.imageResource.Params,
 	}
 
 	volumeID := cacheID.VolumeIdentifier()
@@ -78,6 +79,7 @@ func (i *image) Fetch() (worker.Volume, io.ReadCloser, atc.Version, error) {
 	resourceOptions := &imageResource{
 		imageFetchingDelegate: i.imageFetchingDelegate,
 		source:                i.imageResource.Source,
+		params:                i.imageResource.Params,
 		version:               version,
 		resourceType:          resourceType,
 	}
@@ -186,12 +188,14 @@ type leaseID struct {
 	Type       resource.ResourceType `json:"type"`
 	Version    atc.Version           `json:"version"`
 	Source     atc.Source            `json:"source"`
+	Params     atc.Params            `json:"params,omitempty"`
 	WorkerName string                `json:"worker_name"`
 }
 
 type imageResource struct {
 	imageFetchingDelegate worker.ImageFetchingDelegate
 	source                atc.Source
+	params                atc.Params
 	version               atc.Version
 	resourceType          resource.ResourceType
 }
@@ -207,7 +211,7 @@ func (ir *imageResource) Source() atc.Source {
 }
 
 func (ir *imageResource) Params() atc.Params {
-	return nil
+	return ir.params
 }
 
 func (ir *imageResource) Version() atc.Version {
@@ -223,6 +227,7 @@ func (ir *imageResource) LeaseName(workerName string) (string, error) {
 		Type:       ir.resourceType,
 		Version:    ir.version,
 		Source:     ir.source,
+		Params:     ir.params,
 		WorkerName: workerName,
 	}
 
@@ -340,6 +340,24 @@ var _ = Describe("Image", func() {
 							Expect(resourceOptions.LeaseName("fake-worker-name")).To(Equal(expectedLeaseName))
 						})
 
+						Context("when the image resource has params", func() {
+							BeforeEach(func() {
+								imageResource.Params = atc.Params{"some": "params"}
+							})
+
+							It("fetches the image with the params", func() {
+								Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+								_, _, _, _, _, cacheID, _, _, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+								Expect(cacheID).To(Equal(resource.ResourceCacheIdentifier{
+									Type:    "docker",
+									Version: atc.Version{"v": "1"},
+									Source:  atc.Source{"some": "source"},
+									Params:  atc.Params{"some": "params"},
+								}))
+								Expect(resourceOptions.Params()).To(Equal(atc.Params{"some": "params"}))
+							})
+						})
+
 						It("gets the volume", func() {
 							Expect(fakeVersionedSource.VolumeCallCount()).To(Equal(1))
 						})
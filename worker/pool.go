@@ -254,6 +254,10 @@ func (*pool) LookupVolume(lager.Logger, string) (Volume, bool, error) {
 	return nil, false, errors.New("LookupVolume not implemented for pool")
 }
 
+func (*pool) SaveTaskCache(lager.Logger, TaskCacheStrategy, Volume) error {
+	return errors.New("SaveTaskCache not implemented for pool")
+}
+
 func (pool *pool) Name() string {
 	return "pool"
 }
@@ -15,6 +15,7 @@ type VolumeClient interface {
 	CreateVolume(logger lager.Logger, vs VolumeSpec, teamID int) (Volume, error)
 	ListVolumes(lager.Logger, VolumeProperties) ([]Volume, error)
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
+	SaveTaskCache(lager.Logger, TaskCacheStrategy, Volume) error
 }
 
 var ErrVolumeExpiredImmediately = errors.New("volume expired immediately after saving")
@@ -59,7 +60,12 @@ func (c *volumeClient) FindVolume(
 	}
 
 	var savedVolume db.SavedVolume
-	if len(savedVolumes) == 1 {
+	if _, isTaskCache := volumeSpec.Strategy.(TaskCacheStrategy); isTaskCache {
+		// task caches are replaced by saving newer volumes, which are ordered
+		// last; the older ones are left for the baggage collector, as running
+		// builds may still be using them
+		savedVolume = savedVolumes[len(savedVolumes)-1]
+	} else if len(savedVolumes) == 1 {
 		savedVolume = savedVolumes[0]
 	} else {
 		savedVolume, err = c.selectLowestAlphabeticalVolume(logger, savedVolumes)
@@ -148,6 +154,22 @@ func (c *volumeClient) ListVolumes(logger lager.Logger, properties VolumePropert
 	return volumes, nil
 }
 
+// SaveTaskCache makes the volume the cache for the strategy's task cache, so
+// that later builds find it instead of the cache they were started with.
+func (c *volumeClient) SaveTaskCache(logger lager.Logger, strategy TaskCacheStrategy, volume Volume) error {
+	if c.baggageclaimClient == nil {
+		return ErrNoVolumeManager
+	}
+
+	err := c.db.SetTaskCacheVolume(volume.Handle(), *strategy.dbIdentifier().TaskCache)
+	if err != nil {
+		logger.Error("failed-to-save-task-cache", err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume, bool, error) {
 	if c.baggageclaimClient == nil {
 		return nil, false, nil
@@ -49,20 +49,26 @@ var _ = Describe("VolumeClient", func() {
 
 	Describe("FindVolume", func() {
 		var (
+			volumeSpec worker.VolumeSpec
+
 			foundVolume worker.Volume
 			found       bool
 			err         error
 		)
 
-		JustBeforeEach(func() {
+		BeforeEach(func() {
 			version := "some-version"
-			foundVolume, found, err = volumeClient.FindVolume(testLogger, worker.VolumeSpec{
+			volumeSpec = worker.VolumeSpec{
 				Strategy: worker.HostRootFSStrategy{
 					Path:       "/some/path",
 					WorkerName: "worker-name",
 					Version:    &version,
 				},
-			})
+			}
+		})
+
+		JustBeforeEach(func() {
+			foundVolume, found, err = volumeClient.FindVolume(testLogger, volumeSpec)
 		})
 
 		Context("when there is no baggageclaim client", func() {
@@ -222,6 +228,45 @@ var _ = Describe("VolumeClient", func() {
 			})
 		})
 
+		Context("when many task cache volumes are found in the db", func() {
+			var newestVolume *bfakes.FakeVolume
+
+			BeforeEach(func() {
+				volumeSpec = worker.VolumeSpec{
+					Strategy: worker.TaskCacheStrategy{
+						WorkerName: "some-worker",
+						PipelineID: 7,
+						JobName:    "some-job",
+						StepName:   "some-step",
+						Path:       "some/path",
+					},
+				}
+
+				fakeGardenWorkerDB.GetVolumesByIdentifierReturns([]db.SavedVolume{
+					{ID: 1, Volume: db.Volume{Handle: "older-cache-handle"}},
+					{ID: 2, Volume: db.Volume{Handle: "newest-cache-handle"}},
+				}, nil)
+
+				newestVolume = new(bfakes.FakeVolume)
+				fakeBaggageclaimClient.LookupVolumeReturns(newestVolume, true, nil)
+
+				fakeVolumeFactory.BuildReturns(new(wfakes.FakeVolume), true, nil)
+			})
+
+			It("finds the newest cache", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(fakeBaggageclaimClient.LookupVolumeCallCount()).To(Equal(1))
+				_, actualHandle := fakeBaggageclaimClient.LookupVolumeArgsForCall(0)
+				Expect(actualHandle).To(Equal("newest-cache-handle"))
+			})
+
+			It("leaves the older caches to be expired by the baggage collector", func() {
+				Expect(fakeVolumeFactory.BuildCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the volume is found in the db", func() {
 			BeforeEach(func() {
 				fakeGardenWorkerDB.GetVolumesByIdentifierReturns([]db.SavedVolume{
@@ -347,6 +392,71 @@ var _ = Describe("VolumeClient", func() {
 		})
 	})
 
+	Describe("SaveTaskCache", func() {
+		var (
+			fakeVolume *wfakes.FakeVolume
+			saveErr    error
+		)
+
+		BeforeEach(func() {
+			fakeVolume = new(wfakes.FakeVolume)
+			fakeVolume.HandleReturns("new-cache-handle")
+		})
+
+		JustBeforeEach(func() {
+			saveErr = volumeClient.SaveTaskCache(testLogger, worker.TaskCacheStrategy{
+				WorkerName: "some-worker",
+				PipelineID: 7,
+				JobName:    "some-job",
+				StepName:   "some-step",
+				Path:       "some/path",
+			}, fakeVolume)
+		})
+
+		It("makes the volume the task cache", func() {
+			Expect(saveErr).NotTo(HaveOccurred())
+
+			Expect(fakeGardenWorkerDB.SetTaskCacheVolumeCallCount()).To(Equal(1))
+			handle, id := fakeGardenWorkerDB.SetTaskCacheVolumeArgsForCall(0)
+			Expect(handle).To(Equal("new-cache-handle"))
+			Expect(id).To(Equal(db.TaskCacheIdentifier{
+				WorkerName: "some-worker",
+				PipelineID: 7,
+				JobName:    "some-job",
+				StepName:   "some-step",
+				Path:       "some/path",
+			}))
+		})
+
+		Context("when saving the task cache fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeGardenWorkerDB.SetTaskCacheVolumeReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(saveErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when there is no baggageclaim client", func() {
+			BeforeEach(func() {
+				volumeClient = worker.NewVolumeClient(
+					nil,
+					fakeGardenWorkerDB,
+					fakeVolumeFactory,
+					workerName,
+				)
+			})
+
+			It("returns ErrNoVolumeManager", func() {
+				Expect(saveErr).To(Equal(worker.ErrNoVolumeManager))
+				Expect(fakeGardenWorkerDB.SetTaskCacheVolumeCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("CreateVolume", func() {
 		var baggageclaimClient baggageclaim.Client
 
@@ -70,6 +70,7 @@ type GardenWorkerDB interface {
 	SetVolumeTTL(string, time.Duration) error
 	GetVolumeTTL(string) (time.Duration, bool, error)
 	GetVolumesByIdentifier(db.VolumeIdentifier) ([]db.SavedVolume, error)
+	SetTaskCacheVolume(handle string, id db.TaskCacheIdentifier) error
 }
 
 type gardenWorker struct {
@@ -166,6 +167,10 @@ func (worker *gardenWorker) LookupVolume(logger lager.Logger, handle string) (Vo
 	return worker.volumeClient.LookupVolume(logger, handle)
 }
 
+func (worker *gardenWorker) SaveTaskCache(logger lager.Logger, strategy TaskCacheStrategy, volume Volume) error {
+	return worker.volumeClient.SaveTaskCache(logger, strategy, volume)
+}
+
 func (worker *gardenWorker) getImage(
 	logger lager.Logger,
 	imageSpec ImageSpec,
@@ -185,6 +190,7 @@ func (worker *gardenWorker) getImage(
 			imageResource = &atc.ImageResource{
 				Source: resourceType.Source,
 				Type:   resourceType.Type,
+				Params: resourceType.Params,
 			}
 		}
 	}
@@ -428,6 +434,10 @@ func (worker *gardenWorker) CreateContainer(
 		Properties: gardenProperties,
 		RootFSPath: imageURL,
 		Env:        env,
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{LimitInShares: spec.Limits.CPU},
+			Memory: garden.MemoryLimits{LimitInBytes: spec.Limits.Memory},
+		},
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
@@ -438,6 +448,8 @@ func (worker *gardenWorker) CreateContainer(
 	metadata.WorkerName = worker.name
 	metadata.Handle = gardenContainer.Handle()
 	metadata.User = gardenSpec.Properties["user"]
+	metadata.CPULimit = spec.Limits.CPU
+	metadata.MemoryLimit = spec.Limits.Memory
 
 	id.ResourceTypeVersion = resourceTypeVersion
 
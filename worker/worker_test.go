@@ -145,6 +145,7 @@ var _ = Describe("Worker", func() {
 					Name:   "custom-type-a",
 					Type:   "some-resource",
 					Source: atc.Source{"some": "source"},
+					Params: atc.Params{"some": "params"},
 				},
 				{
 					Name:   "custom-type-c",
@@ -215,6 +216,32 @@ var _ = Describe("Worker", func() {
 			Expect(volumeHandles).To(BeEmpty())
 		})
 
+		Context("when the spec specifies limits", func() {
+			BeforeEach(func() {
+				containerSpec.Limits = atc.ContainerLimits{
+					CPU:    512,
+					Memory: 1024,
+				}
+			})
+
+			It("tries to create a container in garden with the limits", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+				actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				}))
+			})
+
+			It("records the limits on the container in the db", func() {
+				Expect(fakeGardenWorkerDB.CreateContainerCallCount()).To(Equal(1))
+				c, _, _, _ := fakeGardenWorkerDB.CreateContainerArgsForCall(0)
+				Expect(c.CPULimit).To(Equal(uint64(512)))
+				Expect(c.MemoryLimit).To(Equal(uint64(1024)))
+			})
+		})
+
 		Context("when the spec does not specify ImageURL", func() {
 			BeforeEach(func() {
 				containerSpec.ImageSpec.ImageURL = ""
@@ -738,6 +765,7 @@ var _ = Describe("Worker", func() {
 				Expect(fetchImageConfig).To(Equal(atc.ImageResource{
 					Type:   "some-resource",
 					Source: atc.Source{"some": "source"},
+					Params: atc.Params{"some": "params"},
 				}))
 				Expect(fetchSignals).To(Equal(signals))
 				Expect(fetchID).To(Equal(containerID))
@@ -100,6 +100,16 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	SaveTaskCacheStub        func(arg1 lager.Logger, arg2 worker.TaskCacheStrategy, arg3 worker.Volume) error
+	saveTaskCacheMutex       sync.RWMutex
+	saveTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.TaskCacheStrategy
+		arg3 worker.Volume
+	}
+	saveTaskCacheReturns struct {
+		result1 error
+	}
 	SatisfyingStub        func(worker.WorkerSpec, atc.ResourceTypes) (worker.Worker, error)
 	satisfyingMutex       sync.RWMutex
 	satisfyingArgsForCall []struct {
@@ -422,6 +432,41 @@ func (fake *FakeClient) LookupVolumeReturns(result1 worker.Volume, result2 bool,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) SaveTaskCache(arg1 lager.Logger, arg2 worker.TaskCacheStrategy, arg3 worker.Volume) error {
+	fake.saveTaskCacheMutex.Lock()
+	fake.saveTaskCacheArgsForCall = append(fake.saveTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.TaskCacheStrategy
+		arg3 worker.Volume
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SaveTaskCache", []interface{}{arg1, arg2, arg3})
+	fake.saveTaskCacheMutex.Unlock()
+	if fake.SaveTaskCacheStub != nil {
+		return fake.SaveTaskCacheStub(arg1, arg2, arg3)
+	} else {
+		return fake.saveTaskCacheReturns.result1
+	}
+}
+
+func (fake *FakeClient) SaveTaskCacheCallCount() int {
+	fake.saveTaskCacheMutex.RLock()
+	defer fake.saveTaskCacheMutex.RUnlock()
+	return len(fake.saveTaskCacheArgsForCall)
+}
+
+func (fake *FakeClient) SaveTaskCacheArgsForCall(i int) (lager.Logger, worker.TaskCacheStrategy, worker.Volume) {
+	fake.saveTaskCacheMutex.RLock()
+	defer fake.saveTaskCacheMutex.RUnlock()
+	return fake.saveTaskCacheArgsForCall[i].arg1, fake.saveTaskCacheArgsForCall[i].arg2, fake.saveTaskCacheArgsForCall[i].arg3
+}
+
+func (fake *FakeClient) SaveTaskCacheReturns(result1 error) {
+	fake.SaveTaskCacheStub = nil
+	fake.saveTaskCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Satisfying(arg1 worker.WorkerSpec, arg2 atc.ResourceTypes) (worker.Worker, error) {
 	fake.satisfyingMutex.Lock()
 	fake.satisfyingArgsForCall = append(fake.satisfyingArgsForCall, struct {
@@ -545,6 +590,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listVolumesMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.saveTaskCacheMutex.RLock()
+	defer fake.saveTaskCacheMutex.RUnlock()
 	fake.satisfyingMutex.RLock()
 	defer fake.satisfyingMutex.RUnlock()
 	fake.allSatisfyingMutex.RLock()
@@ -96,6 +96,15 @@ type FakeGardenWorkerDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	SetTaskCacheVolumeStub        func(handle string, id db.TaskCacheIdentifier) error
+	setTaskCacheVolumeMutex       sync.RWMutex
+	setTaskCacheVolumeArgsForCall []struct {
+		handle string
+		id     db.TaskCacheIdentifier
+	}
+	setTaskCacheVolumeReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -416,6 +425,40 @@ func (fake *FakeGardenWorkerDB) GetVolumesByIdentifierReturns(result1 []db.Saved
 	}{result1, result2}
 }
 
+func (fake *FakeGardenWorkerDB) SetTaskCacheVolume(handle string, id db.TaskCacheIdentifier) error {
+	fake.setTaskCacheVolumeMutex.Lock()
+	fake.setTaskCacheVolumeArgsForCall = append(fake.setTaskCacheVolumeArgsForCall, struct {
+		handle string
+		id     db.TaskCacheIdentifier
+	}{handle, id})
+	fake.recordInvocation("SetTaskCacheVolume", []interface{}{handle, id})
+	fake.setTaskCacheVolumeMutex.Unlock()
+	if fake.SetTaskCacheVolumeStub != nil {
+		return fake.SetTaskCacheVolumeStub(handle, id)
+	} else {
+		return fake.setTaskCacheVolumeReturns.result1
+	}
+}
+
+func (fake *FakeGardenWorkerDB) SetTaskCacheVolumeCallCount() int {
+	fake.setTaskCacheVolumeMutex.RLock()
+	defer fake.setTaskCacheVolumeMutex.RUnlock()
+	return len(fake.setTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakeGardenWorkerDB) SetTaskCacheVolumeArgsForCall(i int) (string, db.TaskCacheIdentifier) {
+	fake.setTaskCacheVolumeMutex.RLock()
+	defer fake.setTaskCacheVolumeMutex.RUnlock()
+	return fake.setTaskCacheVolumeArgsForCall[i].handle, fake.setTaskCacheVolumeArgsForCall[i].id
+}
+
+func (fake *FakeGardenWorkerDB) SetTaskCacheVolumeReturns(result1 error) {
+	fake.SetTaskCacheVolumeStub = nil
+	fake.setTaskCacheVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -437,6 +480,8 @@ func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.getVolumeTTLMutex.RUnlock()
 	fake.getVolumesByIdentifierMutex.RLock()
 	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	fake.setTaskCacheVolumeMutex.RLock()
+	defer fake.setTaskCacheVolumeMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -52,6 +52,16 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	SaveTaskCacheStub        func(arg1 lager.Logger, arg2 worker.TaskCacheStrategy, arg3 worker.Volume) error
+	saveTaskCacheMutex       sync.RWMutex
+	saveTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.TaskCacheStrategy
+		arg3 worker.Volume
+	}
+	saveTaskCacheReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -199,6 +209,41 @@ func (fake *FakeVolumeClient) LookupVolumeReturns(result1 worker.Volume, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) SaveTaskCache(arg1 lager.Logger, arg2 worker.TaskCacheStrategy, arg3 worker.Volume) error {
+	fake.saveTaskCacheMutex.Lock()
+	fake.saveTaskCacheArgsForCall = append(fake.saveTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.TaskCacheStrategy
+		arg3 worker.Volume
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SaveTaskCache", []interface{}{arg1, arg2, arg3})
+	fake.saveTaskCacheMutex.Unlock()
+	if fake.SaveTaskCacheStub != nil {
+		return fake.SaveTaskCacheStub(arg1, arg2, arg3)
+	} else {
+		return fake.saveTaskCacheReturns.result1
+	}
+}
+
+func (fake *FakeVolumeClient) SaveTaskCacheCallCount() int {
+	fake.saveTaskCacheMutex.RLock()
+	defer fake.saveTaskCacheMutex.RUnlock()
+	return len(fake.saveTaskCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) SaveTaskCacheArgsForCall(i int) (lager.Logger, worker.TaskCacheStrategy, worker.Volume) {
+	fake.saveTaskCacheMutex.RLock()
+	defer fake.saveTaskCacheMutex.RUnlock()
+	return fake.saveTaskCacheArgsForCall[i].arg1, fake.saveTaskCacheArgsForCall[i].arg2, fake.saveTaskCacheArgsForCall[i].arg3
+}
+
+func (fake *FakeVolumeClient) SaveTaskCacheReturns(result1 error) {
+	fake.SaveTaskCacheStub = nil
+	fake.saveTaskCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -210,6 +255,8 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.listVolumesMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.saveTaskCacheMutex.RLock()
+	defer fake.saveTaskCacheMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -104,6 +104,15 @@ type FakeWorkerDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	SetTaskCacheVolumeStub        func(handle string, id db.TaskCacheIdentifier) error
+	setTaskCacheVolumeMutex       sync.RWMutex
+	setTaskCacheVolumeArgsForCall []struct {
+		handle string
+		id     db.TaskCacheIdentifier
+	}
+	setTaskCacheVolumeReturns struct {
+		result1 error
+	}
 	GetVolumeTTLStub        func(volumeHandle string) (time.Duration, bool, error)
 	getVolumeTTLMutex       sync.RWMutex
 	getVolumeTTLArgsForCall []struct {
@@ -487,6 +496,40 @@ func (fake *FakeWorkerDB) GetVolumesByIdentifierReturns(result1 []db.SavedVolume
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerDB) SetTaskCacheVolume(handle string, id db.TaskCacheIdentifier) error {
+	fake.setTaskCacheVolumeMutex.Lock()
+	fake.setTaskCacheVolumeArgsForCall = append(fake.setTaskCacheVolumeArgsForCall, struct {
+		handle string
+		id     db.TaskCacheIdentifier
+	}{handle, id})
+	fake.recordInvocation("SetTaskCacheVolume", []interface{}{handle, id})
+	fake.setTaskCacheVolumeMutex.Unlock()
+	if fake.SetTaskCacheVolumeStub != nil {
+		return fake.SetTaskCacheVolumeStub(handle, id)
+	} else {
+		return fake.setTaskCacheVolumeReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) SetTaskCacheVolumeCallCount() int {
+	fake.setTaskCacheVolumeMutex.RLock()
+	defer fake.setTaskCacheVolumeMutex.RUnlock()
+	return len(fake.setTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakeWorkerDB) SetTaskCacheVolumeArgsForCall(i int) (string, db.TaskCacheIdentifier) {
+	fake.setTaskCacheVolumeMutex.RLock()
+	defer fake.setTaskCacheVolumeMutex.RUnlock()
+	return fake.setTaskCacheVolumeArgsForCall[i].handle, fake.setTaskCacheVolumeArgsForCall[i].id
+}
+
+func (fake *FakeWorkerDB) SetTaskCacheVolumeReturns(result1 error) {
+	fake.SetTaskCacheVolumeStub = nil
+	fake.setTaskCacheVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerDB) GetVolumeTTL(volumeHandle string) (time.Duration, bool, error) {
 	fake.getVolumeTTLMutex.Lock()
 	fake.getVolumeTTLArgsForCall = append(fake.getVolumeTTLArgsForCall, struct {
@@ -646,6 +689,8 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.insertVolumeMutex.RUnlock()
 	fake.getVolumesByIdentifierMutex.RLock()
 	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	fake.setTaskCacheVolumeMutex.RLock()
+	defer fake.setTaskCacheVolumeMutex.RUnlock()
 	fake.getVolumeTTLMutex.RLock()
 	defer fake.getVolumeTTLMutex.RUnlock()
 	fake.reapVolumeMutex.RLock()
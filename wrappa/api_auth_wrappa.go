@@ -56,6 +56,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListResourceVersions,
 			atc.ListPipelines,
 			atc.GetPipeline,
+			atc.CheckResourceWebHook,
 			atc.ListTeams:
 
 		// authenticated
@@ -71,6 +72,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RegisterWorker,
 			atc.SetLogLevel,
 			atc.SetTeam,
+			atc.LintConfig,
 			atc.WritePipe,
 			atc.ListVolumes, //teamname - what does that mean?
 			atc.GetLogLevel:
@@ -80,21 +82,32 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.CheckResource,
 			atc.CreateJobBuild,
 			atc.DeletePipeline,
+			atc.ArchivePipeline,
 			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
+			atc.UnpinResourceVersion,
 			atc.EnableResourceVersion,
 			atc.GetConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.GetConfigDiff,
+			atc.RollbackConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ExplainJobScheduling,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
 			atc.RenamePipeline,
 			atc.UnpauseJob,
+			atc.ClearJobCaches,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
 			atc.RevealPipeline,
 			atc.ConcealPipeline,
+			atc.ExportTeam,
+			atc.ImportTeam,
 			atc.SaveConfig:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
@@ -108,6 +108,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceVersions:          unauthenticated(inputHandlers[atc.ListResourceVersions]),
 				atc.ListPipelines:                 unauthenticated(inputHandlers[atc.ListPipelines]),
 				atc.GetPipeline:                   unauthenticated(inputHandlers[atc.GetPipeline]),
+				atc.CheckResourceWebHook:          unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
 				atc.ListTeams:                     unauthenticated(inputHandlers[atc.ListTeams]),
 
 				// authenticated
@@ -125,17 +126,26 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
 				atc.SetLogLevel:     authenticated(inputHandlers[atc.SetLogLevel]),
 				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
+				atc.LintConfig:      authenticated(inputHandlers[atc.LintConfig]),
 				atc.WritePipe:       authenticated(inputHandlers[atc.WritePipe]),
 
 				// authorized
 				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
 				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
 				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
+				atc.ArchivePipeline:        authorized(inputHandlers[atc.ArchivePipeline]),
 				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.PinResourceVersion:     authorized(inputHandlers[atc.PinResourceVersion]),
+				atc.UnpinResourceVersion:   authorized(inputHandlers[atc.UnpinResourceVersion]),
 				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
+				atc.ListConfigVersions:     authorized(inputHandlers[atc.ListConfigVersions]),
+				atc.GetConfigVersion:       authorized(inputHandlers[atc.GetConfigVersion]),
+				atc.GetConfigDiff:          authorized(inputHandlers[atc.GetConfigDiff]),
+				atc.RollbackConfig:         authorized(inputHandlers[atc.RollbackConfig]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
+				atc.ExplainJobScheduling:   authorized(inputHandlers[atc.ExplainJobScheduling]),
 				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
 				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
 				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
@@ -143,10 +153,13 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
 				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
 				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
+				atc.ClearJobCaches:         authorized(inputHandlers[atc.ClearJobCaches]),
 				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
 				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
 				atc.RevealPipeline:         authorized(inputHandlers[atc.RevealPipeline]),
 				atc.ConcealPipeline:        authorized(inputHandlers[atc.ConcealPipeline]),
+				atc.ExportTeam:             authorized(inputHandlers[atc.ExportTeam]),
+				atc.ImportTeam:             authorized(inputHandlers[atc.ImportTeam]),
 			}
 		})
 
@@ -26,6 +26,9 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		switch name {
 		//wrap everything that is GET or HEAD
 		case atc.GetConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.GetConfigDiff,
 			atc.ListBuilds,
 			atc.GetBuild,
 			atc.GetBuildPlan,
@@ -36,6 +39,7 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetJob,
 			atc.ListJobBuilds,
 			atc.ListJobInputs,
+			atc.ExplainJobScheduling,
 			atc.GetJobBuild,
 			atc.JobBadge,
 			atc.ListPipelines,
@@ -57,6 +61,7 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAuthMethods,
 			atc.GetAuthToken,
 			atc.ListAllPipelines,
+			atc.ExportTeam,
 			atc.ListTeams:
 			newHandler = RedirectingAPIHandler(wrappa.externalHost)
 
@@ -66,12 +71,16 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.AbortBuild,
 			atc.CreateJobBuild,
 			atc.CheckResource,
+			atc.CheckResourceWebHook,
 			atc.CreatePipe,
 			atc.RegisterWorker,
 			atc.DeletePipeline,
+			atc.ArchivePipeline,
 			atc.SaveConfig,
+			atc.RollbackConfig,
 			atc.PauseJob,
 			atc.UnpauseJob,
+			atc.ClearJobCaches,
 			atc.OrderPipelines,
 			atc.PausePipeline,
 			atc.UnpausePipeline,
@@ -80,9 +89,13 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpauseResource,
 			atc.EnableResourceVersion,
 			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
+			atc.UnpinResourceVersion,
 			atc.WritePipe,
 			atc.SetLogLevel,
 			atc.SetTeam,
+			atc.ImportTeam,
+			atc.LintConfig,
 			atc.ConcealPipeline,
 			atc.RevealPipeline:
 